@@ -0,0 +1,227 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/plgd-dev/client-application/service/config"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/client-application/service/config/grpc"
+	"github.com/plgd-dev/client-application/service/headless"
+	pkgConfig "github.com/plgd-dev/hub/v2/pkg/config"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// headlessOptions are shared by all headless commands.
+type headlessOptions struct {
+	ConfigPath   string        `long:"config" description:"yaml config file path, only clients.device and log sections are used"`
+	PSKSubjectID string        `long:"psk-subject-id" description:"subject ID of the pre-shared key, it overrides the configuration"`
+	PSK          string        `long:"psk" description:"pre-shared key, it overrides the configuration"`
+	Timeout      time.Duration `long:"timeout" description:"timeout of the operation, 0 means no timeout"`
+	Verbose      bool          `long:"verbose" description:"print logs according to the configuration, otherwise only errors are logged"`
+}
+
+type deviceCommand struct {
+	headlessOptions
+	DeviceID string `long:"device-id" required:"true" description:"device ID"`
+}
+
+type runCommand struct {
+	headlessOptions
+	Script string `long:"script" required:"true" description:"yaml or json file with operations"`
+}
+
+type applyCommand struct {
+	headlessOptions
+	Manifest string `long:"manifest" required:"true" description:"yaml or json file with the desired state of devices"`
+	DryRun   bool   `long:"dry-run" description:"print the plan without applying it"`
+}
+
+type discoverCommand struct {
+	headlessOptions
+	UseEndpoints []string `long:"use-endpoint" description:"discover device via endpoint <host>:<port>, can be repeated"`
+}
+
+// headlessCommands are the commands which run the client application in the one-shot mode without HTTP and gRPC servers.
+var headlessCommands = map[string]string{
+	"run":      "Run operations from the script",
+	"discover": "Discover devices",
+	"own":      "Own device",
+	"disown":   "Disown device",
+	"apply":    "Apply manifest",
+}
+
+func isHeadlessCommand(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	_, ok := headlessCommands[args[1]]
+	return ok
+}
+
+func (o *headlessOptions) loadConfig() (config.Config, error) {
+	var cfg config.Config
+	if o.ConfigPath == "" {
+		cfg = config.DefaultConfig(os.TempDir())
+	} else {
+		cfg = config.DefaultConfig(filepath.Dir(o.ConfigPath))
+		if err := pkgConfig.Read(o.ConfigPath, &cfg); err != nil {
+			return config.Config{}, fmt.Errorf("cannot load config: %w", err)
+		}
+	}
+	if o.PSK != "" || o.PSKSubjectID != "" {
+		cfg.Clients.Device.COAP.TLS.Authentication = configDevice.AuthenticationPreSharedKey
+		cfg.Clients.Device.COAP.TLS.PreSharedKey.SubjectIDStr = o.PSKSubjectID
+		cfg.Clients.Device.COAP.TLS.PreSharedKey.Key = o.PSK
+	}
+	if err := cfg.Log.Validate(); err != nil {
+		return config.Config{}, fmt.Errorf("invalid config: log.%w", err)
+	}
+	if !o.Verbose {
+		cfg.Log.Level = zapcore.ErrorLevel
+	}
+	return cfg, nil
+}
+
+func (o *headlessOptions) run(script *headless.Script) error {
+	if err := script.Validate(); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	cfg, err := o.loadConfig()
+	if err != nil {
+		return err
+	}
+	logger := log.NewLogger(cfg.Log)
+	log.Set(logger)
+	info := grpc.ServiceInformation{
+		Version:    Version,
+		BuildDate:  BuildDate,
+		CommitHash: CommitHash,
+		CommitDate: CommitDate,
+		ReleaseUrl: ReleaseURL,
+	}
+	ctx := context.Background()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	runner, err := headless.New(ctx, cfg, &info, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errC := runner.Close(); errC != nil {
+			log.Errorf("cannot close headless runner: %v", errC)
+		}
+	}()
+	report := runner.Run(ctx, script)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode report: %w", err)
+	}
+	fmt.Println(string(data))
+	if !report.Success {
+		return errors.New("operation failed")
+	}
+	return nil
+}
+
+func (c *runCommand) Execute([]string) error {
+	script, err := headless.LoadScript(c.Script)
+	if err != nil {
+		return err
+	}
+	return c.run(script)
+}
+
+func (c *discoverCommand) Execute([]string) error {
+	return c.run(&headless.Script{
+		Operations: []headless.Operation{{
+			Discover: &headless.DiscoverOperation{UseEndpoints: c.UseEndpoints},
+		}},
+	})
+}
+
+func (c *applyCommand) Execute([]string) error {
+	return c.run(&headless.Script{
+		Operations: []headless.Operation{{
+			ApplyManifest: &headless.ApplyManifestOperation{Manifest: c.Manifest, DryRun: c.DryRun},
+		}},
+	})
+}
+
+type ownCommand struct {
+	deviceCommand
+}
+
+func (c *ownCommand) Execute([]string) error {
+	return c.run(&headless.Script{
+		Operations: []headless.Operation{{
+			Own: &headless.DeviceOperation{DeviceID: c.DeviceID},
+		}},
+	})
+}
+
+type disownCommand struct {
+	deviceCommand
+}
+
+func (c *disownCommand) Execute([]string) error {
+	return c.run(&headless.Script{
+		Operations: []headless.Operation{{
+			Disown: &headless.DeviceOperation{DeviceID: c.DeviceID},
+		}},
+	})
+}
+
+// runHeadless executes the headless command and returns exit code.
+func runHeadless() int {
+	parser := flags.NewParser(nil, flags.HelpFlag|flags.PassDoubleDash)
+	cmds := map[string]interface{}{
+		"run":      &runCommand{},
+		"discover": &discoverCommand{},
+		"own":      &ownCommand{},
+		"disown":   &disownCommand{},
+		"apply":    &applyCommand{},
+	}
+	for name, data := range cmds {
+		if _, err := parser.AddCommand(name, headlessCommands[name], "", data); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot add command %v: %v\n", name, err)
+			return 1
+		}
+	}
+	if _, err := parser.Parse(); err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) && flagsErr.Type == flags.ErrHelp {
+			fmt.Println(err)
+			return 0
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
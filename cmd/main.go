@@ -17,13 +17,19 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/jessevdk/go-flags"
+	"github.com/plgd-dev/client-application/pkg/logbuffer"
 	service "github.com/plgd-dev/client-application/service"
 	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/config/apikey"
 	"github.com/plgd-dev/client-application/service/config/grpc"
 	"github.com/plgd-dev/hub/v2/pkg/fsnotify"
 	"github.com/plgd-dev/hub/v2/pkg/log"
@@ -37,16 +43,41 @@ var (
 	ReleaseURL = "unknown url"
 )
 
+// readAPIKey reads the API key from the first line of the reader.
+func readAPIKey(r io.Reader) (string, error) {
+	key, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	key = strings.TrimRight(key, "\r\n")
+	if key == "" {
+		return "", errors.New("key is empty")
+	}
+	return key, nil
+}
+
 func loadConfig() config.Config {
 	var opts struct {
 		Version    bool   `short:"v" long:"version" description:"version"`
 		ConfigPath string `long:"config" description:"yaml config file path"`
+		Simulate   int    `long:"simulate" description:"number of simulated devices started inside the client application, it overrides simulator.numDevices"`
+		HashAPIKey bool   `long:"hash-api-key" description:"prints the hash of the API key read from the standard input for apis.apiKeys.keys[].hash"`
 	}
 	_, _ = flags.NewParser(&opts, flags.Default|flags.IgnoreUnknown).Parse()
 	if opts.Version {
 		fmt.Println(Version)
 		os.Exit(0)
 	}
+	if opts.HashAPIKey {
+		// the key is not passed as an argument, because the arguments are visible to the other processes
+		key, err := readAPIKey(os.Stdin)
+		if err != nil {
+			log.Errorf("cannot read API key: %v", err)
+			os.Exit(1)
+		}
+		fmt.Println(apikey.Hash(key))
+		os.Exit(0)
+	}
 	if err := resolveDefaultConfig(opts.ConfigPath); err != nil {
 		log.Errorf("cannot create default config: %v", err)
 		os.Exit(1)
@@ -58,6 +89,13 @@ func loadConfig() config.Config {
 		log.Errorf("cannot load config: %v", err)
 		os.Exit(1)
 	}
+	if opts.Simulate > 0 {
+		cfg.Simulator.NumDevices = opts.Simulate
+		if err = cfg.Simulator.Validate(); err != nil {
+			log.Errorf("invalid config: simulator.%v", err)
+			os.Exit(1)
+		}
+	}
 	if _, err = os.Stat(cfg.APIs.HTTP.UI.Directory); cfg.APIs.HTTP.UI.Enabled && err != nil {
 		if err = extractUI(cfg.APIs.HTTP.UI.Directory); err != nil {
 			log.Errorf("cannot extract UI: %v", err)
@@ -82,8 +120,12 @@ func loadConfig() config.Config {
 }
 
 func main() {
+	if isHeadlessCommand(os.Args) {
+		os.Exit(runHeadless())
+	}
 	cfg := loadConfig()
-	logger := log.NewLogger(cfg.Log)
+	// recent log lines are kept in memory for the diagnostics
+	logger := logbuffer.NewLogger(log.NewLogger(cfg.Log), logbuffer.New(logbuffer.DefaultSize))
 	log.Set(logger)
 	fileWatcher, err := fsnotify.NewWatcher(logger)
 	if err != nil {
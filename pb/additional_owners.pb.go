@@ -0,0 +1,548 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: github.com/plgd-dev/client-application/pb/additional_owners.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Users identified by a claim of the JWT token who may operate the devices owned by the owner of the client application.
+type AdditionalOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Claim of the token, e.g. sub for a subject or groups for a group.
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	// Value of the claim, when the claim is an array it must contain the value.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *AdditionalOwner) Reset() {
+	*x = AdditionalOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdditionalOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalOwner) ProtoMessage() {}
+
+func (x *AdditionalOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalOwner.ProtoReflect.Descriptor instead.
+func (*AdditionalOwner) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AdditionalOwner) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *AdditionalOwner) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GetAdditionalOwnersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetAdditionalOwnersRequest) Reset() {
+	*x = GetAdditionalOwnersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAdditionalOwnersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAdditionalOwnersRequest) ProtoMessage() {}
+
+func (x *GetAdditionalOwnersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAdditionalOwnersRequest.ProtoReflect.Descriptor instead.
+func (*GetAdditionalOwnersRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{1}
+}
+
+type GetAdditionalOwnersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AdditionalOwners []*AdditionalOwner `protobuf:"bytes,1,rep,name=additional_owners,json=additionalOwners,proto3" json:"additional_owners,omitempty"`
+}
+
+func (x *GetAdditionalOwnersResponse) Reset() {
+	*x = GetAdditionalOwnersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAdditionalOwnersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAdditionalOwnersResponse) ProtoMessage() {}
+
+func (x *GetAdditionalOwnersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAdditionalOwnersResponse.ProtoReflect.Descriptor instead.
+func (*GetAdditionalOwnersResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAdditionalOwnersResponse) GetAdditionalOwners() []*AdditionalOwner {
+	if x != nil {
+		return x.AdditionalOwners
+	}
+	return nil
+}
+
+type AddAdditionalOwnerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *AddAdditionalOwnerRequest) Reset() {
+	*x = AddAdditionalOwnerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddAdditionalOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAdditionalOwnerRequest) ProtoMessage() {}
+
+func (x *AddAdditionalOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAdditionalOwnerRequest.ProtoReflect.Descriptor instead.
+func (*AddAdditionalOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddAdditionalOwnerRequest) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *AddAdditionalOwnerRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type AddAdditionalOwnerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddAdditionalOwnerResponse) Reset() {
+	*x = AddAdditionalOwnerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddAdditionalOwnerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAdditionalOwnerResponse) ProtoMessage() {}
+
+func (x *AddAdditionalOwnerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAdditionalOwnerResponse.ProtoReflect.Descriptor instead.
+func (*AddAdditionalOwnerResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{4}
+}
+
+type RemoveAdditionalOwnerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *RemoveAdditionalOwnerRequest) Reset() {
+	*x = RemoveAdditionalOwnerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveAdditionalOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAdditionalOwnerRequest) ProtoMessage() {}
+
+func (x *RemoveAdditionalOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAdditionalOwnerRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAdditionalOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveAdditionalOwnerRequest) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *RemoveAdditionalOwnerRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RemoveAdditionalOwnerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveAdditionalOwnerResponse) Reset() {
+	*x = RemoveAdditionalOwnerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveAdditionalOwnerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAdditionalOwnerResponse) ProtoMessage() {}
+
+func (x *RemoveAdditionalOwnerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAdditionalOwnerResponse.ProtoReflect.Descriptor instead.
+func (*RemoveAdditionalOwnerResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP(), []int{6}
+}
+
+var File_github_com_plgd_dev_client_application_pb_additional_owners_proto protoreflect.FileDescriptor
+
+var file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDesc = []byte{
+	0x0a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6c, 0x67,
+	0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x61, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x22,
+	0x3d, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1c,
+	0x0a, 0x1a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x67, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x11, 0x61,
+	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x52, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x47, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x41, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1c,
+	0x0a, 0x1a, 0x41, 0x64, 0x64, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x1c,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1f, 0x0a, 0x1d, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6c, 0x67, 0x64, 0x2d, 0x64, 0x65, 0x76,
+	0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescOnce sync.Once
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescData = file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDesc
+)
+
+func file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescGZIP() []byte {
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescOnce.Do(func() {
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescData)
+	})
+	return file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDescData
+}
+
+var file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_github_com_plgd_dev_client_application_pb_additional_owners_proto_goTypes = []any{
+	(*AdditionalOwner)(nil),               // 0: service.pb.AdditionalOwner
+	(*GetAdditionalOwnersRequest)(nil),    // 1: service.pb.GetAdditionalOwnersRequest
+	(*GetAdditionalOwnersResponse)(nil),   // 2: service.pb.GetAdditionalOwnersResponse
+	(*AddAdditionalOwnerRequest)(nil),     // 3: service.pb.AddAdditionalOwnerRequest
+	(*AddAdditionalOwnerResponse)(nil),    // 4: service.pb.AddAdditionalOwnerResponse
+	(*RemoveAdditionalOwnerRequest)(nil),  // 5: service.pb.RemoveAdditionalOwnerRequest
+	(*RemoveAdditionalOwnerResponse)(nil), // 6: service.pb.RemoveAdditionalOwnerResponse
+}
+var file_github_com_plgd_dev_client_application_pb_additional_owners_proto_depIdxs = []int32{
+	0, // 0: service.pb.GetAdditionalOwnersResponse.additional_owners:type_name -> service.pb.AdditionalOwner
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_github_com_plgd_dev_client_application_pb_additional_owners_proto_init() }
+func file_github_com_plgd_dev_client_application_pb_additional_owners_proto_init() {
+	if File_github_com_plgd_dev_client_application_pb_additional_owners_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*AdditionalOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAdditionalOwnersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAdditionalOwnersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*AddAdditionalOwnerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*AddAdditionalOwnerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveAdditionalOwnerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveAdditionalOwnerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_plgd_dev_client_application_pb_additional_owners_proto_goTypes,
+		DependencyIndexes: file_github_com_plgd_dev_client_application_pb_additional_owners_proto_depIdxs,
+		MessageInfos:      file_github_com_plgd_dev_client_application_pb_additional_owners_proto_msgTypes,
+	}.Build()
+	File_github_com_plgd_dev_client_application_pb_additional_owners_proto = out.File
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_rawDesc = nil
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_goTypes = nil
+	file_github_com_plgd_dev_client_application_pb_additional_owners_proto_depIdxs = nil
+}
@@ -0,0 +1,972 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: github.com/plgd-dev/client-application/pb/apply_manifest.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AccessControlManifest_Permission int32
+
+const (
+	AccessControlManifest_CREATE AccessControlManifest_Permission = 0
+	AccessControlManifest_READ   AccessControlManifest_Permission = 1
+	AccessControlManifest_WRITE  AccessControlManifest_Permission = 2
+	AccessControlManifest_DELETE AccessControlManifest_Permission = 3
+	AccessControlManifest_NOTIFY AccessControlManifest_Permission = 4
+)
+
+// Enum value maps for AccessControlManifest_Permission.
+var (
+	AccessControlManifest_Permission_name = map[int32]string{
+		0: "CREATE",
+		1: "READ",
+		2: "WRITE",
+		3: "DELETE",
+		4: "NOTIFY",
+	}
+	AccessControlManifest_Permission_value = map[string]int32{
+		"CREATE": 0,
+		"READ":   1,
+		"WRITE":  2,
+		"DELETE": 3,
+		"NOTIFY": 4,
+	}
+)
+
+func (x AccessControlManifest_Permission) Enum() *AccessControlManifest_Permission {
+	p := new(AccessControlManifest_Permission)
+	*p = x
+	return p
+}
+
+func (x AccessControlManifest_Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessControlManifest_Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[0].Descriptor()
+}
+
+func (AccessControlManifest_Permission) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[0]
+}
+
+func (x AccessControlManifest_Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessControlManifest_Permission.Descriptor instead.
+func (AccessControlManifest_Permission) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type ManifestStep_Action int32
+
+const (
+	ManifestStep_OWN                    ManifestStep_Action = 0
+	ManifestStep_DISOWN                 ManifestStep_Action = 1
+	ManifestStep_UPDATE_RESOURCE        ManifestStep_Action = 2
+	ManifestStep_UPDATE_ACCESS_CONTROLS ManifestStep_Action = 3
+	ManifestStep_OFFBOARD               ManifestStep_Action = 4
+	ManifestStep_ONBOARD                ManifestStep_Action = 5
+)
+
+// Enum value maps for ManifestStep_Action.
+var (
+	ManifestStep_Action_name = map[int32]string{
+		0: "OWN",
+		1: "DISOWN",
+		2: "UPDATE_RESOURCE",
+		3: "UPDATE_ACCESS_CONTROLS",
+		4: "OFFBOARD",
+		5: "ONBOARD",
+	}
+	ManifestStep_Action_value = map[string]int32{
+		"OWN":                    0,
+		"DISOWN":                 1,
+		"UPDATE_RESOURCE":        2,
+		"UPDATE_ACCESS_CONTROLS": 3,
+		"OFFBOARD":               4,
+		"ONBOARD":                5,
+	}
+)
+
+func (x ManifestStep_Action) Enum() *ManifestStep_Action {
+	p := new(ManifestStep_Action)
+	*p = x
+	return p
+}
+
+func (x ManifestStep_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManifestStep_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[1].Descriptor()
+}
+
+func (ManifestStep_Action) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[1]
+}
+
+func (x ManifestStep_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManifestStep_Action.Descriptor instead.
+func (ManifestStep_Action) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type ManifestStep_Status int32
+
+const (
+	ManifestStep_PLANNED ManifestStep_Status = 0
+	ManifestStep_APPLIED ManifestStep_Status = 1
+	ManifestStep_FAILED  ManifestStep_Status = 2
+	ManifestStep_SKIPPED ManifestStep_Status = 3
+)
+
+// Enum value maps for ManifestStep_Status.
+var (
+	ManifestStep_Status_name = map[int32]string{
+		0: "PLANNED",
+		1: "APPLIED",
+		2: "FAILED",
+		3: "SKIPPED",
+	}
+	ManifestStep_Status_value = map[string]int32{
+		"PLANNED": 0,
+		"APPLIED": 1,
+		"FAILED":  2,
+		"SKIPPED": 3,
+	}
+)
+
+func (x ManifestStep_Status) Enum() *ManifestStep_Status {
+	p := new(ManifestStep_Status)
+	*p = x
+	return p
+}
+
+func (x ManifestStep_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManifestStep_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[2].Descriptor()
+}
+
+func (ManifestStep_Status) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes[2]
+}
+
+func (x ManifestStep_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManifestStep_Status.Descriptor instead.
+func (ManifestStep_Status) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{5, 1}
+}
+
+// Desired value of the resource. Only the properties set in the content are compared with the live state.
+type ResourceManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Href              string          `protobuf:"bytes,1,opt,name=href,proto3" json:"href,omitempty"`
+	ResourceInterface string          `protobuf:"bytes,2,opt,name=resource_interface,json=resourceInterface,proto3" json:"resource_interface,omitempty"`
+	Content           *structpb.Value `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *ResourceManifest) Reset() {
+	*x = ResourceManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceManifest) ProtoMessage() {}
+
+func (x *ResourceManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceManifest.ProtoReflect.Descriptor instead.
+func (*ResourceManifest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResourceManifest) GetHref() string {
+	if x != nil {
+		return x.Href
+	}
+	return ""
+}
+
+func (x *ResourceManifest) GetResourceInterface() string {
+	if x != nil {
+		return x.ResourceInterface
+	}
+	return ""
+}
+
+func (x *ResourceManifest) GetContent() *structpb.Value {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Access control entry of /oic/sec/acl2. Exactly one of subject_device_id, subject_connection_type must be set.
+type AccessControlManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device ID of the subject.
+	SubjectDeviceId string `protobuf:"bytes,1,opt,name=subject_device_id,json=subjectDeviceId,proto3" json:"subject_device_id,omitempty"`
+	// Connection type of the subject: "auth-crypt" or "anon-clear".
+	SubjectConnectionType string `protobuf:"bytes,2,opt,name=subject_connection_type,json=subjectConnectionType,proto3" json:"subject_connection_type,omitempty"`
+	// Hrefs of the resources. Use "*" for all non-configuration resources.
+	Hrefs       []string                           `protobuf:"bytes,3,rep,name=hrefs,proto3" json:"hrefs,omitempty"`
+	Permissions []AccessControlManifest_Permission `protobuf:"varint,4,rep,packed,name=permissions,proto3,enum=service.pb.AccessControlManifest_Permission" json:"permissions,omitempty"`
+}
+
+func (x *AccessControlManifest) Reset() {
+	*x = AccessControlManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccessControlManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessControlManifest) ProtoMessage() {}
+
+func (x *AccessControlManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessControlManifest.ProtoReflect.Descriptor instead.
+func (*AccessControlManifest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AccessControlManifest) GetSubjectDeviceId() string {
+	if x != nil {
+		return x.SubjectDeviceId
+	}
+	return ""
+}
+
+func (x *AccessControlManifest) GetSubjectConnectionType() string {
+	if x != nil {
+		return x.SubjectConnectionType
+	}
+	return ""
+}
+
+func (x *AccessControlManifest) GetHrefs() []string {
+	if x != nil {
+		return x.Hrefs
+	}
+	return nil
+}
+
+func (x *AccessControlManifest) GetPermissions() []AccessControlManifest_Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// Hub to which the device is onboarded. The device is onboarded when coap_gateway_address and hub_id of the cloud configuration resource are equal.
+type OnboardManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// endpoint in format <scheme>://<host>:<port>
+	CoapGatewayAddress string `protobuf:"bytes,1,opt,name=coap_gateway_address,json=coapGatewayAddress,proto3" json:"coap_gateway_address,omitempty"`
+	// authorization code from the hub
+	AuthorizationCode string `protobuf:"bytes,2,opt,name=authorization_code,json=authorizationCode,proto3" json:"authorization_code,omitempty"`
+	// authorization provider from the hub
+	AuthorizationProviderName string `protobuf:"bytes,3,opt,name=authorization_provider_name,json=authorizationProviderName,proto3" json:"authorization_provider_name,omitempty"`
+	// hub id in uuid format to allow hub access to device
+	HubId string `protobuf:"bytes,4,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	// list of hub certificate authorities in PEM format to verify the hub certificate
+	CertificateAuthorities string `protobuf:"bytes,5,opt,name=certificate_authorities,json=certificateAuthorities,proto3" json:"certificate_authorities,omitempty"`
+}
+
+func (x *OnboardManifest) Reset() {
+	*x = OnboardManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnboardManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardManifest) ProtoMessage() {}
+
+func (x *OnboardManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardManifest.ProtoReflect.Descriptor instead.
+func (*OnboardManifest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OnboardManifest) GetCoapGatewayAddress() string {
+	if x != nil {
+		return x.CoapGatewayAddress
+	}
+	return ""
+}
+
+func (x *OnboardManifest) GetAuthorizationCode() string {
+	if x != nil {
+		return x.AuthorizationCode
+	}
+	return ""
+}
+
+func (x *OnboardManifest) GetAuthorizationProviderName() string {
+	if x != nil {
+		return x.AuthorizationProviderName
+	}
+	return ""
+}
+
+func (x *OnboardManifest) GetHubId() string {
+	if x != nil {
+		return x.HubId
+	}
+	return ""
+}
+
+func (x *OnboardManifest) GetCertificateAuthorities() string {
+	if x != nil {
+		return x.CertificateAuthorities
+	}
+	return ""
+}
+
+// Desired state of the device. Exactly one of device_id, type_filter must be set.
+type DeviceManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Selects the device by id.
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Selects all devices in the cache which contain one of the resource types.
+	TypeFilter []string `protobuf:"bytes,2,rep,name=type_filter,json=typeFilter,proto3" json:"type_filter,omitempty"`
+	// Device is owned by the client application. It must be set when resources, access_controls or onboard are set.
+	Owned          bool                     `protobuf:"varint,3,opt,name=owned,proto3" json:"owned,omitempty"`
+	Resources      []*ResourceManifest      `protobuf:"bytes,4,rep,name=resources,proto3" json:"resources,omitempty"`
+	AccessControls []*AccessControlManifest `protobuf:"bytes,5,rep,name=access_controls,json=accessControls,proto3" json:"access_controls,omitempty"`
+	// Device is onboarded to the hub. Default: not set - onboarding status is not changed.
+	Onboard *OnboardManifest `protobuf:"bytes,6,opt,name=onboard,proto3" json:"onboard,omitempty"`
+}
+
+func (x *DeviceManifest) Reset() {
+	*x = DeviceManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceManifest) ProtoMessage() {}
+
+func (x *DeviceManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceManifest.ProtoReflect.Descriptor instead.
+func (*DeviceManifest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeviceManifest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceManifest) GetTypeFilter() []string {
+	if x != nil {
+		return x.TypeFilter
+	}
+	return nil
+}
+
+func (x *DeviceManifest) GetOwned() bool {
+	if x != nil {
+		return x.Owned
+	}
+	return false
+}
+
+func (x *DeviceManifest) GetResources() []*ResourceManifest {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *DeviceManifest) GetAccessControls() []*AccessControlManifest {
+	if x != nil {
+		return x.AccessControls
+	}
+	return nil
+}
+
+func (x *DeviceManifest) GetOnboard() *OnboardManifest {
+	if x != nil {
+		return x.Onboard
+	}
+	return nil
+}
+
+// Applies the desired state to the devices. Only the missing steps are applied.
+type ApplyManifestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*DeviceManifest `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Returns the plan without applying it.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ApplyManifestRequest) Reset() {
+	*x = ApplyManifestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyManifestRequest) ProtoMessage() {}
+
+func (x *ApplyManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyManifestRequest.ProtoReflect.Descriptor instead.
+func (*ApplyManifestRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApplyManifestRequest) GetDevices() []*DeviceManifest {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ApplyManifestRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ManifestStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string              `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Action   ManifestStep_Action `protobuf:"varint,2,opt,name=action,proto3,enum=service.pb.ManifestStep_Action" json:"action,omitempty"`
+	// Href of the resource for UPDATE_RESOURCE and UPDATE_ACCESS_CONTROLS.
+	Href   string              `protobuf:"bytes,3,opt,name=href,proto3" json:"href,omitempty"`
+	Status ManifestStep_Status `protobuf:"varint,4,opt,name=status,proto3,enum=service.pb.ManifestStep_Status" json:"status,omitempty"`
+	// Reason why the step is needed or why it failed.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ManifestStep) Reset() {
+	*x = ManifestStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ManifestStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestStep) ProtoMessage() {}
+
+func (x *ManifestStep) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestStep.ProtoReflect.Descriptor instead.
+func (*ManifestStep) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ManifestStep) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ManifestStep) GetAction() ManifestStep_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ManifestStep_OWN
+}
+
+func (x *ManifestStep) GetHref() string {
+	if x != nil {
+		return x.Href
+	}
+	return ""
+}
+
+func (x *ManifestStep) GetStatus() ManifestStep_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ManifestStep_PLANNED
+}
+
+func (x *ManifestStep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ApplyManifestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps []*ManifestStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *ApplyManifestResponse) Reset() {
+	*x = ApplyManifestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyManifestResponse) ProtoMessage() {}
+
+func (x *ApplyManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyManifestResponse.ProtoReflect.Descriptor instead.
+func (*ApplyManifestResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ApplyManifestResponse) GetSteps() []*ManifestStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+var File_github_com_plgd_dev_client_application_pb_apply_manifest_proto protoreflect.FileDescriptor
+
+var file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDesc = []byte{
+	0x0a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6c, 0x67,
+	0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x61, 0x70, 0x70, 0x6c,
+	0x79, 0x5f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x87, 0x01, 0x0a, 0x10, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x72, 0x65, 0x66, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x22, 0xa8, 0x02, 0x0a, 0x15, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x2a,
+	0x0a, 0x11, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x72, 0x65, 0x66, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x68, 0x72, 0x65, 0x66, 0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x2c, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x45, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54,
+	0x45, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x54, 0x49, 0x46, 0x59, 0x10, 0x04, 0x22,
+	0x82, 0x02, 0x0a, 0x0f, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x61, 0x70, 0x5f, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x63, 0x6f, 0x61, 0x70, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x68, 0x75, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x75, 0x62, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x17, 0x63,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x22, 0xa3, 0x02, 0x0a, 0x0e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x09, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x4a, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x61, 0x6e, 0x69, 0x66,
+	0x65, 0x73, 0x74, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x62, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73,
+	0x74, 0x52, 0x07, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0x65, 0x0a, 0x14, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x22, 0xf3, 0x02, 0x0a, 0x0c, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x74,
+	0x65, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x37, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x74, 0x65, 0x70, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x72, 0x65, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x72, 0x65, 0x66, 0x12, 0x37, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x65, 0x70, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x69, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x49, 0x53, 0x4f, 0x57, 0x4e, 0x10, 0x01, 0x12, 0x13,
+	0x0a, 0x0f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x43,
+	0x43, 0x45, 0x53, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x53, 0x10, 0x03, 0x12,
+	0x0c, 0x0a, 0x08, 0x4f, 0x46, 0x46, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x10, 0x04, 0x12, 0x0b, 0x0a,
+	0x07, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x10, 0x05, 0x22, 0x3b, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x4c, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x50, 0x50, 0x4c, 0x49, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x4b,
+	0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x22, 0x47, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73,
+	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70,
+	0x6c, 0x67, 0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescOnce sync.Once
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescData = file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDesc
+)
+
+func file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescGZIP() []byte {
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescOnce.Do(func() {
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescData)
+	})
+	return file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDescData
+}
+
+var file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_goTypes = []any{
+	(AccessControlManifest_Permission)(0), // 0: service.pb.AccessControlManifest.Permission
+	(ManifestStep_Action)(0),              // 1: service.pb.ManifestStep.Action
+	(ManifestStep_Status)(0),              // 2: service.pb.ManifestStep.Status
+	(*ResourceManifest)(nil),              // 3: service.pb.ResourceManifest
+	(*AccessControlManifest)(nil),         // 4: service.pb.AccessControlManifest
+	(*OnboardManifest)(nil),               // 5: service.pb.OnboardManifest
+	(*DeviceManifest)(nil),                // 6: service.pb.DeviceManifest
+	(*ApplyManifestRequest)(nil),          // 7: service.pb.ApplyManifestRequest
+	(*ManifestStep)(nil),                  // 8: service.pb.ManifestStep
+	(*ApplyManifestResponse)(nil),         // 9: service.pb.ApplyManifestResponse
+	(*structpb.Value)(nil),                // 10: google.protobuf.Value
+}
+var file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_depIdxs = []int32{
+	10, // 0: service.pb.ResourceManifest.content:type_name -> google.protobuf.Value
+	0,  // 1: service.pb.AccessControlManifest.permissions:type_name -> service.pb.AccessControlManifest.Permission
+	3,  // 2: service.pb.DeviceManifest.resources:type_name -> service.pb.ResourceManifest
+	4,  // 3: service.pb.DeviceManifest.access_controls:type_name -> service.pb.AccessControlManifest
+	5,  // 4: service.pb.DeviceManifest.onboard:type_name -> service.pb.OnboardManifest
+	6,  // 5: service.pb.ApplyManifestRequest.devices:type_name -> service.pb.DeviceManifest
+	1,  // 6: service.pb.ManifestStep.action:type_name -> service.pb.ManifestStep.Action
+	2,  // 7: service.pb.ManifestStep.status:type_name -> service.pb.ManifestStep.Status
+	8,  // 8: service.pb.ApplyManifestResponse.steps:type_name -> service.pb.ManifestStep
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_init() }
+func file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_init() {
+	if File_github_com_plgd_dev_client_application_pb_apply_manifest_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ResourceManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*AccessControlManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*OnboardManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*DeviceManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ApplyManifestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ManifestStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ApplyManifestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_goTypes,
+		DependencyIndexes: file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_depIdxs,
+		EnumInfos:         file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_enumTypes,
+		MessageInfos:      file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_msgTypes,
+	}.Build()
+	File_github_com_plgd_dev_client_application_pb_apply_manifest_proto = out.File
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_rawDesc = nil
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_goTypes = nil
+	file_github_com_plgd_dev_client_application_pb_apply_manifest_proto_depIdxs = nil
+}
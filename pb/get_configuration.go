@@ -34,6 +34,17 @@ func (c *UserAgent) Clone() *UserAgent {
 	}
 }
 
+func (c *OpenIDDiscovery) Clone() *OpenIDDiscovery {
+	if c == nil {
+		return nil
+	}
+	return &OpenIDDiscovery{
+		Enabled:         c.GetEnabled(),
+		RefreshInterval: c.GetRefreshInterval(),
+		Audience:        c.GetAudience(),
+	}
+}
+
 func (c *RemoteProvisioning) Clone() *RemoteProvisioning {
 	if c == nil {
 		return nil
@@ -50,6 +61,7 @@ func (c *RemoteProvisioning) Clone() *RemoteProvisioning {
 		Authority:              c.GetAuthority(),
 		DeviceOauthClient:      c.GetDeviceOauthClient().Clone(),
 		CertificateAuthority:   c.GetCertificateAuthority(),
+		OpenIdDiscovery:        c.GetOpenIdDiscovery().Clone(),
 	}
 }
 
@@ -136,6 +148,38 @@ func (c *UserAgent) MarshalYAML() (interface{}, error) {
 	return v, nil
 }
 
+func (c *OpenIDDiscovery) Validate() error {
+	if c.GetEnabled() && c.GetRefreshInterval() <= 0 {
+		return fmt.Errorf("refreshInterval('%v')", time.Duration(c.GetRefreshInterval()))
+	}
+	return nil
+}
+
+type openIDDiscoveryYAML struct {
+	Enabled         bool          `yaml:"enabled"`
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+	Audience        string        `yaml:"audience"`
+}
+
+func (c *OpenIDDiscovery) UnmarshalYAML(value *yaml.Node) error {
+	var v openIDDiscoveryYAML
+	if err := value.Decode(&v); err != nil {
+		return fmt.Errorf("refreshInterval('%v') - %w", c.GetRefreshInterval(), err)
+	}
+	c.Enabled = v.Enabled
+	c.RefreshInterval = v.RefreshInterval.Nanoseconds()
+	c.Audience = v.Audience
+	return nil
+}
+
+func (c *OpenIDDiscovery) MarshalYAML() (interface{}, error) {
+	return openIDDiscoveryYAML{
+		Enabled:         c.GetEnabled(),
+		RefreshInterval: time.Duration(c.GetRefreshInterval()),
+		Audience:        c.GetAudience(),
+	}, nil
+}
+
 func (c RemoteProvisioning_Mode) MarshalYAML() (interface{}, error) {
 	switch c {
 	case RemoteProvisioning_USER_AGENT:
@@ -214,6 +258,12 @@ func (c *RemoteProvisioning) Validate() error {
 		return err
 	}
 	c.CertificateAuthorities = string(certificateAuthorities)
+	if err := c.GetOpenIdDiscovery().Validate(); err != nil {
+		return fmt.Errorf("openIDDiscovery.%w", err)
+	}
+	if c.GetOpenIdDiscovery().GetEnabled() && c.GetAuthority() == "" {
+		return fmt.Errorf("authority('%v') - is required by openIDDiscovery", c.GetAuthority())
+	}
 	switch c.GetMode() {
 	case RemoteProvisioning_USER_AGENT:
 		if err := c.validateForUserAgent(); err != nil {
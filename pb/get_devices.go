@@ -0,0 +1,61 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package pb
+
+import (
+	"github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+)
+
+// NewDevice creates Device from grpcgateway.pb.Device without the device metadata.
+func NewDevice(d *pb.Device) *Device {
+	if d == nil {
+		return nil
+	}
+	return &Device{
+		Id:                    d.GetId(),
+		Types:                 d.GetTypes(),
+		Name:                  d.GetName(),
+		Metadata:              d.GetMetadata(),
+		ManufacturerName:      d.GetManufacturerName(),
+		ModelNumber:           d.GetModelNumber(),
+		Interfaces:            d.GetInterfaces(),
+		ProtocolIndependentId: d.GetProtocolIndependentId(),
+		Data:                  d.GetData(),
+		OwnershipStatus:       d.GetOwnershipStatus(),
+		Endpoints:             d.GetEndpoints(),
+	}
+}
+
+// ToGrpcGatewayDevice converts Device to grpcgateway.pb.Device, the device metadata are dropped.
+func (d *Device) ToGrpcGatewayDevice() *pb.Device {
+	if d == nil {
+		return nil
+	}
+	return &pb.Device{
+		Id:                    d.GetId(),
+		Types:                 d.GetTypes(),
+		Name:                  d.GetName(),
+		Metadata:              d.GetMetadata(),
+		ManufacturerName:      d.GetManufacturerName(),
+		ModelNumber:           d.GetModelNumber(),
+		Interfaces:            d.GetInterfaces(),
+		ProtocolIndependentId: d.GetProtocolIndependentId(),
+		Data:                  d.GetData(),
+		OwnershipStatus:       d.GetOwnershipStatus(),
+		Endpoints:             d.GetEndpoints(),
+	}
+}
@@ -23,6 +23,8 @@
 package pb
 
 import (
+	pb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	events "github.com/plgd-dev/hub/v2/resource-aggregate/events"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -84,6 +86,62 @@ func (GetDevicesRequest_OwnershipStatusFilter) EnumDescriptor() ([]byte, []int)
 	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type GetDevicesRequest_OnboardingStatusFilter int32
+
+const (
+	// get only devices which are not onboarded, provisioning status of the cloud configuration resource is uninitialized or readytoregister
+	GetDevicesRequest_OFFBOARDED GetDevicesRequest_OnboardingStatusFilter = 0
+	// get only devices with provisioning status registering
+	GetDevicesRequest_ONBOARDING GetDevicesRequest_OnboardingStatusFilter = 1
+	// get only devices with provisioning status registered
+	GetDevicesRequest_ONBOARDED GetDevicesRequest_OnboardingStatusFilter = 2
+	// get only devices with provisioning status failed
+	GetDevicesRequest_ONBOARDING_FAILED GetDevicesRequest_OnboardingStatusFilter = 3
+)
+
+// Enum value maps for GetDevicesRequest_OnboardingStatusFilter.
+var (
+	GetDevicesRequest_OnboardingStatusFilter_name = map[int32]string{
+		0: "OFFBOARDED",
+		1: "ONBOARDING",
+		2: "ONBOARDED",
+		3: "ONBOARDING_FAILED",
+	}
+	GetDevicesRequest_OnboardingStatusFilter_value = map[string]int32{
+		"OFFBOARDED":        0,
+		"ONBOARDING":        1,
+		"ONBOARDED":         2,
+		"ONBOARDING_FAILED": 3,
+	}
+)
+
+func (x GetDevicesRequest_OnboardingStatusFilter) Enum() *GetDevicesRequest_OnboardingStatusFilter {
+	p := new(GetDevicesRequest_OnboardingStatusFilter)
+	*p = x
+	return p
+}
+
+func (x GetDevicesRequest_OnboardingStatusFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetDevicesRequest_OnboardingStatusFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[1].Descriptor()
+}
+
+func (GetDevicesRequest_OnboardingStatusFilter) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[1]
+}
+
+func (x GetDevicesRequest_OnboardingStatusFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetDevicesRequest_OnboardingStatusFilter.Descriptor instead.
+func (GetDevicesRequest_OnboardingStatusFilter) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{0, 1}
+}
+
 type GetDevicesRequest_UseMulticast int32
 
 const (
@@ -114,11 +172,11 @@ func (x GetDevicesRequest_UseMulticast) String() string {
 }
 
 func (GetDevicesRequest_UseMulticast) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[1].Descriptor()
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[2].Descriptor()
 }
 
 func (GetDevicesRequest_UseMulticast) Type() protoreflect.EnumType {
-	return &file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[1]
+	return &file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[2]
 }
 
 func (x GetDevicesRequest_UseMulticast) Number() protoreflect.EnumNumber {
@@ -127,10 +185,123 @@ func (x GetDevicesRequest_UseMulticast) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GetDevicesRequest_UseMulticast.Descriptor instead.
 func (GetDevicesRequest_UseMulticast) EnumDescriptor() ([]byte, []int) {
-	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{0, 1}
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{0, 2}
+}
+
+type GetDevicesRequest_MulticastScope int32
+
+const (
+	// 224.0.1.187
+	GetDevicesRequest_IPV4_LOCAL GetDevicesRequest_MulticastScope = 0
+	// ff02::158
+	GetDevicesRequest_IPV6_LINK_LOCAL GetDevicesRequest_MulticastScope = 1
+	// ff03::158
+	GetDevicesRequest_IPV6_REALM_LOCAL GetDevicesRequest_MulticastScope = 2
+	// ff05::158
+	GetDevicesRequest_IPV6_SITE_LOCAL GetDevicesRequest_MulticastScope = 3
+)
+
+// Enum value maps for GetDevicesRequest_MulticastScope.
+var (
+	GetDevicesRequest_MulticastScope_name = map[int32]string{
+		0: "IPV4_LOCAL",
+		1: "IPV6_LINK_LOCAL",
+		2: "IPV6_REALM_LOCAL",
+		3: "IPV6_SITE_LOCAL",
+	}
+	GetDevicesRequest_MulticastScope_value = map[string]int32{
+		"IPV4_LOCAL":       0,
+		"IPV6_LINK_LOCAL":  1,
+		"IPV6_REALM_LOCAL": 2,
+		"IPV6_SITE_LOCAL":  3,
+	}
+)
+
+func (x GetDevicesRequest_MulticastScope) Enum() *GetDevicesRequest_MulticastScope {
+	p := new(GetDevicesRequest_MulticastScope)
+	*p = x
+	return p
+}
+
+func (x GetDevicesRequest_MulticastScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-// Returns a list of devices. The list is sorted by device id. If use_cache, use_multicast, use_endpoints are not set, then it will set use_multicast with [IPV4,IPV6].
+func (GetDevicesRequest_MulticastScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[3].Descriptor()
+}
+
+func (GetDevicesRequest_MulticastScope) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[3]
+}
+
+func (x GetDevicesRequest_MulticastScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetDevicesRequest_MulticastScope.Descriptor instead.
+func (GetDevicesRequest_MulticastScope) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{0, 3}
+}
+
+// Event of the device in GetDevices stream_incrementally mode.
+type Device_Event int32
+
+const (
+	// the device is not sent in stream_incrementally mode
+	Device_NONE Device_Event = 0
+	// the device is sent for the first time
+	Device_DISCOVERED Device_Event = 1
+	// the device which was already sent is refined
+	Device_UPDATED Device_Event = 2
+	// the discovery is finished, only the event and the statistics are set
+	Device_END_OF_DISCOVERY Device_Event = 3
+)
+
+// Enum value maps for Device_Event.
+var (
+	Device_Event_name = map[int32]string{
+		0: "NONE",
+		1: "DISCOVERED",
+		2: "UPDATED",
+		3: "END_OF_DISCOVERY",
+	}
+	Device_Event_value = map[string]int32{
+		"NONE":             0,
+		"DISCOVERED":       1,
+		"UPDATED":          2,
+		"END_OF_DISCOVERY": 3,
+	}
+)
+
+func (x Device_Event) Enum() *Device_Event {
+	p := new(Device_Event)
+	*p = x
+	return p
+}
+
+func (x Device_Event) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Device_Event) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[4].Descriptor()
+}
+
+func (Device_Event) Type() protoreflect.EnumType {
+	return &file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes[4]
+}
+
+func (x Device_Event) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Device_Event.Descriptor instead.
+func (Device_Event) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// Returns a list of devices. The list is sorted by device id. If use_cache, use_multicast, use_endpoints, use_subnets, use_dns_sd are not set, then it will set use_multicast with [IPV4,IPV6].
 type GetDevicesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -144,6 +315,8 @@ type GetDevicesRequest struct {
 	// Endpoint can be in format:
 	// - <host>:<port> is interpreted as coap://<host>:<port>
 	// - <host> is interpreted as coap://<host>:5683
+	// - <scheme>://<host>[:<port>] with the scheme coap, coaps, coap+tcp or coaps+tcp is probed by the matching transport, the default port is 5683 for coap and coap+tcp, 5684 for coaps and coaps+tcp.
+	//   The secure schemes use the identity of the client application, so only the owned devices respond.
 	UseEndpoints []string `protobuf:"bytes,3,rep,name=use_endpoints,json=useEndpoints,proto3" json:"use_endpoints,omitempty"`
 	// How long to wait for the devices responses for responses in nanoseconds. Default: 0 - means 2sec.
 	Timeout int64 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
@@ -151,6 +324,40 @@ type GetDevicesRequest struct {
 	OwnershipStatusFilter []GetDevicesRequest_OwnershipStatusFilter `protobuf:"varint,5,rep,packed,name=ownership_status_filter,json=ownershipStatusFilter,proto3,enum=service.pb.GetDevicesRequest_OwnershipStatusFilter" json:"ownership_status_filter,omitempty"`
 	// Filter by device resource type of oic/d. Default: [] - filter is disabled.
 	TypeFilter []string `protobuf:"bytes,6,rep,name=type_filter,json=typeFilter,proto3" json:"type_filter,omitempty"`
+	// Filter by labels of the device metadata. All selectors must match. Default: [] - filter is disabled.
+	// Selector can be in format:
+	// - <key>=<value> label is set to the value
+	// - <key>!=<value> label is not set or it is set to a different value
+	// - <key> label is set
+	// - !<key> label is not set
+	LabelSelector []string `protobuf:"bytes,7,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// Filter by groups of the device metadata. The device must be a member of one of the groups. Default: [] - filter is disabled.
+	GroupFilter []string `protobuf:"bytes,8,rep,name=group_filter,json=groupFilter,proto3" json:"group_filter,omitempty"`
+	// Filter by the name (n) of the device resource oic/d. The name must contain the value, the comparison is case-insensitive. Default: "" - filter is disabled.
+	NameFilter string `protobuf:"bytes,9,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	// Filter by the name (n) of the device resource oic/d. The name must match the regular expression (RE2 syntax). Default: "" - filter is disabled.
+	NameRegexFilter string `protobuf:"bytes,10,opt,name=name_regex_filter,json=nameRegexFilter,proto3" json:"name_regex_filter,omitempty"`
+	// Filter by the prefix of the device id. The device id must start with one of the prefixes. Default: [] - filter is disabled.
+	DeviceIdPrefixFilter []string `protobuf:"bytes,11,rep,name=device_id_prefix_filter,json=deviceIdPrefixFilter,proto3" json:"device_id_prefix_filter,omitempty"`
+	// Filter by IP subnet in CIDR notation (eg. 192.168.1.0/24, fd00::/8). One of the device endpoints must be in one of the subnets. Default: [] - filter is disabled.
+	SubnetFilter []string `protobuf:"bytes,12,rep,name=subnet_filter,json=subnetFilter,proto3" json:"subnet_filter,omitempty"`
+	// Filter by endpoint scheme: coap, coaps, coap+tcp, coaps+tcp. The device must have an endpoint with one of the schemes. Default: [] - filter is disabled.
+	SchemeFilter []string `protobuf:"bytes,13,rep,name=scheme_filter,json=schemeFilter,proto3" json:"scheme_filter,omitempty"`
+	// Filter by cloud onboarding status. The status is read from the cloud configuration resource, so devices which don't support it or are not accessible are filtered out. The statuses are read in parallel and each read is limited by the timeout of the request. Default: [] - filter is disabled.
+	OnboardingStatusFilter []GetDevicesRequest_OnboardingStatusFilter `protobuf:"varint,14,rep,packed,name=onboarding_status_filter,json=onboardingStatusFilter,proto3,enum=service.pb.GetDevicesRequest_OnboardingStatusFilter" json:"onboarding_status_filter,omitempty"`
+	// Names of the network interfaces used for the multicast discovery. Default: [] - the interfaces are set by clients.device.discovery.multicastInterfaces.
+	MulticastInterfaces []string `protobuf:"bytes,15,rep,name=multicast_interfaces,json=multicastInterfaces,proto3" json:"multicast_interfaces,omitempty"`
+	// Multicast addresses used for the discovery, only the addresses of the IP versions in use_multicast are used. Default: [] - the scopes are set by clients.device.discovery.multicastScopes.
+	MulticastScopes []GetDevicesRequest_MulticastScope `protobuf:"varint,16,rep,packed,name=multicast_scopes,json=multicastScopes,proto3,enum=service.pb.GetDevicesRequest_MulticastScope" json:"multicast_scopes,omitempty"`
+	// Returns devices via the unicast discovery of all hosts of the subnets in CIDR notation (eg. 192.168.1.0/24) on port 5683. Default: [] - the sweep is disabled. New devices will be added to cache.
+	// The devices are sent as soon as they are found. The number of the hosts is limited by clients.device.discovery.subnetSweep.maxHosts.
+	UseSubnets []string `protobuf:"bytes,17,rep,name=use_subnets,json=useSubnets,proto3" json:"use_subnets,omitempty"`
+	// Devices are sent as soon as the content of their device resource oic/d is fetched and again when they are refined later (event UPDATED).
+	// The last message has the event END_OF_DISCOVERY and the statistics of the discovery. Default: false - the devices are sent sorted by device id at the end of the discovery.
+	StreamIncrementally bool `protobuf:"varint,18,opt,name=stream_incrementally,json=streamIncrementally,proto3" json:"stream_incrementally,omitempty"`
+	// Returns devices announced by DNS-SD over multicast DNS. The services set by clients.device.discovery.dnsSD.services are resolved to the endpoints
+	// which are probed as use_endpoints. Default: false - the DNS-SD discovery is disabled. New devices will be added to cache.
+	UseDnsSd bool `protobuf:"varint,19,opt,name=use_dns_sd,json=useDnsSd,proto3" json:"use_dns_sd,omitempty"`
 }
 
 func (x *GetDevicesRequest) Reset() {
@@ -227,6 +434,375 @@ func (x *GetDevicesRequest) GetTypeFilter() []string {
 	return nil
 }
 
+func (x *GetDevicesRequest) GetLabelSelector() []string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetGroupFilter() []string {
+	if x != nil {
+		return x.GroupFilter
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+func (x *GetDevicesRequest) GetNameRegexFilter() string {
+	if x != nil {
+		return x.NameRegexFilter
+	}
+	return ""
+}
+
+func (x *GetDevicesRequest) GetDeviceIdPrefixFilter() []string {
+	if x != nil {
+		return x.DeviceIdPrefixFilter
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetSubnetFilter() []string {
+	if x != nil {
+		return x.SubnetFilter
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetSchemeFilter() []string {
+	if x != nil {
+		return x.SchemeFilter
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetOnboardingStatusFilter() []GetDevicesRequest_OnboardingStatusFilter {
+	if x != nil {
+		return x.OnboardingStatusFilter
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetMulticastInterfaces() []string {
+	if x != nil {
+		return x.MulticastInterfaces
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetMulticastScopes() []GetDevicesRequest_MulticastScope {
+	if x != nil {
+		return x.MulticastScopes
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetUseSubnets() []string {
+	if x != nil {
+		return x.UseSubnets
+	}
+	return nil
+}
+
+func (x *GetDevicesRequest) GetStreamIncrementally() bool {
+	if x != nil {
+		return x.StreamIncrementally
+	}
+	return false
+}
+
+func (x *GetDevicesRequest) GetUseDnsSd() bool {
+	if x != nil {
+		return x.UseDnsSd
+	}
+	return false
+}
+
+// Statistics of the discovery sent by GetDevices in stream_incrementally mode.
+type DiscoveryStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// duration of the discovery in nanoseconds
+	Duration int64 `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	// number of the devices found by the discovery
+	DiscoveredDevices uint32 `protobuf:"varint,2,opt,name=discovered_devices,json=discoveredDevices,proto3" json:"discovered_devices,omitempty"`
+	// number of the devices taken from the cache
+	CachedDevices uint32 `protobuf:"varint,3,opt,name=cached_devices,json=cachedDevices,proto3" json:"cached_devices,omitempty"`
+	// number of the sent devices, the filtered out devices are not counted
+	SentDevices uint32 `protobuf:"varint,4,opt,name=sent_devices,json=sentDevices,proto3" json:"sent_devices,omitempty"`
+	// number of the sent updates of the devices
+	Updates uint32 `protobuf:"varint,5,opt,name=updates,proto3" json:"updates,omitempty"`
+}
+
+func (x *DiscoveryStatistics) Reset() {
+	*x = DiscoveryStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscoveryStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryStatistics) ProtoMessage() {}
+
+func (x *DiscoveryStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryStatistics.ProtoReflect.Descriptor instead.
+func (*DiscoveryStatistics) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiscoveryStatistics) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *DiscoveryStatistics) GetDiscoveredDevices() uint32 {
+	if x != nil {
+		return x.DiscoveredDevices
+	}
+	return 0
+}
+
+func (x *DiscoveryStatistics) GetCachedDevices() uint32 {
+	if x != nil {
+		return x.CachedDevices
+	}
+	return 0
+}
+
+func (x *DiscoveryStatistics) GetSentDevices() uint32 {
+	if x != nil {
+		return x.SentDevices
+	}
+	return 0
+}
+
+func (x *DiscoveryStatistics) GetUpdates() uint32 {
+	if x != nil {
+		return x.Updates
+	}
+	return 0
+}
+
+// Device extends grpcgateway.pb.Device by the device metadata. The fields 1-11 are the same as in grpcgateway.pb.Device so the messages are wire compatible.
+type Device struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                    string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Types                 []string                `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Name                  string                  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Metadata              *pb.Device_Metadata     `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ManufacturerName      []*pb.LocalizedString   `protobuf:"bytes,5,rep,name=manufacturer_name,json=manufacturerName,proto3" json:"manufacturer_name,omitempty"`
+	ModelNumber           string                  `protobuf:"bytes,6,opt,name=model_number,json=modelNumber,proto3" json:"model_number,omitempty"`
+	Interfaces            []string                `protobuf:"bytes,7,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	ProtocolIndependentId string                  `protobuf:"bytes,8,opt,name=protocol_independent_id,json=protocolIndependentId,proto3" json:"protocol_independent_id,omitempty"`
+	Data                  *events.ResourceChanged `protobuf:"bytes,9,opt,name=data,proto3" json:"data,omitempty"`
+	// ownership status of the device
+	OwnershipStatus pb.Device_OwnershipStatus `protobuf:"varint,10,opt,name=ownership_status,json=ownershipStatus,proto3,enum=grpcgateway.pb.Device_OwnershipStatus" json:"ownership_status,omitempty"`
+	// endpoints with schemas which are hosted by the device
+	Endpoints []string `protobuf:"bytes,11,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// endpoint of the last connection established to the device
+	ActiveEndpoint string `protobuf:"bytes,12,opt,name=active_endpoint,json=activeEndpoint,proto3" json:"active_endpoint,omitempty"`
+	// endpoints to which the last connection attempt failed, they are tried as the last ones
+	FailedEndpoints []string `protobuf:"bytes,13,rep,name=failed_endpoints,json=failedEndpoints,proto3" json:"failed_endpoints,omitempty"`
+	// event in GetDevices stream_incrementally mode
+	Event Device_Event `protobuf:"varint,14,opt,name=event,proto3,enum=service.pb.Device_Event" json:"event,omitempty"`
+	// statistics of the discovery, set only for the event END_OF_DISCOVERY
+	Statistics *DiscoveryStatistics `protobuf:"bytes,15,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	// user-defined alias of the device
+	Alias string `protobuf:"bytes,101,opt,name=alias,proto3" json:"alias,omitempty"`
+	// user-defined labels of the device
+	Labels map[string]string `protobuf:"bytes,102,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// user-defined groups of the device
+	Groups []string `protobuf:"bytes,103,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetMetadata() *pb.Device_Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Device) GetManufacturerName() []*pb.LocalizedString {
+	if x != nil {
+		return x.ManufacturerName
+	}
+	return nil
+}
+
+func (x *Device) GetModelNumber() string {
+	if x != nil {
+		return x.ModelNumber
+	}
+	return ""
+}
+
+func (x *Device) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *Device) GetProtocolIndependentId() string {
+	if x != nil {
+		return x.ProtocolIndependentId
+	}
+	return ""
+}
+
+func (x *Device) GetData() *events.ResourceChanged {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Device) GetOwnershipStatus() pb.Device_OwnershipStatus {
+	if x != nil {
+		return x.OwnershipStatus
+	}
+	return pb.Device_OwnershipStatus(0)
+}
+
+func (x *Device) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Device) GetActiveEndpoint() string {
+	if x != nil {
+		return x.ActiveEndpoint
+	}
+	return ""
+}
+
+func (x *Device) GetFailedEndpoints() []string {
+	if x != nil {
+		return x.FailedEndpoints
+	}
+	return nil
+}
+
+func (x *Device) GetEvent() Device_Event {
+	if x != nil {
+		return x.Event
+	}
+	return Device_NONE
+}
+
+func (x *Device) GetStatistics() *DiscoveryStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *Device) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Device) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Device) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
 var File_github_com_plgd_dev_client_application_pb_get_devices_proto protoreflect.FileDescriptor
 
 var file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDesc = []byte{
@@ -234,37 +810,164 @@ var file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDesc = [
 	0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70, 0x70,
 	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x67, 0x65, 0x74, 0x5f,
 	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x22, 0xa3, 0x03, 0x0a, 0x11, 0x47, 0x65,
-	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x08, 0x75, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x4f, 0x0a, 0x0d,
-	0x75, 0x73, 0x65, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62,
-	0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52,
-	0x0c, 0x75, 0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x23, 0x0a,
-	0x0d, 0x75, 0x73, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x6b, 0x0a, 0x17,
-	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x33, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x77, 0x6e,
-	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x15, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70,
-	0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
-	0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x15, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4f, 0x57, 0x4e, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x57, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x22, 0x22, 0x0a, 0x0c, 0x55,
-	0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x49,
-	0x50, 0x56, 0x34, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x36, 0x10, 0x01, 0x42,
-	0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6c,
-	0x67, 0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x1a, 0x1d, 0x67, 0x72, 0x70, 0x63, 0x2d,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2f, 0x70, 0x62, 0x2f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xeb, 0x09, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12,
+	0x4f, 0x0a, 0x0d, 0x75, 0x73, 0x65, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x75, 0x73, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x6b, 0x0a, 0x17, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x33, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x15, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x79, 0x70, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x25, 0x0a,
+	0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61,
+	0x6d, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x61, 0x6d, 0x65,
+	0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x67, 0x65, 0x78, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x17, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x6e, 0x0a, 0x18, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x16, 0x6f,
+	0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61,
+	0x73, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x57, 0x0a, 0x10, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x52, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x5f, 0x64, 0x6e, 0x73,
+	0x5f, 0x73, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x73, 0x65, 0x44, 0x6e,
+	0x73, 0x53, 0x64, 0x22, 0x2f, 0x0a, 0x15, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0b, 0x0a, 0x07,
+	0x55, 0x4e, 0x4f, 0x57, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x57, 0x4e,
+	0x45, 0x44, 0x10, 0x01, 0x22, 0x5e, 0x0a, 0x16, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x0a, 0x4f, 0x46, 0x46, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a,
+	0x11, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x45, 0x44, 0x10, 0x03, 0x22, 0x22, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x34, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x49, 0x50, 0x56, 0x36, 0x10, 0x01, 0x22, 0x60, 0x0a, 0x0e, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x63, 0x61, 0x73, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x50,
+	0x56, 0x34, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x50,
+	0x56, 0x36, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x01, 0x12,
+	0x14, 0x0a, 0x10, 0x49, 0x50, 0x56, 0x36, 0x5f, 0x52, 0x45, 0x41, 0x4c, 0x4d, 0x5f, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x50, 0x56, 0x36, 0x5f, 0x53, 0x49,
+	0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x03, 0x22, 0xc4, 0x01, 0x0a, 0x13, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d,
+	0x0a, 0x12, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x22, 0xa0, 0x07, 0x0a, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x4c, 0x0a, 0x11, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x62, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52,
+	0x10, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x49,
+	0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x51, 0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x70, 0x62, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0f, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x66, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x62,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x67, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x44,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x14,
+	0x0a, 0x10, 0x45, 0x4e, 0x44, 0x5f, 0x4f, 0x46, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45,
+	0x52, 0x59, 0x10, 0x03, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x70, 0x6c, 0x67, 0x64, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x2d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70,
+	0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -279,21 +982,40 @@ func file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescGZI
 	return file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDescData
 }
 
-var file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_plgd_dev_client_application_pb_get_devices_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_github_com_plgd_dev_client_application_pb_get_devices_proto_goTypes = []any{
-	(GetDevicesRequest_OwnershipStatusFilter)(0), // 0: service.pb.GetDevicesRequest.OwnershipStatusFilter
-	(GetDevicesRequest_UseMulticast)(0),          // 1: service.pb.GetDevicesRequest.UseMulticast
-	(*GetDevicesRequest)(nil),                    // 2: service.pb.GetDevicesRequest
+	(GetDevicesRequest_OwnershipStatusFilter)(0),  // 0: service.pb.GetDevicesRequest.OwnershipStatusFilter
+	(GetDevicesRequest_OnboardingStatusFilter)(0), // 1: service.pb.GetDevicesRequest.OnboardingStatusFilter
+	(GetDevicesRequest_UseMulticast)(0),           // 2: service.pb.GetDevicesRequest.UseMulticast
+	(GetDevicesRequest_MulticastScope)(0),         // 3: service.pb.GetDevicesRequest.MulticastScope
+	(Device_Event)(0),                             // 4: service.pb.Device.Event
+	(*GetDevicesRequest)(nil),                     // 5: service.pb.GetDevicesRequest
+	(*DiscoveryStatistics)(nil),                   // 6: service.pb.DiscoveryStatistics
+	(*Device)(nil),                                // 7: service.pb.Device
+	nil,                                           // 8: service.pb.Device.LabelsEntry
+	(*pb.Device_Metadata)(nil),                    // 9: grpcgateway.pb.Device.Metadata
+	(*pb.LocalizedString)(nil),                    // 10: grpcgateway.pb.LocalizedString
+	(*events.ResourceChanged)(nil),                // 11: resourceaggregate.pb.ResourceChanged
+	(pb.Device_OwnershipStatus)(0),                // 12: grpcgateway.pb.Device.OwnershipStatus
 }
 var file_github_com_plgd_dev_client_application_pb_get_devices_proto_depIdxs = []int32{
-	1, // 0: service.pb.GetDevicesRequest.use_multicast:type_name -> service.pb.GetDevicesRequest.UseMulticast
-	0, // 1: service.pb.GetDevicesRequest.ownership_status_filter:type_name -> service.pb.GetDevicesRequest.OwnershipStatusFilter
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: service.pb.GetDevicesRequest.use_multicast:type_name -> service.pb.GetDevicesRequest.UseMulticast
+	0,  // 1: service.pb.GetDevicesRequest.ownership_status_filter:type_name -> service.pb.GetDevicesRequest.OwnershipStatusFilter
+	1,  // 2: service.pb.GetDevicesRequest.onboarding_status_filter:type_name -> service.pb.GetDevicesRequest.OnboardingStatusFilter
+	3,  // 3: service.pb.GetDevicesRequest.multicast_scopes:type_name -> service.pb.GetDevicesRequest.MulticastScope
+	9,  // 4: service.pb.Device.metadata:type_name -> grpcgateway.pb.Device.Metadata
+	10, // 5: service.pb.Device.manufacturer_name:type_name -> grpcgateway.pb.LocalizedString
+	11, // 6: service.pb.Device.data:type_name -> resourceaggregate.pb.ResourceChanged
+	12, // 7: service.pb.Device.ownership_status:type_name -> grpcgateway.pb.Device.OwnershipStatus
+	4,  // 8: service.pb.Device.event:type_name -> service.pb.Device.Event
+	6,  // 9: service.pb.Device.statistics:type_name -> service.pb.DiscoveryStatistics
+	8,  // 10: service.pb.Device.labels:type_name -> service.pb.Device.LabelsEntry
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_github_com_plgd_dev_client_application_pb_get_devices_proto_init() }
@@ -314,14 +1036,38 @@ func file_github_com_plgd_dev_client_application_pb_get_devices_proto_init() {
 				return nil
 			}
 		}
+		file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*DiscoveryStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_plgd_dev_client_application_pb_get_devices_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Device); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_plgd_dev_client_application_pb_get_devices_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   1,
+			NumEnums:      5,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -49,6 +49,8 @@ const (
 	ClientApplication_FinishOwnDevice_FullMethodName        = "/service.pb.ClientApplication/FinishOwnDevice"
 	ClientApplication_DisownDevice_FullMethodName           = "/service.pb.ClientApplication/DisownDevice"
 	ClientApplication_ClearCache_FullMethodName             = "/service.pb.ClientApplication/ClearCache"
+	ClientApplication_GetConnections_FullMethodName         = "/service.pb.ClientApplication/GetConnections"
+	ClientApplication_CloseConnections_FullMethodName       = "/service.pb.ClientApplication/CloseConnections"
 	ClientApplication_GetConfiguration_FullMethodName       = "/service.pb.ClientApplication/GetConfiguration"
 	ClientApplication_GetJSONWebKeys_FullMethodName         = "/service.pb.ClientApplication/GetJSONWebKeys"
 	ClientApplication_GetIdentityCertificate_FullMethodName = "/service.pb.ClientApplication/GetIdentityCertificate"
@@ -57,13 +59,23 @@ const (
 	ClientApplication_Reset_FullMethodName                  = "/service.pb.ClientApplication/Reset"
 	ClientApplication_OnboardDevice_FullMethodName          = "/service.pb.ClientApplication/OnboardDevice"
 	ClientApplication_OffboardDevice_FullMethodName         = "/service.pb.ClientApplication/OffboardDevice"
+	ClientApplication_ApplyManifest_FullMethodName          = "/service.pb.ClientApplication/ApplyManifest"
+	ClientApplication_ExportInventory_FullMethodName        = "/service.pb.ClientApplication/ExportInventory"
+	ClientApplication_ImportInventory_FullMethodName        = "/service.pb.ClientApplication/ImportInventory"
+	ClientApplication_SetDeviceMetadata_FullMethodName      = "/service.pb.ClientApplication/SetDeviceMetadata"
+	ClientApplication_GetDeviceMetadata_FullMethodName      = "/service.pb.ClientApplication/GetDeviceMetadata"
+	ClientApplication_GetDiagnostics_FullMethodName         = "/service.pb.ClientApplication/GetDiagnostics"
+	ClientApplication_GetAuditLog_FullMethodName            = "/service.pb.ClientApplication/GetAuditLog"
+	ClientApplication_GetAdditionalOwners_FullMethodName    = "/service.pb.ClientApplication/GetAdditionalOwners"
+	ClientApplication_AddAdditionalOwner_FullMethodName     = "/service.pb.ClientApplication/AddAdditionalOwner"
+	ClientApplication_RemoveAdditionalOwner_FullMethodName  = "/service.pb.ClientApplication/RemoveAdditionalOwner"
 )
 
 // ClientApplicationClient is the client API for ClientApplication service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ClientApplicationClient interface {
-	GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.Device], error)
+	GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Device], error)
 	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*pb.Device, error)
 	GetDeviceResourceLinks(ctx context.Context, in *GetDeviceResourceLinksRequest, opts ...grpc.CallOption) (*events.ResourceLinksPublished, error)
 	GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*pb.Resource, error)
@@ -74,6 +86,8 @@ type ClientApplicationClient interface {
 	FinishOwnDevice(ctx context.Context, in *FinishOwnDeviceRequest, opts ...grpc.CallOption) (*FinishOwnDeviceResponse, error)
 	DisownDevice(ctx context.Context, in *DisownDeviceRequest, opts ...grpc.CallOption) (*DisownDeviceResponse, error)
 	ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*ClearCacheResponse, error)
+	GetConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error)
+	CloseConnections(ctx context.Context, in *CloseConnectionsRequest, opts ...grpc.CallOption) (*CloseConnectionsResponse, error)
 	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error)
 	GetJSONWebKeys(ctx context.Context, in *GetJSONWebKeysRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
 	GetIdentityCertificate(ctx context.Context, in *GetIdentityCertificateRequest, opts ...grpc.CallOption) (*GetIdentityCertificateResponse, error)
@@ -82,6 +96,16 @@ type ClientApplicationClient interface {
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
 	OnboardDevice(ctx context.Context, in *OnboardDeviceRequest, opts ...grpc.CallOption) (*OnboardDeviceResponse, error)
 	OffboardDevice(ctx context.Context, in *OffboardDeviceRequest, opts ...grpc.CallOption) (*OffboardDeviceResponse, error)
+	ApplyManifest(ctx context.Context, in *ApplyManifestRequest, opts ...grpc.CallOption) (*ApplyManifestResponse, error)
+	ExportInventory(ctx context.Context, in *ExportInventoryRequest, opts ...grpc.CallOption) (*ExportInventoryResponse, error)
+	ImportInventory(ctx context.Context, in *ImportInventoryRequest, opts ...grpc.CallOption) (*ImportInventoryResponse, error)
+	SetDeviceMetadata(ctx context.Context, in *SetDeviceMetadataRequest, opts ...grpc.CallOption) (*DeviceMetadata, error)
+	GetDeviceMetadata(ctx context.Context, in *GetDeviceMetadataRequest, opts ...grpc.CallOption) (*DeviceMetadata, error)
+	GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsResponse, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error)
+	GetAdditionalOwners(ctx context.Context, in *GetAdditionalOwnersRequest, opts ...grpc.CallOption) (*GetAdditionalOwnersResponse, error)
+	AddAdditionalOwner(ctx context.Context, in *AddAdditionalOwnerRequest, opts ...grpc.CallOption) (*AddAdditionalOwnerResponse, error)
+	RemoveAdditionalOwner(ctx context.Context, in *RemoveAdditionalOwnerRequest, opts ...grpc.CallOption) (*RemoveAdditionalOwnerResponse, error)
 }
 
 type clientApplicationClient struct {
@@ -92,13 +116,13 @@ func NewClientApplicationClient(cc grpc.ClientConnInterface) ClientApplicationCl
 	return &clientApplicationClient{cc}
 }
 
-func (c *clientApplicationClient) GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.Device], error) {
+func (c *clientApplicationClient) GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Device], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ClientApplication_ServiceDesc.Streams[0], ClientApplication_GetDevices_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[GetDevicesRequest, pb.Device]{ClientStream: stream}
+	x := &grpc.GenericClientStream[GetDevicesRequest, Device]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -109,7 +133,7 @@ func (c *clientApplicationClient) GetDevices(ctx context.Context, in *GetDevices
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ClientApplication_GetDevicesClient = grpc.ServerStreamingClient[pb.Device]
+type ClientApplication_GetDevicesClient = grpc.ServerStreamingClient[Device]
 
 func (c *clientApplicationClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*pb.Device, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
@@ -211,6 +235,26 @@ func (c *clientApplicationClient) ClearCache(ctx context.Context, in *ClearCache
 	return out, nil
 }
 
+func (c *clientApplicationClient) GetConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConnectionsResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_GetConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) CloseConnections(ctx context.Context, in *CloseConnectionsRequest, opts ...grpc.CallOption) (*CloseConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseConnectionsResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_CloseConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clientApplicationClient) GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetConfigurationResponse)
@@ -291,11 +335,111 @@ func (c *clientApplicationClient) OffboardDevice(ctx context.Context, in *Offboa
 	return out, nil
 }
 
+func (c *clientApplicationClient) ApplyManifest(ctx context.Context, in *ApplyManifestRequest, opts ...grpc.CallOption) (*ApplyManifestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyManifestResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_ApplyManifest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) ExportInventory(ctx context.Context, in *ExportInventoryRequest, opts ...grpc.CallOption) (*ExportInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportInventoryResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_ExportInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) ImportInventory(ctx context.Context, in *ImportInventoryRequest, opts ...grpc.CallOption) (*ImportInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportInventoryResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_ImportInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) SetDeviceMetadata(ctx context.Context, in *SetDeviceMetadataRequest, opts ...grpc.CallOption) (*DeviceMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceMetadata)
+	err := c.cc.Invoke(ctx, ClientApplication_SetDeviceMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) GetDeviceMetadata(ctx context.Context, in *GetDeviceMetadataRequest, opts ...grpc.CallOption) (*DeviceMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceMetadata)
+	err := c.cc.Invoke(ctx, ClientApplication_GetDeviceMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_GetDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuditLogResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_GetAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) GetAdditionalOwners(ctx context.Context, in *GetAdditionalOwnersRequest, opts ...grpc.CallOption) (*GetAdditionalOwnersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAdditionalOwnersResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_GetAdditionalOwners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) AddAdditionalOwner(ctx context.Context, in *AddAdditionalOwnerRequest, opts ...grpc.CallOption) (*AddAdditionalOwnerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddAdditionalOwnerResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_AddAdditionalOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientApplicationClient) RemoveAdditionalOwner(ctx context.Context, in *RemoveAdditionalOwnerRequest, opts ...grpc.CallOption) (*RemoveAdditionalOwnerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveAdditionalOwnerResponse)
+	err := c.cc.Invoke(ctx, ClientApplication_RemoveAdditionalOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientApplicationServer is the server API for ClientApplication service.
 // All implementations must embed UnimplementedClientApplicationServer
 // for forward compatibility.
 type ClientApplicationServer interface {
-	GetDevices(*GetDevicesRequest, grpc.ServerStreamingServer[pb.Device]) error
+	GetDevices(*GetDevicesRequest, grpc.ServerStreamingServer[Device]) error
 	GetDevice(context.Context, *GetDeviceRequest) (*pb.Device, error)
 	GetDeviceResourceLinks(context.Context, *GetDeviceResourceLinksRequest) (*events.ResourceLinksPublished, error)
 	GetResource(context.Context, *GetResourceRequest) (*pb.Resource, error)
@@ -306,6 +450,8 @@ type ClientApplicationServer interface {
 	FinishOwnDevice(context.Context, *FinishOwnDeviceRequest) (*FinishOwnDeviceResponse, error)
 	DisownDevice(context.Context, *DisownDeviceRequest) (*DisownDeviceResponse, error)
 	ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error)
+	GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error)
+	CloseConnections(context.Context, *CloseConnectionsRequest) (*CloseConnectionsResponse, error)
 	GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error)
 	GetJSONWebKeys(context.Context, *GetJSONWebKeysRequest) (*structpb.Struct, error)
 	GetIdentityCertificate(context.Context, *GetIdentityCertificateRequest) (*GetIdentityCertificateResponse, error)
@@ -314,6 +460,16 @@ type ClientApplicationServer interface {
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
 	OnboardDevice(context.Context, *OnboardDeviceRequest) (*OnboardDeviceResponse, error)
 	OffboardDevice(context.Context, *OffboardDeviceRequest) (*OffboardDeviceResponse, error)
+	ApplyManifest(context.Context, *ApplyManifestRequest) (*ApplyManifestResponse, error)
+	ExportInventory(context.Context, *ExportInventoryRequest) (*ExportInventoryResponse, error)
+	ImportInventory(context.Context, *ImportInventoryRequest) (*ImportInventoryResponse, error)
+	SetDeviceMetadata(context.Context, *SetDeviceMetadataRequest) (*DeviceMetadata, error)
+	GetDeviceMetadata(context.Context, *GetDeviceMetadataRequest) (*DeviceMetadata, error)
+	GetDiagnostics(context.Context, *GetDiagnosticsRequest) (*GetDiagnosticsResponse, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error)
+	GetAdditionalOwners(context.Context, *GetAdditionalOwnersRequest) (*GetAdditionalOwnersResponse, error)
+	AddAdditionalOwner(context.Context, *AddAdditionalOwnerRequest) (*AddAdditionalOwnerResponse, error)
+	RemoveAdditionalOwner(context.Context, *RemoveAdditionalOwnerRequest) (*RemoveAdditionalOwnerResponse, error)
 	mustEmbedUnimplementedClientApplicationServer()
 }
 
@@ -324,7 +480,7 @@ type ClientApplicationServer interface {
 // pointer dereference when methods are called.
 type UnimplementedClientApplicationServer struct{}
 
-func (UnimplementedClientApplicationServer) GetDevices(*GetDevicesRequest, grpc.ServerStreamingServer[pb.Device]) error {
+func (UnimplementedClientApplicationServer) GetDevices(*GetDevicesRequest, grpc.ServerStreamingServer[Device]) error {
 	return status.Errorf(codes.Unimplemented, "method GetDevices not implemented")
 }
 func (UnimplementedClientApplicationServer) GetDevice(context.Context, *GetDeviceRequest) (*pb.Device, error) {
@@ -357,6 +513,12 @@ func (UnimplementedClientApplicationServer) DisownDevice(context.Context, *Disow
 func (UnimplementedClientApplicationServer) ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearCache not implemented")
 }
+func (UnimplementedClientApplicationServer) GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnections not implemented")
+}
+func (UnimplementedClientApplicationServer) CloseConnections(context.Context, *CloseConnectionsRequest) (*CloseConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseConnections not implemented")
+}
 func (UnimplementedClientApplicationServer) GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
 }
@@ -381,6 +543,36 @@ func (UnimplementedClientApplicationServer) OnboardDevice(context.Context, *Onbo
 func (UnimplementedClientApplicationServer) OffboardDevice(context.Context, *OffboardDeviceRequest) (*OffboardDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OffboardDevice not implemented")
 }
+func (UnimplementedClientApplicationServer) ApplyManifest(context.Context, *ApplyManifestRequest) (*ApplyManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyManifest not implemented")
+}
+func (UnimplementedClientApplicationServer) ExportInventory(context.Context, *ExportInventoryRequest) (*ExportInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportInventory not implemented")
+}
+func (UnimplementedClientApplicationServer) ImportInventory(context.Context, *ImportInventoryRequest) (*ImportInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportInventory not implemented")
+}
+func (UnimplementedClientApplicationServer) SetDeviceMetadata(context.Context, *SetDeviceMetadataRequest) (*DeviceMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceMetadata not implemented")
+}
+func (UnimplementedClientApplicationServer) GetDeviceMetadata(context.Context, *GetDeviceMetadataRequest) (*DeviceMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceMetadata not implemented")
+}
+func (UnimplementedClientApplicationServer) GetDiagnostics(context.Context, *GetDiagnosticsRequest) (*GetDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiagnostics not implemented")
+}
+func (UnimplementedClientApplicationServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedClientApplicationServer) GetAdditionalOwners(context.Context, *GetAdditionalOwnersRequest) (*GetAdditionalOwnersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdditionalOwners not implemented")
+}
+func (UnimplementedClientApplicationServer) AddAdditionalOwner(context.Context, *AddAdditionalOwnerRequest) (*AddAdditionalOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAdditionalOwner not implemented")
+}
+func (UnimplementedClientApplicationServer) RemoveAdditionalOwner(context.Context, *RemoveAdditionalOwnerRequest) (*RemoveAdditionalOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAdditionalOwner not implemented")
+}
 func (UnimplementedClientApplicationServer) mustEmbedUnimplementedClientApplicationServer() {}
 func (UnimplementedClientApplicationServer) testEmbeddedByValue()                           {}
 
@@ -407,11 +599,11 @@ func _ClientApplication_GetDevices_Handler(srv interface{}, stream grpc.ServerSt
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ClientApplicationServer).GetDevices(m, &grpc.GenericServerStream[GetDevicesRequest, pb.Device]{ServerStream: stream})
+	return srv.(ClientApplicationServer).GetDevices(m, &grpc.GenericServerStream[GetDevicesRequest, Device]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ClientApplication_GetDevicesServer = grpc.ServerStreamingServer[pb.Device]
+type ClientApplication_GetDevicesServer = grpc.ServerStreamingServer[Device]
 
 func _ClientApplication_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDeviceRequest)
@@ -593,6 +785,42 @@ func _ClientApplication_ClearCache_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientApplication_GetConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).GetConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_GetConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).GetConnections(ctx, req.(*GetConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_CloseConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).CloseConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_CloseConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).CloseConnections(ctx, req.(*CloseConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClientApplication_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConfigurationRequest)
 	if err := dec(in); err != nil {
@@ -737,6 +965,186 @@ func _ClientApplication_OffboardDevice_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientApplication_ApplyManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).ApplyManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_ApplyManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).ApplyManifest(ctx, req.(*ApplyManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_ExportInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).ExportInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_ExportInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).ExportInventory(ctx, req.(*ExportInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_ImportInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).ImportInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_ImportInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).ImportInventory(ctx, req.(*ImportInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_SetDeviceMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).SetDeviceMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_SetDeviceMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).SetDeviceMetadata(ctx, req.(*SetDeviceMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_GetDeviceMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).GetDeviceMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_GetDeviceMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).GetDeviceMetadata(ctx, req.(*GetDeviceMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_GetDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).GetDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_GetDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).GetDiagnostics(ctx, req.(*GetDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_GetAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_GetAdditionalOwners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdditionalOwnersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).GetAdditionalOwners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_GetAdditionalOwners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).GetAdditionalOwners(ctx, req.(*GetAdditionalOwnersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_AddAdditionalOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAdditionalOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).AddAdditionalOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_AddAdditionalOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).AddAdditionalOwner(ctx, req.(*AddAdditionalOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientApplication_RemoveAdditionalOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAdditionalOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientApplicationServer).RemoveAdditionalOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientApplication_RemoveAdditionalOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientApplicationServer).RemoveAdditionalOwner(ctx, req.(*RemoveAdditionalOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientApplication_ServiceDesc is the grpc.ServiceDesc for ClientApplication service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -784,6 +1192,14 @@ var ClientApplication_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearCache",
 			Handler:    _ClientApplication_ClearCache_Handler,
 		},
+		{
+			MethodName: "GetConnections",
+			Handler:    _ClientApplication_GetConnections_Handler,
+		},
+		{
+			MethodName: "CloseConnections",
+			Handler:    _ClientApplication_CloseConnections_Handler,
+		},
 		{
 			MethodName: "GetConfiguration",
 			Handler:    _ClientApplication_GetConfiguration_Handler,
@@ -816,6 +1232,46 @@ var ClientApplication_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OffboardDevice",
 			Handler:    _ClientApplication_OffboardDevice_Handler,
 		},
+		{
+			MethodName: "ApplyManifest",
+			Handler:    _ClientApplication_ApplyManifest_Handler,
+		},
+		{
+			MethodName: "ExportInventory",
+			Handler:    _ClientApplication_ExportInventory_Handler,
+		},
+		{
+			MethodName: "ImportInventory",
+			Handler:    _ClientApplication_ImportInventory_Handler,
+		},
+		{
+			MethodName: "SetDeviceMetadata",
+			Handler:    _ClientApplication_SetDeviceMetadata_Handler,
+		},
+		{
+			MethodName: "GetDeviceMetadata",
+			Handler:    _ClientApplication_GetDeviceMetadata_Handler,
+		},
+		{
+			MethodName: "GetDiagnostics",
+			Handler:    _ClientApplication_GetDiagnostics_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _ClientApplication_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetAdditionalOwners",
+			Handler:    _ClientApplication_GetAdditionalOwners_Handler,
+		},
+		{
+			MethodName: "AddAdditionalOwner",
+			Handler:    _ClientApplication_AddAdditionalOwner_Handler,
+		},
+		{
+			MethodName: "RemoveAdditionalOwner",
+			Handler:    _ClientApplication_RemoveAdditionalOwner_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
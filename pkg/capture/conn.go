@@ -0,0 +1,102 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package capture
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+)
+
+// Conn records all requests and responses of the wrapped connection.
+type Conn struct {
+	coap.ClientConn
+	scheme   schema.Scheme
+	recorder *Recorder
+	errFunc  func(err error)
+}
+
+// NewConn wraps the connection, errors of the recorder are reported via errFunc.
+func NewConn(conn coap.ClientConn, scheme schema.Scheme, recorder *Recorder, errFunc func(err error)) *Conn {
+	return &Conn{
+		ClientConn: conn,
+		scheme:     scheme,
+		recorder:   recorder,
+		errFunc:    errFunc,
+	}
+}
+
+func (c *Conn) record(start time.Time, req Message, resp *pool.Message, err error) {
+	if errR := c.recorder.RecordResponse(c.scheme, c.RemoteAddr(), false, start, req, resp, err); errR != nil && c.errFunc != nil {
+		c.errFunc(errR)
+	}
+}
+
+func (c *Conn) Post(ctx context.Context, path string, contentFormat message.MediaType, payload io.ReadSeeker, opts ...message.Option) (*pool.Message, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = io.ReadAll(payload); err != nil {
+			return nil, err
+		}
+		if _, err = payload.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	req := NewRequest(codes.POST, path, contentFormat, body, opts...)
+	start := time.Now()
+	resp, err := c.ClientConn.Post(ctx, path, contentFormat, payload, opts...)
+	c.record(start, req, resp, err)
+	return resp, err
+}
+
+func (c *Conn) Get(ctx context.Context, path string, opts ...message.Option) (*pool.Message, error) {
+	req := NewRequest(codes.GET, path, 0, nil, opts...)
+	start := time.Now()
+	resp, err := c.ClientConn.Get(ctx, path, opts...)
+	c.record(start, req, resp, err)
+	return resp, err
+}
+
+func (c *Conn) Delete(ctx context.Context, path string, opts ...message.Option) (*pool.Message, error) {
+	req := NewRequest(codes.DELETE, path, 0, nil, opts...)
+	start := time.Now()
+	resp, err := c.ClientConn.Delete(ctx, path, opts...)
+	c.record(start, req, resp, err)
+	return resp, err
+}
+
+// Observe records each notification as the response of the observe request.
+func (c *Conn) Observe(ctx context.Context, path string, observeFunc func(notification *pool.Message), opts ...message.Option) (coap.Observation, error) {
+	req := NewRequest(codes.GET, path, 0, nil, opts...)
+	req.Options = append(req.Options, Option{ID: message.Observe, Name: message.Observe.String(), Value: "0"})
+	start := time.Now()
+	obs, err := c.ClientConn.Observe(ctx, path, func(notification *pool.Message) {
+		c.record(time.Now(), req, notification, nil)
+		observeFunc(notification)
+	}, opts...)
+	if err != nil {
+		c.record(start, req, nil, err)
+	}
+	return obs, err
+}
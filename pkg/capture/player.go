@@ -0,0 +1,118 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+type responses struct {
+	messages []*Message
+	next     int
+}
+
+func (r *responses) pop() *Message {
+	m := r.messages[r.next]
+	if r.next < len(r.messages)-1 {
+		r.next++
+	}
+	return m
+}
+
+// Player replays recorded responses of a device. Responses are matched by the code, path and queries
+// of the request, when no response matches the queries then the code and path are used. Responses of
+// the same request are returned in the recorded order and the last one is repeated.
+type Player struct {
+	mutex   sync.Mutex
+	byQuery map[string]*responses
+	byPath  map[string]*responses
+}
+
+func queryKey(code, path string, queries []string) string {
+	q := append([]string(nil), queries...)
+	sort.Strings(q)
+	return code + " " + path + "?" + strings.Join(q, "&")
+}
+
+func pathKey(code, path string) string {
+	return code + " " + path
+}
+
+func add(m map[string]*responses, key string, msg *Message) {
+	r, ok := m[key]
+	if !ok {
+		r = &responses{}
+		m[key] = r
+	}
+	r.messages = append(r.messages, msg)
+}
+
+// NewPlayer creates the player from the records of the device. Records of other devices and records
+// without a response are skipped.
+func NewPlayer(records []Record, deviceID string) (*Player, error) {
+	p := &Player{
+		byQuery: make(map[string]*responses),
+		byPath:  make(map[string]*responses),
+	}
+	var n int
+	for i := range records {
+		rec := records[i]
+		if rec.Response == nil || (deviceID != "" && rec.DeviceID != deviceID) {
+			continue
+		}
+		add(p.byQuery, queryKey(rec.Request.Code, rec.Request.Path, rec.Request.Queries), rec.Response)
+		add(p.byPath, pathKey(rec.Request.Code, rec.Request.Path), rec.Response)
+		n++
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("deviceID('%v') - no records with response", deviceID)
+	}
+	return p, nil
+}
+
+// Play returns the recorded response of the request.
+func (p *Player) Play(code codes.Code, path string, queries []string) (*Message, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if r, ok := p.byQuery[queryKey(code.String(), path, queries)]; ok {
+		return r.pop(), true
+	}
+	if r, ok := p.byPath[pathKey(code.String(), path)]; ok {
+		return r.pop(), true
+	}
+	return nil, false
+}
+
+// GetPayload returns the raw payload of the message. When the payload has been redacted the payload is
+// encoded from the JSON body.
+func (m *Message) GetPayload() ([]byte, error) {
+	if len(m.Payload) > 0 || len(m.Body) == 0 {
+		return m.Payload, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(m.Body, &v); err != nil {
+		return nil, fmt.Errorf("cannot decode body: %w", err)
+	}
+	return cbor.Encode(v)
+}
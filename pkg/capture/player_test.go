@@ -0,0 +1,75 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package capture_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/pkg/capture"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/stretchr/testify/require"
+)
+
+func makeRecord(deviceID, code, path string, queries []string, respCode string) capture.Record {
+	return capture.Record{
+		DeviceID: deviceID,
+		Request: capture.Message{
+			Code:    code,
+			Path:    path,
+			Queries: queries,
+		},
+		Response: &capture.Message{
+			Code: respCode,
+		},
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	const deviceID = "a"
+	records := []capture.Record{
+		makeRecord(deviceID, "GET", "/oic/res", []string{"rt=oic.wk.d", "di=a"}, "Content"),
+		makeRecord(deviceID, "POST", "/light/1", nil, "Changed"),
+		makeRecord(deviceID, "POST", "/light/1", nil, "BadRequest"),
+		makeRecord("b", "GET", "/oic/d", nil, "Content"),
+		{DeviceID: deviceID, Request: capture.Message{Code: "GET", Path: "/oic/d"}},
+	}
+	p, err := capture.NewPlayer(records, deviceID)
+	require.NoError(t, err)
+
+	// queries are matched in any order
+	m, ok := p.Play(codes.GET, "/oic/res", []string{"di=a", "rt=oic.wk.d"})
+	require.True(t, ok)
+	require.Equal(t, "Content", m.Code)
+	// fallback to the path
+	m, ok = p.Play(codes.GET, "/oic/res", nil)
+	require.True(t, ok)
+	require.Equal(t, "Content", m.Code)
+
+	// recorded order, the last response is repeated
+	for _, code := range []string{"Changed", "BadRequest", "BadRequest"} {
+		m, ok = p.Play(codes.POST, "/light/1", nil)
+		require.True(t, ok)
+		require.Equal(t, code, m.Code)
+	}
+
+	// records of other devices and without response are skipped
+	_, ok = p.Play(codes.GET, "/oic/d", nil)
+	require.False(t, ok)
+
+	_, err = capture.NewPlayer(records, "c")
+	require.Error(t, err)
+}
@@ -0,0 +1,282 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Package capture records CoAP exchanges between the client application and devices to a JSON-lines
+// file and plays them back.
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+// Option is a CoAP option, the value is formatted according to the option definition.
+type Option struct {
+	ID    message.OptionID `json:"id"`
+	Name  string           `json:"name"`
+	Value string           `json:"value,omitempty"`
+}
+
+// Message is a CoAP request or response.
+type Message struct {
+	Code          string   `json:"code"`
+	Path          string   `json:"path,omitempty"`
+	Queries       []string `json:"queries,omitempty"`
+	Options       []Option `json:"options,omitempty"`
+	ContentFormat string   `json:"contentFormat,omitempty"`
+	// Payload is the raw payload, it is omitted when the payload contains secrets.
+	Payload []byte `json:"payload,omitempty"`
+	// Body is the JSON representation of CBOR payload.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Redacted is set when secrets were removed from the payload.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Record is one exchange between the client application and a device.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Duration string    `json:"duration"`
+	DeviceID string    `json:"deviceId,omitempty"`
+	// Endpoint of the device in format {SCHEME}://{ADDRESS}.
+	Endpoint  string   `json:"endpoint"`
+	Multicast bool     `json:"multicast,omitempty"`
+	Request   Message  `json:"request"`
+	Response  *Message `json:"response,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func formatOptionValue(o message.Option) string {
+	def, ok := message.CoapOptionDefs[o.ID]
+	if !ok {
+		return hex.EncodeToString(o.Value)
+	}
+	switch def.ValueFormat {
+	case message.ValueString:
+		return string(o.Value)
+	case message.ValueUint:
+		v := make([]byte, 8)
+		copy(v[8-len(o.Value):], o.Value)
+		if o.ID == message.ContentFormat || o.ID == message.Accept {
+			return message.MediaType(binary.BigEndian.Uint64(v)).String()
+		}
+		return strconv.FormatUint(binary.BigEndian.Uint64(v), 10)
+	case message.ValueEmpty:
+		return ""
+	}
+	return hex.EncodeToString(o.Value)
+}
+
+func toOptions(opts message.Options) []Option {
+	res := make([]Option, 0, len(opts))
+	for _, o := range opts {
+		if o.ID == message.URIPath || o.ID == message.URIQuery {
+			// stored in path and queries
+			continue
+		}
+		res = append(res, Option{
+			ID:    o.ID,
+			Name:  o.ID.String(),
+			Value: formatOptionValue(o),
+		})
+	}
+	return res
+}
+
+// isSecret returns true for the payloads which can contain private keys.
+func isSecret(path string) bool {
+	return path == credential.ResourceURI
+}
+
+// redactedValue replaces the private data of credentials.
+const redactedValue = "<redacted>"
+
+func redactPrivateData(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		if _, ok := val["data"]; ok {
+			val["data"] = redactedValue
+		}
+		return val
+	case map[string]interface{}:
+		if _, ok := val["data"]; ok {
+			val["data"] = redactedValue
+		}
+		return val
+	}
+	return redactedValue
+}
+
+// redact removes private data of credentials.
+func redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		for k, item := range val {
+			if k == "privatedata" {
+				val[k] = redactPrivateData(item)
+				continue
+			}
+			val[k] = redact(item)
+		}
+	case map[string]interface{}:
+		for k, item := range val {
+			if k == "privatedata" {
+				val[k] = redactPrivateData(item)
+				continue
+			}
+			val[k] = redact(item)
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = redact(val[i])
+		}
+	}
+	return v
+}
+
+func (m *Message) setPayload(path string, contentFormat message.MediaType, payload []byte) {
+	if len(payload) == 0 {
+		return
+	}
+	m.Payload = payload
+	if contentFormat != message.AppCBOR && contentFormat != message.AppOcfCbor {
+		return
+	}
+	if isSecret(path) {
+		var v interface{}
+		if err := cbor.Decode(payload, &v); err != nil {
+			m.Payload = nil
+			m.Redacted = true
+			return
+		}
+		data, err := cbor.Encode(redact(v))
+		if err != nil {
+			m.Payload = nil
+			m.Redacted = true
+			return
+		}
+		m.Payload = nil
+		m.Redacted = true
+		payload = data
+	}
+	if body, err := cbor.ToJSON(payload); err == nil {
+		m.Body = json.RawMessage(body)
+	}
+}
+
+// NewRequest creates the message from the request parameters.
+func NewRequest(code codes.Code, path string, contentFormat message.MediaType, payload []byte, opts ...message.Option) Message {
+	options := message.Options(opts)
+	queries, _ := options.Queries()
+	m := Message{
+		Code:    code.String(),
+		Path:    path,
+		Queries: queries,
+		Options: toOptions(options),
+	}
+	if len(payload) > 0 {
+		m.ContentFormat = contentFormat.String()
+		m.setPayload(path, contentFormat, payload)
+	}
+	return m
+}
+
+func readBody(m *pool.Message) ([]byte, error) {
+	body, err := m.ReadBody()
+	if err != nil {
+		return nil, err
+	}
+	if m.Body() != nil {
+		// the body is decoded later by the caller
+		if _, err = m.Body().Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// NewResponse creates the message from the response, path is the path of the request.
+func NewResponse(path string, m *pool.Message) *Message {
+	r := &Message{
+		Code:    m.Code().String(),
+		Options: toOptions(m.Options()),
+	}
+	body, err := readBody(m)
+	if err != nil || len(body) == 0 {
+		return r
+	}
+	contentFormat, err := m.ContentFormat()
+	if err != nil {
+		contentFormat = message.TextPlain
+	} else {
+		r.ContentFormat = contentFormat.String()
+	}
+	r.setPayload(path, contentFormat, body)
+	return r
+}
+
+// Read decodes JSON-lines records.
+func Read(r io.Reader) ([]Record, error) {
+	records := make([]Record, 0, 32)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("invalid record at line %v: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReadFile reads records from the capture file.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("cannot open capture: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	records, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read capture: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("capture is empty")
+	}
+	return records, nil
+}
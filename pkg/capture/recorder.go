@@ -0,0 +1,143 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+// Recorder writes records as JSON lines. The device ID of the record is resolved from the remote
+// address, the addresses are learned from the discovery and device resource responses.
+type Recorder struct {
+	mutex     sync.Mutex
+	w         io.Writer
+	closer    io.Closer
+	deviceIDs map[string]string
+}
+
+// NewRecorder creates the recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		w:         w,
+		deviceIDs: make(map[string]string),
+	}
+}
+
+// NewFileRecorder creates the recorder appending to the file.
+func NewFileRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("cannot open capture file: %w", err)
+	}
+	r := NewRecorder(f)
+	r.closer = f
+	return r, nil
+}
+
+func endpointAddress(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// learnDeviceID stores the device IDs of the addresses from /oic/res and /oic/d responses.
+func (r *Recorder) learnDeviceID(remoteAddr, path string, payload []byte) {
+	switch path {
+	case resources.ResourceURI:
+		var links schema.ResourceLinks
+		if err := cbor.Decode(payload, &links); err != nil {
+			return
+		}
+		for _, l := range links {
+			if l.DeviceID == "" {
+				continue
+			}
+			r.deviceIDs[remoteAddr] = l.DeviceID
+			for _, ep := range l.Endpoints {
+				if addr := endpointAddress(ep.URI); addr != "" {
+					r.deviceIDs[addr] = l.DeviceID
+				}
+			}
+		}
+	case device.ResourceURI:
+		var d device.Device
+		if err := cbor.Decode(payload, &d); err != nil || d.ID == "" {
+			return
+		}
+		r.deviceIDs[remoteAddr] = d.ID
+	}
+}
+
+// Write writes the record, the device ID is filled when it is known for the endpoint.
+func (r *Recorder) Write(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("cannot encode record: %w", err)
+	}
+	data = append(data, '\n')
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, err = r.w.Write(data)
+	return err
+}
+
+// RecordResponse records the request and the response or the error of the request.
+func (r *Recorder) RecordResponse(scheme schema.Scheme, remoteAddr net.Addr, multicast bool, start time.Time, req Message, resp *pool.Message, respErr error) error {
+	rec := Record{
+		Time:      start,
+		Duration:  time.Since(start).String(),
+		Endpoint:  string(scheme) + "://" + remoteAddr.String(),
+		Multicast: multicast,
+		Request:   req,
+	}
+	if respErr != nil {
+		rec.Error = respErr.Error()
+	}
+	if resp != nil {
+		rec.Response = NewResponse(req.Path, resp)
+	}
+	r.mutex.Lock()
+	if rec.Response != nil && rec.Response.Payload != nil {
+		r.learnDeviceID(remoteAddr.String(), req.Path, rec.Response.Payload)
+	}
+	rec.DeviceID = r.deviceIDs[remoteAddr.String()]
+	r.mutex.Unlock()
+	return r.Write(rec)
+}
+
+// Close closes the underlying file.
+func (r *Recorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
@@ -0,0 +1,127 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package capture_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pkg/capture"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("timeout")
+
+func newResponse(t *testing.T, v interface{}) *pool.Message {
+	data, err := cbor.Encode(v)
+	require.NoError(t, err)
+	m := pool.NewMessage(nil)
+	m.SetCode(codes.Content)
+	m.SetContentFormat(message.AppOcfCbor)
+	m.SetBody(bytes.NewReader(data))
+	return m
+}
+
+func TestRecorder(t *testing.T) {
+	const deviceID = "00000000-0000-0000-0000-000000000001"
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	r, err := capture.NewFileRecorder(path)
+	require.NoError(t, err)
+
+	udpAddr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5683}
+	dtlsAddr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5684}
+	links := schema.ResourceLinks{
+		{
+			Href:     device.ResourceURI,
+			DeviceID: deviceID,
+			Endpoints: schema.Endpoints{
+				{URI: "coaps://" + dtlsAddr.String()},
+			},
+		},
+	}
+	resp := newResponse(t, links)
+	err = r.RecordResponse(schema.UDPScheme, udpAddr, true, time.Now(), capture.NewRequest(codes.GET, resources.ResourceURI, 0, nil), resp, nil)
+	require.NoError(t, err)
+	// the body can be decoded by the caller
+	var decoded schema.ResourceLinks
+	require.NoError(t, cbor.ReadFrom(resp.Body(), &decoded))
+	require.Equal(t, links[0].DeviceID, decoded[0].DeviceID)
+
+	cred := credential.CredentialUpdateRequest{
+		Credentials: []credential.Credential{
+			{
+				Subject: deviceID,
+				Type:    credential.CredentialType_SYMMETRIC_PAIR_WISE,
+				PrivateData: &credential.CredentialPrivateData{
+					DataInternal: "secret-key",
+					Encoding:     credential.CredentialPrivateDataEncoding_RAW,
+				},
+			},
+		},
+	}
+	data, err := cbor.Encode(cred)
+	require.NoError(t, err)
+	req := capture.NewRequest(codes.POST, credential.ResourceURI, message.AppOcfCbor, data, message.Option{ID: message.URIQuery, Value: []byte("di=" + deviceID)})
+	err = r.RecordResponse(schema.UDPSecureScheme, dtlsAddr, false, time.Now(), req, nil, errTest)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	records, err := capture.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, deviceID, records[0].DeviceID)
+	require.True(t, records[0].Multicast)
+	require.Equal(t, "coap://"+udpAddr.String(), records[0].Endpoint)
+	require.Equal(t, codes.Content.String(), records[0].Response.Code)
+	require.NotEmpty(t, records[0].Response.Payload)
+	require.Contains(t, string(records[0].Response.Body), deviceID)
+
+	// the device ID is learned from the endpoints of the discovery response
+	require.Equal(t, deviceID, records[1].DeviceID)
+	require.Equal(t, "coaps://"+dtlsAddr.String(), records[1].Endpoint)
+	require.Equal(t, []string{"di=" + deviceID}, records[1].Request.Queries)
+	require.Equal(t, errTest.Error(), records[1].Error)
+	require.Nil(t, records[1].Response)
+	require.True(t, records[1].Request.Redacted)
+	require.Empty(t, records[1].Request.Payload)
+	require.NotContains(t, string(records[1].Request.Body), "secret-key")
+}
+
+func TestRead(t *testing.T) {
+	records, err := capture.Read(strings.NewReader("{\"endpoint\":\"coap://127.0.0.1:5683\",\"request\":{\"code\":\"GET\"}}\n\n"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	_, err = capture.Read(strings.NewReader("{\n"))
+	require.Error(t, err)
+
+	_, err = capture.ReadFile(filepath.Join(t.TempDir(), "notExist.jsonl"))
+	require.Error(t, err)
+}
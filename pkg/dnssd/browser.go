@@ -0,0 +1,271 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Package dnssd browses DNS-SD services (RFC 6763) announced by multicast DNS (RFC 6762). The queries are sent from
+// an ephemeral port, so the responders answer by unicast directly to the browser.
+package dnssd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// MulticastAddressUDP4 is the IPv4 multicast DNS address.
+	MulticastAddressUDP4 = "224.0.0.251:5353"
+	// MulticastAddressUDP6 is the IPv6 link-local multicast DNS address.
+	MulticastAddressUDP6 = "[ff02::fb]:5353"
+
+	domain        = "local."
+	maxPacketSize = 9000
+)
+
+// Service is the resolved address of the service instance.
+type Service struct {
+	// Instance is the name of the service instance, e.g. "device._ocf._udp.local."
+	Instance string
+	// Type is the type of the service, e.g. "_ocf._udp"
+	Type string
+	// Host is the target host of the service instance, e.g. "device.local."
+	Host string
+	// AddrPort is the address of the host with the port of the service instance.
+	AddrPort netip.AddrPort
+}
+
+type srvRecord struct {
+	target string
+	port   uint16
+}
+
+type browser struct {
+	mutex     sync.Mutex
+	types     map[string]string // service name -> service type
+	instances map[string]string // instance name -> service type
+	srvs      map[string]srvRecord
+	hosts     map[string][]netip.Addr
+	queried   map[string]struct{}
+	reported  map[string]struct{}
+}
+
+func newBrowser(serviceTypes []string) *browser {
+	b := &browser{
+		types:     make(map[string]string, len(serviceTypes)),
+		instances: make(map[string]string),
+		srvs:      make(map[string]srvRecord),
+		hosts:     make(map[string][]netip.Addr),
+		queried:   make(map[string]struct{}),
+		reported:  make(map[string]struct{}),
+	}
+	for _, t := range serviceTypes {
+		b.types[canonicalName(strings.TrimSuffix(t, ".")+"."+domain)] = t
+	}
+	return b
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(name)
+}
+
+func toAddr(ip []byte, zone string) netip.Addr {
+	addr, _ := netip.AddrFromSlice(ip)
+	addr = addr.Unmap()
+	if addr.Is6() && addr.IsLinkLocalUnicast() && zone != "" {
+		addr = addr.WithZone(zone)
+	}
+	return addr
+}
+
+func (b *browser) addHost(name string, addr netip.Addr) {
+	for _, a := range b.hosts[name] {
+		if a == addr {
+			return
+		}
+	}
+	b.hosts[name] = append(b.hosts[name], addr)
+}
+
+func (b *browser) processRecord(r dnsmessage.Resource, zone string) {
+	name := canonicalName(r.Header.Name.String())
+	switch body := r.Body.(type) {
+	case *dnsmessage.PTRResource:
+		if t, ok := b.types[name]; ok {
+			b.instances[canonicalName(body.PTR.String())] = t
+		}
+	case *dnsmessage.SRVResource:
+		b.srvs[name] = srvRecord{target: canonicalName(body.Target.String()), port: body.Port}
+	case *dnsmessage.AResource:
+		b.addHost(name, toAddr(body.A[:], ""))
+	case *dnsmessage.AAAAResource:
+		b.addHost(name, toAddr(body.AAAA[:], zone))
+	}
+}
+
+func (b *browser) question(name string, t dnsmessage.Type) (dnsmessage.Question, bool) {
+	key := t.String() + " " + name
+	if _, ok := b.queried[key]; ok {
+		return dnsmessage.Question{}, false
+	}
+	b.queried[key] = struct{}{}
+	return dnsmessage.Question{Name: dnsmessage.MustNewName(name), Type: t, Class: dnsmessage.ClassINET}, true
+}
+
+// process returns the newly resolved services and the questions for the records which are missing to resolve the services.
+func (b *browser) process(msg *dnsmessage.Message, zone string) ([]Service, []dnsmessage.Question) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, r := range msg.Answers {
+		b.processRecord(r, zone)
+	}
+	for _, r := range msg.Additionals {
+		b.processRecord(r, zone)
+	}
+	var services []Service
+	var questions []dnsmessage.Question
+	for instance, t := range b.instances {
+		srv, ok := b.srvs[instance]
+		if !ok {
+			if q, ok := b.question(instance, dnsmessage.TypeSRV); ok {
+				questions = append(questions, q)
+			}
+			continue
+		}
+		addrs, ok := b.hosts[srv.target]
+		if !ok {
+			for _, qt := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+				if q, ok := b.question(srv.target, qt); ok {
+					questions = append(questions, q)
+				}
+			}
+			continue
+		}
+		for _, addr := range addrs {
+			addrPort := netip.AddrPortFrom(addr, srv.port)
+			key := instance + " " + addrPort.String()
+			if _, ok := b.reported[key]; ok {
+				continue
+			}
+			b.reported[key] = struct{}{}
+			services = append(services, Service{
+				Instance: instance,
+				Type:     t,
+				Host:     srv.target,
+				AddrPort: addrPort,
+			})
+		}
+	}
+	return services, questions
+}
+
+func (b *browser) serviceQuestions() []dnsmessage.Question {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	questions := make([]dnsmessage.Question, 0, len(b.types))
+	for name := range b.types {
+		if q, ok := b.question(name, dnsmessage.TypePTR); ok {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+func sendQuestions(conn *net.UDPConn, addr *net.UDPAddr, questions []dnsmessage.Question) error {
+	msg := dnsmessage.Message{Questions: questions}
+	data, err := msg.Pack()
+	if err != nil {
+		return err
+	}
+	_, err = conn.WriteToUDP(data, addr)
+	return err
+}
+
+func (b *browser) browse(ctx context.Context, address string, questions []dnsmessage.Question, onService func(Service)) error {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return err
+	}
+	network := "udp6"
+	if addr.IP.To4() != nil {
+		network = "udp4"
+	}
+	conn, err := net.ListenUDP(network, nil)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer func() {
+		if stop() {
+			_ = conn.Close()
+		}
+	}()
+	if err = sendQuestions(conn, addr, questions); err != nil {
+		return fmt.Errorf("cannot send query to %v: %w", address, err)
+	}
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		var msg dnsmessage.Message
+		if err = msg.Unpack(buf[:n]); err != nil || !msg.Header.Response {
+			continue
+		}
+		services, questions := b.process(&msg, src.Zone)
+		for _, s := range services {
+			onService(s)
+		}
+		if len(questions) > 0 {
+			if err = sendQuestions(conn, addr, questions); err != nil {
+				return fmt.Errorf("cannot send query to %v: %w", address, err)
+			}
+		}
+	}
+}
+
+// Browse sends the queries for the service types, e.g. "_ocf._udp", to the multicast DNS addresses and calls onService
+// for each resolved address of a service instance until the ctx is done. The onService can be called concurrently.
+func Browse(ctx context.Context, addresses []string, serviceTypes []string, onService func(Service)) error {
+	b := newBrowser(serviceTypes)
+	questions := b.serviceQuestions()
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+	for _, address := range addresses {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			if err := b.browse(ctx, address, questions, onService); err != nil {
+				mutex.Lock()
+				defer mutex.Unlock()
+				errs = append(errs, err)
+			}
+		}(address)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,146 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package dnssd_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pkg/dnssd"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type responder struct {
+	conn *net.UDPConn
+	// withAdditionals sends the SRV and A records with the PTR record, otherwise they are sent only when they are queried
+	withAdditionals bool
+}
+
+func newResponder(t *testing.T, withAdditionals bool) *responder {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	r := &responder{conn: conn, withAdditionals: withAdditionals}
+	go r.serve()
+	return r
+}
+
+func (r *responder) address() string {
+	return r.conn.LocalAddr().String()
+}
+
+func (r *responder) close() {
+	_ = r.conn.Close()
+}
+
+func resource(name string, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 120},
+		Body:   body,
+	}
+}
+
+func (r *responder) answer(q dnsmessage.Question) []dnsmessage.Resource {
+	ptr := resource("_ocf._udp.local.", &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName("device._ocf._udp.local.")})
+	srv := resource("device._ocf._udp.local.", &dnsmessage.SRVResource{Target: dnsmessage.MustNewName("device.local."), Port: 5683})
+	a := resource("device.local.", &dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}})
+	switch {
+	case q.Type == dnsmessage.TypePTR && q.Name.String() == "_ocf._udp.local.":
+		if r.withAdditionals {
+			return []dnsmessage.Resource{ptr, srv, a}
+		}
+		return []dnsmessage.Resource{ptr}
+	case q.Type == dnsmessage.TypeSRV && q.Name.String() == "device._ocf._udp.local.":
+		return []dnsmessage.Resource{srv}
+	case q.Type == dnsmessage.TypeA && q.Name.String() == "device.local.":
+		return []dnsmessage.Resource{a}
+	}
+	return nil
+}
+
+func (r *responder) serve() {
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if err = query.Unpack(buf[:n]); err != nil {
+			continue
+		}
+		resp := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: query.Header.ID, Response: true, Authoritative: true},
+			Questions: query.Questions,
+		}
+		for _, q := range query.Questions {
+			resp.Answers = append(resp.Answers, r.answer(q)...)
+		}
+		if len(resp.Answers) == 0 {
+			continue
+		}
+		data, err := resp.Pack()
+		if err != nil {
+			continue
+		}
+		_, _ = r.conn.WriteToUDP(data, src)
+	}
+}
+
+func browse(t *testing.T, address string) []dnssd.Service {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer cancel()
+	var mutex sync.Mutex
+	var services []dnssd.Service
+	err := dnssd.Browse(ctx, []string{address}, []string{"_ocf._udp", "_coap._udp"}, func(s dnssd.Service) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		services = append(services, s)
+	})
+	require.NoError(t, err)
+	return services
+}
+
+func TestBrowse(t *testing.T) {
+	want := []dnssd.Service{
+		{
+			Instance: "device._ocf._udp.local.",
+			Type:     "_ocf._udp",
+			Host:     "device.local.",
+			AddrPort: netip.MustParseAddrPort("127.0.0.1:5683"),
+		},
+	}
+
+	r := newResponder(t, true)
+	defer r.close()
+	require.Equal(t, want, browse(t, r.address()))
+
+	r = newResponder(t, false)
+	defer r.close()
+	require.Equal(t, want, browse(t, r.address()))
+}
+
+func TestBrowseInvalidAddress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	err := dnssd.Browse(ctx, []string{"invalid"}, []string{"_ocf._udp"}, func(dnssd.Service) {})
+	require.Error(t, err)
+}
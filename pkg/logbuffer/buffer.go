@@ -0,0 +1,67 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Package logbuffer keeps the recent log lines in memory so they can be included in the diagnostics.
+package logbuffer
+
+import (
+	"sync"
+)
+
+// DefaultSize is the default number of the kept log lines.
+const DefaultSize = 1000
+
+// Buffer is a ring buffer of the log lines, when it is full the oldest line is overwritten.
+type Buffer struct {
+	mutex sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// New creates the buffer which keeps the last size lines.
+func New(size int) *Buffer {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Buffer{
+		lines: make([]string, size),
+	}
+}
+
+// Add appends the line to the buffer.
+func (b *Buffer) Add(line string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.lines[b.next] = line
+	b.next++
+	if b.next == len(b.lines) {
+		b.next = 0
+		b.full = true
+	}
+}
+
+// Lines returns the kept lines from the oldest one.
+func (b *Buffer) Lines() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.full {
+		return append([]string(nil), b.lines[:b.next]...)
+	}
+	lines := make([]string, 0, len(b.lines))
+	lines = append(lines, b.lines[b.next:]...)
+	return append(lines, b.lines[:b.next]...)
+}
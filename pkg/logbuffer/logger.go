@@ -0,0 +1,144 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package logbuffer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger writes the enabled log messages to the wrapped logger and to the buffer.
+type Logger struct {
+	log.Logger
+	buffer *Buffer
+	fields string
+}
+
+// NewLogger wraps the logger so the enabled messages are also kept in the buffer.
+func NewLogger(logger log.Logger, buffer *Buffer) *Logger {
+	return &Logger{
+		Logger: logger,
+		buffer: buffer,
+	}
+}
+
+// Buffer returns the buffer with the recent log lines.
+func (l *Logger) Buffer() *Buffer {
+	return l.buffer
+}
+
+func (l *Logger) add(lvl zapcore.Level, msg string) {
+	if !l.Logger.Check(lvl) {
+		return
+	}
+	l.buffer.Add(time.Now().Format(time.RFC3339Nano) + "\t" + lvl.CapitalString() + "\t" + msg + l.fields)
+}
+
+func (l *Logger) Debug(args ...interface{}) {
+	l.add(log.DebugLevel, fmt.Sprint(args...))
+	l.Logger.Debug(args...)
+}
+
+func (l *Logger) Info(args ...interface{}) {
+	l.add(log.InfoLevel, fmt.Sprint(args...))
+	l.Logger.Info(args...)
+}
+
+func (l *Logger) Warn(args ...interface{}) {
+	l.add(log.WarnLevel, fmt.Sprint(args...))
+	l.Logger.Warn(args...)
+}
+
+func (l *Logger) Error(args ...interface{}) {
+	l.add(log.ErrorLevel, fmt.Sprint(args...))
+	l.Logger.Error(args...)
+}
+
+func (l *Logger) Fatal(args ...interface{}) {
+	l.add(log.FatalLevel, fmt.Sprint(args...))
+	l.Logger.Fatal(args...)
+}
+
+func (l *Logger) Debugf(template string, args ...interface{}) {
+	l.add(log.DebugLevel, fmt.Sprintf(template, args...))
+	l.Logger.Debugf(template, args...)
+}
+
+func (l *Logger) Infof(template string, args ...interface{}) {
+	l.add(log.InfoLevel, fmt.Sprintf(template, args...))
+	l.Logger.Infof(template, args...)
+}
+
+func (l *Logger) Warnf(template string, args ...interface{}) {
+	l.add(log.WarnLevel, fmt.Sprintf(template, args...))
+	l.Logger.Warnf(template, args...)
+}
+
+func (l *Logger) Errorf(template string, args ...interface{}) {
+	l.add(log.ErrorLevel, fmt.Sprintf(template, args...))
+	l.Logger.Errorf(template, args...)
+}
+
+func (l *Logger) Fatalf(template string, args ...interface{}) {
+	l.add(log.FatalLevel, fmt.Sprintf(template, args...))
+	l.Logger.Fatalf(template, args...)
+}
+
+func (l *Logger) LogAndReturnError(err error) error {
+	if err != nil {
+		l.add(log.ErrorLevel, err.Error())
+	}
+	return l.Logger.LogAndReturnError(err)
+}
+
+func (l *Logger) GetLogFunc(lvl zapcore.Level) func(args ...interface{}) {
+	switch lvl {
+	case log.DebugLevel:
+		return l.Debug
+	case log.InfoLevel:
+		return l.Info
+	case log.WarnLevel:
+		return l.Warn
+	case log.ErrorLevel:
+		return l.Error
+	case log.FatalLevel:
+		return l.Fatal
+	}
+	return l.Logger.GetLogFunc(lvl)
+}
+
+// With returns the logger with the fields which are also appended to the buffered lines.
+func (l *Logger) With(args ...interface{}) log.Logger {
+	var fields strings.Builder
+	fields.WriteString(l.fields)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&fields, "\t%v=%v", args[i], args[i+1])
+			continue
+		}
+		fmt.Fprintf(&fields, "\t%v", args[i])
+	}
+	return &Logger{
+		Logger: l.Logger.With(args...),
+		buffer: l.buffer,
+		fields: fields.String(),
+	}
+}
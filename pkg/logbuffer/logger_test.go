@@ -0,0 +1,59 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package logbuffer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/plgd-dev/client-application/pkg/logbuffer"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	b := logbuffer.New(3)
+	require.Empty(t, b.Lines())
+	b.Add("1")
+	b.Add("2")
+	require.Equal(t, []string{"1", "2"}, b.Lines())
+	b.Add("3")
+	b.Add("4")
+	b.Add("5")
+	require.Equal(t, []string{"3", "4", "5"}, b.Lines())
+}
+
+func TestLogger(t *testing.T) {
+	cfg := log.MakeDefaultConfig()
+	cfg.Level = log.InfoLevel
+	b := logbuffer.New(10)
+	logger := logbuffer.NewLogger(log.NewLogger(cfg), b)
+
+	logger.Debugf("debug %v", 1)
+	logger.Infof("info %v", 2)
+	logger.With("deviceID", "a").Warn("warn")
+	_ = logger.LogAndReturnError(errors.New("error"))
+	logger.GetLogFunc(log.InfoLevel)("func")
+
+	lines := b.Lines()
+	require.Len(t, lines, 4)
+	require.True(t, strings.HasSuffix(lines[0], "INFO\tinfo 2"), lines[0])
+	require.True(t, strings.HasSuffix(lines[1], "WARN\twarn\tdeviceID=a"), lines[1])
+	require.True(t, strings.HasSuffix(lines[2], "ERROR\terror"), lines[2])
+	require.True(t, strings.HasSuffix(lines[3], "INFO\tfunc"), lines[3])
+}
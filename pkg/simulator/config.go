@@ -0,0 +1,115 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/device/v2/schema/resources"
+)
+
+const DefaultAddress = "127.0.0.1"
+
+// reservedHrefs are served by the simulator itself and cannot be used by custom resources.
+var reservedHrefs = map[string]struct{}{
+	resources.ResourceURI:  {},
+	device.ResourceURI:     {},
+	doxm.ResourceURI:       {},
+	pstat.ResourceURI:      {},
+	acl.ResourceURI:        {},
+	credential.ResourceURI: {},
+	csr.ResourceURI:        {},
+	cloud.ResourceURI:      {},
+}
+
+// Resource is a custom resource served by the simulated device. The value is returned
+// by GET and merged with the body of POST requests.
+type Resource struct {
+	Href          string                 `yaml:"href" json:"href"`
+	ResourceTypes []string               `yaml:"resourceTypes" json:"resourceTypes"`
+	Interfaces    []string               `yaml:"interfaces" json:"interfaces"`
+	Value         map[string]interface{} `yaml:"value" json:"value"`
+}
+
+func (r *Resource) Validate() error {
+	if !strings.HasPrefix(r.Href, "/") {
+		return fmt.Errorf("href('%v') - must start with '/'", r.Href)
+	}
+	if _, ok := reservedHrefs[r.Href]; ok {
+		return fmt.Errorf("href('%v') - is reserved", r.Href)
+	}
+	if len(r.ResourceTypes) == 0 {
+		return fmt.Errorf("resourceTypes('%v') - is empty", r.ResourceTypes)
+	}
+	if len(r.Interfaces) == 0 {
+		r.Interfaces = []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW}
+	}
+	return nil
+}
+
+// Config describes a simulated device.
+type Config struct {
+	// ID of the device, a random one is generated when empty.
+	ID   string `yaml:"id" json:"id"`
+	Name string `yaml:"name" json:"name"`
+	// ResourceTypes are device types reported by /oic/d in addition to oic.wk.d.
+	ResourceTypes []string   `yaml:"resourceTypes" json:"resourceTypes"`
+	Resources     []Resource `yaml:"resources" json:"resources"`
+	// Address is the IP address the listeners are bound to, the ports are allocated by the system.
+	Address string `yaml:"address" json:"address"`
+	// Multicast enables responses to IPv4 multicast discovery.
+	Multicast bool `yaml:"multicast" json:"multicast"`
+}
+
+func (c *Config) Validate() error {
+	if c.ID != "" {
+		if _, err := uuid.Parse(c.ID); err != nil {
+			return fmt.Errorf("id('%v') - %w", c.ID, err)
+		}
+	}
+	if c.Name == "" {
+		return fmt.Errorf("name('%v') - is empty", c.Name)
+	}
+	if c.Address == "" {
+		c.Address = DefaultAddress
+	}
+	if net.ParseIP(c.Address) == nil {
+		return fmt.Errorf("address('%v') - invalid IP address", c.Address)
+	}
+	hrefs := make(map[string]struct{}, len(c.Resources))
+	for i := range c.Resources {
+		if err := c.Resources[i].Validate(); err != nil {
+			return fmt.Errorf("resources[%v].%w", i, err)
+		}
+		if _, ok := hrefs[c.Resources[i].Href]; ok {
+			return fmt.Errorf("resources[%v].href('%v') - is duplicated", i, c.Resources[i].Href)
+		}
+		hrefs[c.Resources[i].Href] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"gopkg.in/yaml.v3"
+)
+
+// Description is the yaml or json description of simulated devices. The devices are used as templates
+// when more devices are requested than described.
+type Description struct {
+	Devices []Config `yaml:"devices" json:"devices"`
+}
+
+func (d *Description) Validate() error {
+	if len(d.Devices) == 0 {
+		return errors.New("devices('[]') - is empty")
+	}
+	for i := range d.Devices {
+		if err := d.Devices[i].Validate(); err != nil {
+			return fmt.Errorf("devices[%v].%w", i, err)
+		}
+	}
+	return nil
+}
+
+// ParseDescription decodes and validates the description, json is accepted as a subset of yaml.
+func ParseDescription(data []byte) (*Description, error) {
+	var d Description
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+	return &d, nil
+}
+
+// LoadDescription reads the description from the file.
+func LoadDescription(path string) (*Description, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("cannot read description: %w", err)
+	}
+	return ParseDescription(data)
+}
+
+// MakeLightConfig returns configuration of a device with the /light/1 resource.
+func MakeLightConfig(name string) Config {
+	return Config{
+		Name:          name,
+		ResourceTypes: []string{"oic.d.light"},
+		Resources: []Resource{
+			{
+				Href:          "/light/1",
+				ResourceTypes: []string{"core.light"},
+				Interfaces:    []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE},
+				Value: map[string]interface{}{
+					"state": false,
+					"power": uint64(0),
+					"name":  "Light",
+				},
+			},
+		},
+	}
+}
+
+// DefaultDescription describes a light device.
+func DefaultDescription() *Description {
+	return &Description{
+		Devices: []Config{MakeLightConfig("simulated-light")},
+	}
+}
+
+// MakeConfigs returns configurations of n devices. The described devices are repeated in order and
+// each device gets the name with the index suffix. A described ID is used only by the first occurrence.
+func (d *Description) MakeConfigs(n int) []Config {
+	cfgs := make([]Config, 0, n)
+	for i := 0; i < n && len(d.Devices) > 0; i++ {
+		tmpl := d.Devices[i%len(d.Devices)]
+		cfg := tmpl
+		if i >= len(d.Devices) {
+			cfg.ID = ""
+		}
+		cfg.Name = fmt.Sprintf("%v-%v", tmpl.Name, i)
+		cfg.Resources = make([]Resource, 0, len(tmpl.Resources))
+		for _, r := range tmpl.Resources {
+			r.Value = cloneMap(r.Value)
+			cfg.Resources = append(cfg.Resources, r)
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs
+}
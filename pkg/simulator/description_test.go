@@ -0,0 +1,142 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pkg/simulator"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDescription(t *testing.T) {
+	id := uuid.NewString()
+	tests := []struct {
+		name    string
+		data    string
+		want    []simulator.Config
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			data: `
+devices:
+  - id: ` + id + `
+    name: light
+    resourceTypes: [oic.d.light]
+    resources:
+      - href: /light/1
+        resourceTypes: [core.light]
+        value:
+          power: 1
+  - name: switch
+    resources:
+      - href: /switch/1
+        resourceTypes: [oic.r.switch.binary]
+        interfaces: [oic.if.a]
+`,
+			want: []simulator.Config{
+				{
+					ID:            id,
+					Name:          "light",
+					ResourceTypes: []string{"oic.d.light"},
+					Address:       simulator.DefaultAddress,
+					Resources: []simulator.Resource{
+						{
+							Href:          "/light/1",
+							ResourceTypes: []string{"core.light"},
+							Interfaces:    []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW},
+							Value:         map[string]interface{}{"power": 1},
+						},
+					},
+				},
+				{
+					Name:    "switch",
+					Address: simulator.DefaultAddress,
+					Resources: []simulator.Resource{
+						{
+							Href:          "/switch/1",
+							ResourceTypes: []string{"oic.r.switch.binary"},
+							Interfaces:    []string{interfaces.OC_IF_A},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "json",
+			data: `{"devices":[{"name":"light","address":"127.0.0.1"}]}`,
+			want: []simulator.Config{
+				{
+					Name:    "light",
+					Address: simulator.DefaultAddress,
+				},
+			},
+		},
+		{
+			name:    "empty",
+			data:    `devices: []`,
+			wantErr: true,
+		},
+		{
+			name:    "reserved href",
+			data:    `{"devices":[{"name":"light","resources":[{"href":"/oic/d","resourceTypes":["x"]}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid id",
+			data:    `{"devices":[{"id":"invalid","name":"light"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := simulator.ParseDescription([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Devices)
+		})
+	}
+}
+
+func TestDescriptionMakeConfigs(t *testing.T) {
+	id := uuid.NewString()
+	light := simulator.MakeLightConfig("light")
+	light.ID = id
+	d := simulator.Description{
+		Devices: []simulator.Config{light, simulator.MakeLightConfig("lamp")},
+	}
+	cfgs := d.MakeConfigs(3)
+	require.Len(t, cfgs, 3)
+	require.Equal(t, "light-0", cfgs[0].Name)
+	require.Equal(t, id, cfgs[0].ID)
+	require.Equal(t, "lamp-1", cfgs[1].Name)
+	require.Equal(t, "light-2", cfgs[2].Name)
+	require.Empty(t, cfgs[2].ID)
+
+	// resources of the devices are independent
+	cfgs[0].Resources[0].Value["power"] = uint64(42)
+	require.Equal(t, uint64(0), cfgs[2].Resources[0].Value["power"])
+	require.Equal(t, uint64(0), d.Devices[0].Resources[0].Value["power"])
+
+	require.Empty(t, d.MakeConfigs(0))
+}
@@ -0,0 +1,373 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/dtls"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	coapNet "github.com/plgd-dev/go-coap/v3/net"
+	"github.com/plgd-dev/go-coap/v3/options"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/plgd-dev/go-coap/v3/udp"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+)
+
+type server interface {
+	Stop()
+}
+
+type listener interface {
+	Close() error
+}
+
+// Device is a virtual OCF device served on loopback UDP, TCP and DTLS.
+type Device struct {
+	cfg    Config
+	logger log.Logger
+
+	mutex     sync.Mutex
+	id        uuid.UUID
+	resources map[string]*Resource
+	security  securityState
+	// privateKey is used for the self-signed certificate and for the identity certificate signing request.
+	privateKey     *ecdsa.PrivateKey
+	selfSignedCert tls.Certificate
+	unsecureEps    schema.Endpoints
+	secureEps      schema.Endpoints
+	servers        []server
+	listeners      []listener
+	wg             sync.WaitGroup
+	closeOnce      sync.Once
+	// replay is set when the device replays a capture instead of serving its resources.
+	replay *replayState
+	// linksObservers are notified when the resource links change.
+	linksObservers map[linksObserver]struct{}
+	linksSequence  uint32
+}
+
+// NewDevice creates and starts a simulated device.
+func NewDevice(cfg Config, logger log.Logger) (*Device, error) {
+	return newDevice(cfg, logger, nil)
+}
+
+func newDevice(cfg Config, logger log.Logger, replay *replayState) (*Device, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	id := uuid.New()
+	if cfg.ID != "" {
+		id = uuid.MustParse(cfg.ID)
+	}
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate private key: %w", err)
+	}
+	selfSignedCert, err := newSelfSignedCertificate(id, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create self-signed certificate: %w", err)
+	}
+	d := &Device{
+		cfg:            cfg,
+		logger:         logger,
+		id:             id,
+		resources:      make(map[string]*Resource, len(cfg.Resources)),
+		privateKey:     privateKey,
+		selfSignedCert: selfSignedCert,
+		replay:         replay,
+		linksObservers: make(map[linksObserver]struct{}),
+	}
+	for i := range cfg.Resources {
+		r := cfg.Resources[i]
+		r.Value = cloneMap(r.Value)
+		d.resources[r.Href] = &r
+	}
+	d.security.reset()
+	if err = d.serve(); err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func getPort(addr net.Addr) (int, error) {
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(port)
+}
+
+func (d *Device) endpoint(scheme schema.Scheme, addr net.Addr) (schema.Endpoint, error) {
+	port, err := getPort(addr)
+	if err != nil {
+		return schema.Endpoint{}, err
+	}
+	return schema.Endpoint{
+		URI:      string(scheme) + "://" + net.JoinHostPort(d.cfg.Address, strconv.Itoa(port)),
+		Priority: 1,
+	}, nil
+}
+
+func (d *Device) onError(err error) {
+	d.logger.Debugf("simulated device %v: %v", d.cfg.Name, err)
+}
+
+func (d *Device) serveUDP(router *mux.Router) error {
+	l, err := coapNet.NewListenUDP(udpNetwork(d.cfg.Address), net.JoinHostPort(d.cfg.Address, "0"))
+	if err != nil {
+		return fmt.Errorf("cannot listen on udp: %w", err)
+	}
+	d.listeners = append(d.listeners, l)
+	ep, err := d.endpoint(schema.UDPScheme, l.LocalAddr())
+	if err != nil {
+		return err
+	}
+	d.unsecureEps = append(d.unsecureEps, ep)
+	s := udp.NewServer(options.WithMux(router), options.WithErrors(d.onError))
+	d.servers = append(d.servers, s)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if errS := s.Serve(l); errS != nil {
+			d.onError(fmt.Errorf("udp server: %w", errS))
+		}
+	}()
+	return nil
+}
+
+func (d *Device) serveTCP(router *mux.Router) error {
+	network := "tcp4"
+	if udpNetwork(d.cfg.Address) == "udp6" {
+		network = "tcp6"
+	}
+	l, err := coapNet.NewTCPListener(network, net.JoinHostPort(d.cfg.Address, "0"))
+	if err != nil {
+		return fmt.Errorf("cannot listen on tcp: %w", err)
+	}
+	d.listeners = append(d.listeners, l)
+	ep, err := d.endpoint(schema.TCPScheme, l.Addr())
+	if err != nil {
+		return err
+	}
+	d.unsecureEps = append(d.unsecureEps, ep)
+	s := tcp.NewServer(options.WithMux(router), options.WithErrors(d.onError))
+	d.servers = append(d.servers, s)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if errS := s.Serve(l); errS != nil {
+			d.onError(fmt.Errorf("tcp server: %w", errS))
+		}
+	}()
+	return nil
+}
+
+func (d *Device) serveDTLS(router *mux.Router) error {
+	l, err := coapNet.NewDTLSListener(udpNetwork(d.cfg.Address), net.JoinHostPort(d.cfg.Address, "0"), d.newDTLSConfig())
+	if err != nil {
+		return fmt.Errorf("cannot listen on dtls: %w", err)
+	}
+	d.listeners = append(d.listeners, l)
+	ep, err := d.endpoint(schema.UDPSecureScheme, l.Addr())
+	if err != nil {
+		return err
+	}
+	d.secureEps = append(d.secureEps, ep)
+	s := dtls.NewServer(options.WithMux(router), options.WithErrors(d.onError))
+	d.servers = append(d.servers, s)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		if errS := s.Serve(l); errS != nil {
+			d.onError(fmt.Errorf("dtls server: %w", errS))
+		}
+	}()
+	return nil
+}
+
+func (d *Device) serveMulticast(router *mux.Router) error {
+	var errs *multierror.Error
+	var joined bool
+	for _, addr := range core.DefaultDiscoveryConfiguration().MulticastAddressUDP4 {
+		l, err := newMulticastConn(addr)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		d.listeners = append(d.listeners, l)
+		s := udp.NewServer(options.WithMux(router), options.WithErrors(d.onError))
+		d.servers = append(d.servers, s)
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			if errS := s.Serve(l); errS != nil {
+				d.onError(fmt.Errorf("multicast server: %w", errS))
+			}
+		}()
+		joined = true
+	}
+	if !joined {
+		return fmt.Errorf("cannot listen on multicast: %w", errs.ErrorOrNil())
+	}
+	return nil
+}
+
+func (d *Device) serve() error {
+	unsecureRouter := mux.NewRouter()
+	unsecureRouter.DefaultHandle(d.newHandler(connectionUnsecure))
+	secureRouter := mux.NewRouter()
+	secureRouter.DefaultHandle(d.newHandler(connectionSecure))
+	if err := d.serveUDP(unsecureRouter); err != nil {
+		return err
+	}
+	if err := d.serveTCP(unsecureRouter); err != nil {
+		return err
+	}
+	if err := d.serveDTLS(secureRouter); err != nil {
+		return err
+	}
+	if !d.cfg.Multicast {
+		return nil
+	}
+	multicastRouter := mux.NewRouter()
+	multicastRouter.DefaultHandle(d.newHandler(connectionMulticast))
+	return d.serveMulticast(multicastRouter)
+}
+
+func udpNetwork(address string) string {
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		return "udp6"
+	}
+	return "udp4"
+}
+
+func newMulticastConn(multicastAddr string) (*coapNet.UDPConn, error) {
+	a, err := net.ResolveUDPAddr("udp4", multicastAddr)
+	if err != nil {
+		return nil, err
+	}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	l, err := coapNet.NewListenUDP("udp4", multicastAddr)
+	if err != nil {
+		return nil, err
+	}
+	var joined bool
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagMulticast == 0 {
+			continue
+		}
+		if err = l.JoinGroup(&ifaces[i], a); err == nil {
+			joined = true
+		}
+	}
+	if !joined {
+		_ = l.Close()
+		return nil, fmt.Errorf("cannot join multicast group %v", multicastAddr)
+	}
+	if err = l.SetMulticastLoopback(true); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// ID returns the current device ID.
+func (d *Device) ID() uuid.UUID {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.id
+}
+
+// Name returns the name of the device.
+func (d *Device) Name() string {
+	return d.cfg.Name
+}
+
+// Endpoints returns unsecure and secure endpoints of the device.
+func (d *Device) Endpoints() schema.Endpoints {
+	eps := make(schema.Endpoints, 0, len(d.unsecureEps)+len(d.secureEps))
+	eps = append(eps, d.unsecureEps...)
+	return append(eps, d.secureEps...)
+}
+
+// UDPAddress returns the host:port of the unsecure UDP endpoint, it can be used in GetDevicesRequest.UseEndpoints.
+func (d *Device) UDPAddress() string {
+	for _, ep := range d.unsecureEps {
+		if addr, err := ep.GetAddr(); err == nil && addr.GetScheme() == string(schema.UDPScheme) {
+			return net.JoinHostPort(addr.GetHostname(), strconv.Itoa(int(addr.GetPort())))
+		}
+	}
+	return ""
+}
+
+// IsOwned returns true when the ownership transfer has been finished.
+func (d *Device) IsOwned() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.security.owned
+}
+
+// OwnerID returns the owner of the device or an empty string when it is not owned.
+func (d *Device) OwnerID() string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.security.owned {
+		return ""
+	}
+	return d.security.ownerID
+}
+
+// FactoryReset resets security state and cloud configuration as when the device is disowned.
+func (d *Device) FactoryReset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.security.reset()
+}
+
+// Close stops all servers of the device.
+func (d *Device) Close() error {
+	var errs *multierror.Error
+	d.closeOnce.Do(func() {
+		for _, s := range d.servers {
+			s.Stop()
+		}
+		for _, l := range d.listeners {
+			if err := l.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		d.wg.Wait()
+	})
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,230 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+type connectionType int
+
+const (
+	connectionUnsecure connectionType = iota
+	connectionSecure
+	connectionMulticast
+)
+
+type request struct {
+	code    codes.Code
+	href    string
+	queries []string
+	body    []byte
+	conn    connectionType
+}
+
+func (r request) query(key string) []string {
+	prefix := key + "="
+	values := make([]string, 0, len(r.queries))
+	for _, q := range r.queries {
+		if strings.HasPrefix(q, prefix) {
+			values = append(values, strings.TrimPrefix(q, prefix))
+		}
+	}
+	return values
+}
+
+func (r request) isBaseline() bool {
+	for _, v := range r.query("if") {
+		if v == interfaces.OC_IF_BASELINE {
+			return true
+		}
+	}
+	return false
+}
+
+func (r request) decode(v interface{}) error {
+	if len(r.body) == 0 {
+		return errors.New("empty body")
+	}
+	return cbor.Decode(r.body, v)
+}
+
+type response struct {
+	code codes.Code
+	body interface{}
+}
+
+type statusError struct {
+	code codes.Code
+	err  error
+}
+
+func (e statusError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(code codes.Code, format string, a ...any) error {
+	return statusError{code: code, err: fmt.Errorf(format, a...)}
+}
+
+func newRequest(r *mux.Message, conn connectionType) (request, error) {
+	href, err := r.Path()
+	if err != nil {
+		return request{}, err
+	}
+	queries, err := r.Queries()
+	if err != nil && !errors.Is(err, message.ErrOptionNotFound) {
+		return request{}, err
+	}
+	var body []byte
+	if b := r.Body(); b != nil {
+		if body, err = io.ReadAll(b); err != nil {
+			return request{}, err
+		}
+	}
+	return request{
+		code:    r.Code(),
+		href:    "/" + strings.TrimPrefix(href, "/"),
+		queries: queries,
+		body:    body,
+		conn:    conn,
+	}, nil
+}
+
+func setErrorResponse(w mux.ResponseWriter, err error) {
+	code := codes.BadRequest
+	var statusErr statusError
+	if errors.As(err, &statusErr) {
+		code = statusErr.code
+	}
+	_ = w.SetResponse(code, message.TextPlain, bytes.NewReader([]byte(err.Error())))
+}
+
+func (d *Device) newHandler(conn connectionType) mux.Handler {
+	return mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
+		req, err := newRequest(r, conn)
+		if err != nil {
+			setErrorResponse(w, err)
+			return
+		}
+		if d.replay != nil {
+			d.replayResponse(w, req)
+			return
+		}
+		resp, err := d.handle(req)
+		if conn == connectionMulticast && (err != nil || resp.body == nil) {
+			// devices don't respond to multicast requests which they cannot serve
+			return
+		}
+		if err != nil {
+			d.onError(fmt.Errorf("%v %v: %w", req.code, req.href, err))
+			setErrorResponse(w, err)
+			return
+		}
+		if resp.body == nil {
+			_ = w.SetResponse(resp.code, message.TextPlain, nil)
+			return
+		}
+		data, err := cbor.Encode(resp.body)
+		if err != nil {
+			setErrorResponse(w, errorf(codes.InternalServerError, "cannot encode response: %w", err))
+			return
+		}
+		_ = w.SetResponse(resp.code, message.AppOcfCbor, bytes.NewReader(data))
+		if seq, ok := d.observeResourceLinks(w, r, req); ok {
+			w.Message().SetObserve(seq)
+		}
+	})
+}
+
+// isSecurityResource returns true for resources that are accessible only via secure connection.
+func isSecurityResource(href string) bool {
+	switch href {
+	case pstat.ResourceURI, acl.ResourceURI, credential.ResourceURI, csr.ResourceURI:
+		return true
+	}
+	return false
+}
+
+func (d *Device) checkAccess(req request) error {
+	if req.conn == connectionSecure {
+		return nil
+	}
+	switch req.href {
+	case resources.ResourceURI, device.ResourceURI:
+		return nil
+	}
+	if req.conn == connectionMulticast {
+		return errorf(codes.BadRequest, "resource %v is not accessible via multicast", req.href)
+	}
+	if d.security.owned || isSecurityResource(req.href) {
+		return errorf(codes.Unauthorized, "resource %v is accessible only via secure connection", req.href)
+	}
+	return nil
+}
+
+func (d *Device) handle(req request) (response, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := d.checkAccess(req); err != nil {
+		return response{}, err
+	}
+	switch req.href {
+	case resources.ResourceURI:
+		return d.handleDiscovery(req)
+	case device.ResourceURI:
+		return d.handleDevice(req)
+	case doxm.ResourceURI:
+		return d.handleDoxm(req)
+	case pstat.ResourceURI:
+		return d.handlePstat(req)
+	case acl.ResourceURI:
+		return d.handleACL(req)
+	case credential.ResourceURI:
+		return d.handleCredential(req)
+	case csr.ResourceURI:
+		return d.handleCSR(req)
+	case cloud.ResourceURI:
+		return d.handleCloudConfiguration(req)
+	}
+	if r, ok := d.resources[req.href]; ok {
+		return d.handleResource(req, r)
+	}
+	return response{}, errorf(codes.NotFound, "resource %v not found", req.href)
+}
+
+func methodNotAllowed(req request) error {
+	return errorf(codes.MethodNotAllowed, "method %v is not allowed for resource %v", req.code, req.href)
+}
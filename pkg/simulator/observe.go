@@ -0,0 +1,126 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+// linksObserver identifies the observation of /oic/res by the connection and the token of the request.
+type linksObserver struct {
+	conn  mux.Conn
+	token string
+}
+
+// observeResourceLinks registers or deregisters the observer of /oic/res by the observe option of the request.
+// It returns the sequence number of the response when the observer was registered.
+func (d *Device) observeResourceLinks(w mux.ResponseWriter, r *mux.Message, req request) (uint32, bool) {
+	if req.href != resources.ResourceURI || req.code != codes.GET || req.conn == connectionMulticast {
+		return 0, false
+	}
+	obs, err := r.Options().Observe()
+	if err != nil {
+		return 0, false
+	}
+	o := linksObserver{conn: w.Conn(), token: string(r.Token())}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if obs != 0 {
+		delete(d.linksObservers, o)
+		return 0, false
+	}
+	if _, ok := d.linksObservers[o]; !ok {
+		d.linksObservers[o] = struct{}{}
+		o.conn.AddOnClose(func() {
+			d.mutex.Lock()
+			defer d.mutex.Unlock()
+			delete(d.linksObservers, o)
+		})
+	}
+	d.linksSequence++
+	return d.linksSequence, true
+}
+
+func writeNotification(o linksObserver, seq uint32, data []byte) error {
+	m := o.conn.AcquireMessage(o.conn.Context())
+	defer o.conn.ReleaseMessage(m)
+	m.SetCode(codes.Content)
+	m.SetToken(message.Token(o.token))
+	m.SetContentFormat(message.AppOcfCbor)
+	m.SetBody(bytes.NewReader(data))
+	m.SetObserve(seq)
+	if c, ok := o.conn.(interface{ GetMessageID() int32 }); ok {
+		// the UDP notifications require the message ID
+		m.SetMessageID(c.GetMessageID())
+		m.SetType(message.NonConfirmable)
+	}
+	return o.conn.WriteMessage(m)
+}
+
+// notifyResourceLinks sends the current links to the observers of /oic/res.
+func (d *Device) notifyResourceLinks() {
+	d.mutex.Lock()
+	data, err := cbor.Encode(d.links())
+	if err != nil {
+		d.mutex.Unlock()
+		d.onError(fmt.Errorf("cannot encode resource links: %w", err))
+		return
+	}
+	d.linksSequence++
+	seq := d.linksSequence
+	observers := make([]linksObserver, 0, len(d.linksObservers))
+	for o := range d.linksObservers {
+		observers = append(observers, o)
+	}
+	d.mutex.Unlock()
+	for _, o := range observers {
+		if err := writeNotification(o, seq, data); err != nil {
+			d.onError(fmt.Errorf("cannot notify resource links: %w", err))
+		}
+	}
+}
+
+// AddResource adds the resource to the device and notifies the observers of /oic/res.
+func (d *Device) AddResource(r Resource) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	r.Value = cloneMap(r.Value)
+	d.mutex.Lock()
+	if _, ok := d.resources[r.Href]; ok {
+		d.mutex.Unlock()
+		return fmt.Errorf("href('%v') - is duplicated", r.Href)
+	}
+	d.resources[r.Href] = &r
+	d.mutex.Unlock()
+	d.notifyResourceLinks()
+	return nil
+}
+
+// ResourceLinksObservers returns the number of the observations of /oic/res.
+func (d *Device) ResourceLinksObservers() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.linksObservers)
+}
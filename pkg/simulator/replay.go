@@ -0,0 +1,178 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pkg/capture"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+)
+
+// ReplayConfig describes a device which responds with the responses of a capture.
+type ReplayConfig struct {
+	// Records of the capture, see capture.ReadFile.
+	Records []capture.Record
+	// DeviceID selects the records of the device, when empty the device of the first record is used.
+	DeviceID string
+	// PreSharedKey is accepted for the DTLS connections of any identity.
+	PreSharedKey []byte
+	// Address is the IP address the listeners are bound to.
+	Address string
+	// Multicast enables responses to IPv4 multicast discovery.
+	Multicast bool
+}
+
+func (c *ReplayConfig) Validate() error {
+	if len(c.Records) == 0 {
+		return errors.New("records('[]') - is empty")
+	}
+	if c.DeviceID == "" {
+		for _, r := range c.Records {
+			if r.DeviceID != "" {
+				c.DeviceID = r.DeviceID
+				break
+			}
+		}
+	}
+	if _, err := uuid.Parse(c.DeviceID); err != nil {
+		return fmt.Errorf("deviceID('%v') - %w", c.DeviceID, err)
+	}
+	return nil
+}
+
+type replayState struct {
+	player       *capture.Player
+	preSharedKey []byte
+}
+
+// NewReplayDevice creates and starts a device which replays the captured responses of the device.
+// The endpoints in the discovery responses are replaced by the endpoints of the replay device.
+func NewReplayDevice(cfg ReplayConfig, logger log.Logger) (*Device, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	player, err := capture.NewPlayer(cfg.Records, cfg.DeviceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return newDevice(Config{
+		ID:        cfg.DeviceID,
+		Name:      "replay-" + cfg.DeviceID,
+		Address:   cfg.Address,
+		Multicast: cfg.Multicast,
+	}, logger, &replayState{
+		player:       player,
+		preSharedKey: cfg.PreSharedKey,
+	})
+}
+
+// replaceEndpoints replaces captured endpoints in the links by the endpoints of the device with the same scheme.
+func (d *Device) replaceEndpoints(v interface{}) interface{} {
+	uris := make(map[string]string)
+	for _, ep := range d.Endpoints() {
+		if u, err := url.Parse(ep.URI); err == nil {
+			uris[u.Scheme] = ep.URI
+		}
+	}
+	links, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	for _, l := range links {
+		link, ok := l.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		eps, ok := link["eps"].([]interface{})
+		if !ok {
+			continue
+		}
+		replaced := make([]interface{}, 0, len(eps))
+		for _, e := range eps {
+			ep, ok := e.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			uri, _ := ep["ep"].(string)
+			u, err := url.Parse(uri)
+			if err != nil {
+				continue
+			}
+			if newURI, ok := uris[u.Scheme]; ok {
+				ep["ep"] = newURI
+				replaced = append(replaced, ep)
+			}
+		}
+		link["eps"] = replaced
+	}
+	return links
+}
+
+func (d *Device) replayPayload(req request, m *capture.Message) ([]byte, error) {
+	payload, err := m.GetPayload()
+	if err != nil || len(payload) == 0 || req.href != resources.ResourceURI {
+		return payload, err
+	}
+	var v interface{}
+	if err = cbor.Decode(payload, &v); err != nil {
+		return nil, err
+	}
+	return cbor.Encode(d.replaceEndpoints(v))
+}
+
+func (d *Device) replayResponse(w mux.ResponseWriter, req request) {
+	m, ok := d.replay.player.Play(req.code, req.href, req.queries)
+	if !ok {
+		if req.conn == connectionMulticast {
+			return
+		}
+		d.onError(fmt.Errorf("%v %v: response not captured", req.code, req.href))
+		setErrorResponse(w, errorf(codes.NotFound, "response to %v %v is not captured", req.code, req.href))
+		return
+	}
+	code, err := codes.ToCode(m.Code)
+	if err != nil {
+		setErrorResponse(w, errorf(codes.InternalServerError, "invalid captured code: %w", err))
+		return
+	}
+	payload, err := d.replayPayload(req, m)
+	if err != nil {
+		setErrorResponse(w, errorf(codes.InternalServerError, "invalid captured payload: %w", err))
+		return
+	}
+	if len(payload) == 0 {
+		_ = w.SetResponse(code, message.TextPlain, nil)
+		return
+	}
+	contentFormat := message.AppOcfCbor
+	if m.ContentFormat != "" {
+		if contentFormat, err = message.ToMediaType(m.ContentFormat); err != nil {
+			contentFormat = message.AppOcfCbor
+		}
+	}
+	_ = w.SetResponse(code, contentFormat, bytes.NewReader(payload))
+}
@@ -0,0 +1,181 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/capture"
+	"github.com/plgd-dev/client-application/pkg/simulator"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	serviceGrpc "github.com/plgd-dev/client-application/service/grpc"
+	serviceHttp "github.com/plgd-dev/client-application/service/http"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+func discoverDevice(ctx context.Context, t *testing.T, s *serviceGrpc.ClientApplicationServer, addr string) *pb.Device {
+	devices := getDevices(ctx, t, s, &pb.GetDevicesRequest{
+		UseEndpoints: []string{addr},
+		Timeout:      (time.Second).Nanoseconds(),
+	})
+	require.Len(t, devices, 1)
+	return devices[0]
+}
+
+func ownDevice(t *testing.T, addr string, opts ...func(cfg *configDevice.Config)) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	s, teardown := newClientApplicationServer(ctx, t, opts...)
+	defer teardown()
+
+	d := discoverDevice(ctx, t, s, addr)
+	require.Equal(t, grpcgwPb.Device_UNOWNED, d.GetOwnershipStatus())
+	_, err := s.OwnDevice(ctx, &pb.OwnDeviceRequest{
+		DeviceId: d.GetId(),
+	})
+	require.NoError(t, err)
+}
+
+// updateAndGetLight updates and returns the power of the light of the owned device.
+func updateAndGetLight(t *testing.T, addr string, opts ...func(cfg *configDevice.Config)) (string, uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	s, teardown := newClientApplicationServer(ctx, t, opts...)
+	defer teardown()
+
+	d := discoverDevice(ctx, t, s, addr)
+	require.Equal(t, grpcgwPb.Device_OWNED, d.GetOwnershipStatus())
+	resourceID := commands.NewResourceID(d.GetId(), "/light/1")
+	_, err := s.UpdateResource(ctx, &pb.UpdateResourceRequest{
+		ResourceId: resourceID,
+		Content: &grpcgwPb.Content{
+			ContentType: serviceHttp.ApplicationJsonContentType,
+			Data:        []byte(`{"power":42}`),
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId: resourceID,
+	})
+	require.NoError(t, err)
+	var value map[string]interface{}
+	err = cbor.Decode(res.GetData().GetContent().GetData(), &value)
+	require.NoError(t, err)
+	power, ok := value["power"].(uint64)
+	require.True(t, ok)
+	return d.GetId(), power
+}
+
+func withCapture(path string) func(cfg *configDevice.Config) {
+	return func(cfg *configDevice.Config) {
+		cfg.COAP.Capture.FilePath = path
+	}
+}
+
+func readCapture(t *testing.T, path, deviceID string) ([]capture.Record, map[string]bool) {
+	records, err := capture.ReadFile(path)
+	require.NoError(t, err)
+	paths := make(map[string]bool)
+	for _, r := range records {
+		require.Equal(t, deviceID, r.DeviceID)
+		require.NotEmpty(t, r.Endpoint)
+		require.NotEmpty(t, r.Request.Code)
+		require.NotNil(t, r.Response)
+		paths[r.Request.Path] = true
+	}
+	return records, paths
+}
+
+func TestCaptureAndReplay(t *testing.T) {
+	cfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	cfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, cfg)
+	defer tearDown()
+	dev := sim.Devices()[0]
+	deviceID := dev.ID().String()
+
+	ownPath := filepath.Join(t.TempDir(), "own.jsonl")
+	ownDevice(t, dev.UDPAddress(), withCapture(ownPath))
+	records, paths := readCapture(t, ownPath, deviceID)
+	for _, p := range []string{resources.ResourceURI, device.ResourceURI, doxm.ResourceURI, credential.ResourceURI} {
+		require.True(t, paths[p], "path %v not captured", p)
+	}
+	for _, r := range records {
+		if r.Request.Path == credential.ResourceURI && r.Request.Code == codes.POST.String() {
+			require.True(t, r.Request.Redacted)
+			require.Empty(t, r.Request.Payload)
+			var body credential.CredentialUpdateRequest
+			require.NoError(t, json.Unmarshal(r.Request.Body, &body))
+			require.NotEmpty(t, body.Credentials)
+			for _, c := range body.Credentials {
+				require.Equal(t, []byte("<redacted>"), c.PrivateData.Data())
+			}
+		}
+	}
+
+	sessionPath := filepath.Join(t.TempDir(), "session.jsonl")
+	gotID, power := updateAndGetLight(t, dev.UDPAddress(), withCapture(sessionPath))
+	require.Equal(t, deviceID, gotID)
+	require.Equal(t, uint64(42), power)
+	records, paths = readCapture(t, sessionPath, deviceID)
+	require.True(t, paths["/light/1"])
+	require.NoError(t, sim.Close())
+
+	// iotivity-lite supports only 16-byte PSK
+	psk := []byte(test.MakeDeviceConfig().COAP.TLS.PreSharedKey.Key)[:16]
+	replay, err := simulator.NewReplayDevice(simulator.ReplayConfig{
+		Records:      records,
+		PreSharedKey: psk,
+	}, log.NewLogger(log.MakeDefaultConfig()))
+	require.NoError(t, err)
+	defer func() {
+		_ = replay.Close()
+	}()
+	require.Equal(t, deviceID, replay.ID().String())
+	require.True(t, strings.HasPrefix(replay.UDPAddress(), simulator.DefaultAddress))
+
+	gotID, power = updateAndGetLight(t, replay.UDPAddress())
+	require.Equal(t, deviceID, gotID)
+	require.Equal(t, uint64(42), power)
+}
+
+func TestNewReplayDeviceInvalidConfig(t *testing.T) {
+	_, err := simulator.NewReplayDevice(simulator.ReplayConfig{}, log.NewLogger(log.MakeDefaultConfig()))
+	require.Error(t, err)
+
+	_, err = simulator.NewReplayDevice(simulator.ReplayConfig{
+		Records: []capture.Record{{Request: capture.Message{Code: "GET", Path: "/oic/d"}}},
+	}, log.NewLogger(log.MakeDefaultConfig()))
+	require.Error(t, err)
+}
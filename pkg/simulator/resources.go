@@ -0,0 +1,229 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+)
+
+const (
+	dataModelVersion     = "ocf.res.1.3.0,ocf.sh.1.3.0"
+	specificationVersion = "ocf.2.0.5"
+)
+
+func cloneValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = cloneValue(item)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(val))
+		for k, item := range val {
+			m[k] = cloneValue(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, 0, len(val))
+		for _, item := range val {
+			s = append(s, cloneValue(item))
+		}
+		return s
+	}
+	return v
+}
+
+func cloneMap(v map[string]interface{}) map[string]interface{} {
+	if v == nil {
+		return map[string]interface{}{}
+	}
+	return cloneValue(v).(map[string]interface{})
+}
+
+func (d *Device) deviceResourceTypes() []string {
+	return append([]string{device.ResourceType}, d.cfg.ResourceTypes...)
+}
+
+func (d *Device) endpoints(secureOnly bool) schema.Endpoints {
+	if secureOnly {
+		return slices.Clone(d.secureEps)
+	}
+	return d.Endpoints()
+}
+
+func (d *Device) newLink(href string, resourceTypes, ifs []string, secureOnly bool) schema.ResourceLink {
+	return schema.ResourceLink{
+		Href:          href,
+		ResourceTypes: resourceTypes,
+		Interfaces:    ifs,
+		Policy: &schema.Policy{
+			BitMask: schema.Discoverable,
+			Secured: &secureOnly,
+		},
+		Endpoints: d.endpoints(secureOnly),
+		Anchor:    "ocf://" + d.id.String(),
+		DeviceID:  d.id.String(),
+	}
+}
+
+// links returns links of all resources. Before the ownership transfer the resources which are not
+// security related are accessible via unsecure endpoints.
+func (d *Device) links() schema.ResourceLinks {
+	owned := d.security.owned
+	links := schema.ResourceLinks{
+		d.newLink(resources.ResourceURI, []string{resources.ResourceType}, []string{interfaces.OC_IF_LL, interfaces.OC_IF_BASELINE}, false),
+		d.newLink(device.ResourceURI, d.deviceResourceTypes(), []string{interfaces.OC_IF_R, interfaces.OC_IF_BASELINE}, false),
+		d.newLink(doxm.ResourceURI, []string{doxm.ResourceType}, []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}, owned),
+		d.newLink(pstat.ResourceURI, []string{pstat.ResourceType}, []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}, true),
+		d.newLink(acl.ResourceURI, []string{acl.ResourceType}, []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}, true),
+		d.newLink(credential.ResourceURI, []string{credential.ResourceType}, []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}, true),
+		d.newLink(csr.ResourceURI, []string{csr.ResourceType}, []string{interfaces.OC_IF_R, interfaces.OC_IF_BASELINE}, true),
+		d.newLink(cloud.ResourceURI, []string{cloud.ResourceType}, []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}, owned),
+	}
+	hrefs := make([]string, 0, len(d.resources))
+	for href := range d.resources {
+		hrefs = append(hrefs, href)
+	}
+	sort.Strings(hrefs)
+	for _, href := range hrefs {
+		r := d.resources[href]
+		links = append(links, d.newLink(r.Href, r.ResourceTypes, r.Interfaces, owned))
+	}
+	return links
+}
+
+func (d *Device) handleDiscovery(req request) (response, error) {
+	if req.code != codes.GET {
+		return response{}, methodNotAllowed(req)
+	}
+	links := d.links()
+	if rts := req.query("rt"); len(rts) > 0 {
+		filtered := make(schema.ResourceLinks, 0, len(links))
+		for _, l := range links {
+			for _, rt := range rts {
+				if slices.Contains(l.ResourceTypes, rt) {
+					filtered = append(filtered, l)
+					break
+				}
+			}
+		}
+		links = filtered
+	}
+	if len(links) == 0 {
+		if req.conn == connectionMulticast {
+			return response{}, nil
+		}
+		return response{}, errorf(codes.NotFound, "no resource matches the query %v", req.queries)
+	}
+	return response{code: codes.Content, body: links}, nil
+}
+
+func (d *Device) handleDevice(req request) (response, error) {
+	if req.code != codes.GET {
+		return response{}, methodNotAllowed(req)
+	}
+	return response{code: codes.Content, body: device.Device{
+		ID:                    d.id.String(),
+		ResourceTypes:         d.deviceResourceTypes(),
+		Interfaces:            []string{interfaces.OC_IF_R, interfaces.OC_IF_BASELINE},
+		Name:                  d.cfg.Name,
+		ProtocolIndependentID: uuid.NewSHA1(d.id, []byte("piid")).String(),
+		DataModelVersion:      dataModelVersion,
+		SpecificationVersion:  specificationVersion,
+	}}, nil
+}
+
+func (d *Device) cloudConfiguration() cloud.Configuration {
+	cfg := d.security.cloud
+	cfg.ResourceTypes = []string{cloud.ResourceType}
+	cfg.Interfaces = []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}
+	return cfg
+}
+
+// handleCloudConfiguration stores the configuration of the cloud. The simulated device doesn't connect
+// to the hub, so the provisioning status stays in the registering state until the configuration is reset.
+func (d *Device) handleCloudConfiguration(req request) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: d.cloudConfiguration()}, nil
+	case codes.POST:
+		var upd cloud.ConfigurationUpdateRequest
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode cloud configuration: %w", err)
+		}
+		if upd.URL == "" {
+			d.security.cloud = cloud.Configuration{
+				ProvisioningStatus: cloud.ProvisioningStatus_UNINITIALIZED,
+			}
+		} else {
+			d.security.cloud = cloud.Configuration{
+				AuthorizationProvider: upd.AuthorizationProvider,
+				CloudID:               upd.CloudID,
+				URL:                   upd.URL,
+				Endpoints:             upd.Endpoints,
+				ProvisioningStatus:    cloud.ProvisioningStatus_REGISTERING,
+			}
+		}
+		return response{code: codes.Changed, body: d.cloudConfiguration()}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
+
+func (d *Device) resourceValue(req request, r *Resource) map[string]interface{} {
+	v := cloneMap(r.Value)
+	if req.isBaseline() {
+		v["rt"] = r.ResourceTypes
+		v["if"] = r.Interfaces
+	}
+	return v
+}
+
+func (d *Device) handleResource(req request, r *Resource) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: d.resourceValue(req, r)}, nil
+	case codes.POST:
+		var upd map[string]interface{}
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode resource %v: %w", r.Href, err)
+		}
+		if r.Value == nil {
+			r.Value = make(map[string]interface{}, len(upd))
+		}
+		for k, v := range upd {
+			r.Value[k] = v
+		}
+		return response{code: codes.Changed, body: d.resourceValue(req, r)}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
@@ -0,0 +1,307 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/pkg/security/generateCertificate"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+)
+
+var securityInterfaces = []string{interfaces.OC_IF_RW, interfaces.OC_IF_BASELINE}
+
+func (d *Device) doxm() doxm.Doxm {
+	return doxm.Doxm{
+		ResourceOwner:                 d.security.resourceOwner,
+		SupportedOwnerTransferMethods: []doxm.OwnerTransferMethod{doxm.JustWorks},
+		OwnerID:                       d.security.ownerID,
+		DeviceID:                      d.id.String(),
+		Owned:                         d.security.owned,
+		SupportedCredentialTypes:      credential.CredentialType_SYMMETRIC_PAIR_WISE | credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE,
+		SelectedOwnerTransferMethod:   d.security.oxmsel,
+		Interfaces:                    securityInterfaces,
+		ResourceTypes:                 []string{doxm.ResourceType},
+	}
+}
+
+func parseUUID(field, v string) (uuid.UUID, error) {
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return uuid.Nil, errorf(codes.BadRequest, "invalid %v('%v'): %w", field, v, err)
+	}
+	return id, nil
+}
+
+func (d *Device) updateDoxm(req request, upd doxm.DoxmUpdate) error {
+	if upd.SelectOwnerTransferMethod != nil {
+		if d.security.owned {
+			return errorf(codes.Forbidden, "cannot select owner transfer method of the owned device")
+		}
+		if *upd.SelectOwnerTransferMethod != doxm.JustWorks {
+			return errorf(codes.BadRequest, "owner transfer method %v is not supported", *upd.SelectOwnerTransferMethod)
+		}
+		d.security.oxmsel = *upd.SelectOwnerTransferMethod
+	}
+	if upd.DeviceID == nil && upd.OwnerID == nil && upd.ResourceOwner == nil && upd.Owned == nil {
+		return nil
+	}
+	if req.conn != connectionSecure || d.security.dos != pstat.OperationalState_RFOTM {
+		return errorf(codes.Unauthorized, "doxm can be updated only via secure connection in the RFOTM state")
+	}
+	if upd.DeviceID != nil {
+		id, err := parseUUID("deviceuuid", *upd.DeviceID)
+		if err != nil {
+			return err
+		}
+		d.id = id
+	}
+	if upd.OwnerID != nil {
+		if _, err := parseUUID("devowneruuid", *upd.OwnerID); err != nil {
+			return err
+		}
+		d.security.ownerID = *upd.OwnerID
+	}
+	if upd.ResourceOwner != nil {
+		if _, err := parseUUID("rowneruuid", *upd.ResourceOwner); err != nil {
+			return err
+		}
+		d.security.resourceOwner = *upd.ResourceOwner
+	}
+	if upd.Owned != nil {
+		d.security.owned = *upd.Owned
+	}
+	return nil
+}
+
+func (d *Device) handleDoxm(req request) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: d.doxm()}, nil
+	case codes.POST:
+		var upd doxm.DoxmUpdate
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode doxm: %w", err)
+		}
+		if err := d.updateDoxm(req, upd); err != nil {
+			return response{}, err
+		}
+		return response{code: codes.Changed}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
+
+func (d *Device) pstat() pstat.ProvisionStatusResponse {
+	return pstat.ProvisionStatusResponse{
+		ResourceOwner:             d.security.resourceOwner,
+		Interfaces:                securityInterfaces,
+		ResourceTypes:             []string{pstat.ResourceType},
+		CurrentOperationalMode:    d.security.om,
+		DeviceIsOperational:       d.security.dos == pstat.OperationalState_RFNOP,
+		SupportedOperationalModes: pstat.OperationalMode_CLIENT_DIRECTED,
+		DeviceOnboardingState: pstat.DeviceOnboardingState{
+			CurrentOrPendingOperationalState: d.security.dos,
+		},
+	}
+}
+
+func (d *Device) updateOperationalState(dos pstat.OperationalState) error {
+	switch dos {
+	case pstat.OperationalState_RESET:
+		d.security.reset()
+		return nil
+	case pstat.OperationalState_RFOTM:
+		if d.security.dos != pstat.OperationalState_RFOTM {
+			return errorf(codes.BadRequest, "cannot transition from %v to %v", d.security.dos, dos)
+		}
+		return nil
+	case pstat.OperationalState_RFPRO, pstat.OperationalState_RFNOP, pstat.OperationalState_SRESET:
+		if !d.security.owned {
+			return errorf(codes.BadRequest, "cannot transition to %v, device is not owned", dos)
+		}
+		d.security.dos = dos
+		return nil
+	}
+	return errorf(codes.BadRequest, "invalid operational state %v", dos)
+}
+
+func (d *Device) handlePstat(req request) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: d.pstat()}, nil
+	case codes.POST:
+		var upd pstat.ProvisionStatusUpdateRequest
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode pstat: %w", err)
+		}
+		if upd.ResourceOwner != "" {
+			if _, err := parseUUID("rowneruuid", upd.ResourceOwner); err != nil {
+				return response{}, err
+			}
+			d.security.resourceOwner = upd.ResourceOwner
+		}
+		if upd.CurrentOperationalMode != 0 {
+			if upd.CurrentOperationalMode&^pstat.OperationalMode_CLIENT_DIRECTED != 0 {
+				return response{}, errorf(codes.BadRequest, "operational mode %v is not supported", upd.CurrentOperationalMode)
+			}
+			d.security.om = upd.CurrentOperationalMode
+		}
+		if upd.DeviceOnboardingState != nil {
+			if err := d.updateOperationalState(upd.DeviceOnboardingState.CurrentOrPendingOperationalState); err != nil {
+				return response{}, err
+			}
+		}
+		return response{code: codes.Changed}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
+
+func (d *Device) handleACL(req request) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: acl.Response{
+			ResourceOwner:     d.security.resourceOwner,
+			Interfaces:        securityInterfaces,
+			ResourceTypes:     []string{acl.ResourceType},
+			AccessControlList: d.security.acls,
+		}}, nil
+	case codes.POST:
+		var upd acl.UpdateRequest
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode acl2: %w", err)
+		}
+		if upd.ResourceOwner != "" {
+			if _, err := parseUUID("rowneruuid", upd.ResourceOwner); err != nil {
+				return response{}, err
+			}
+			d.security.resourceOwner = upd.ResourceOwner
+		}
+		d.security.updateACLs(upd.AccessControlList)
+		return response{code: codes.Changed}, nil
+	case codes.DELETE:
+		var id int
+		if ids := req.query("aceid"); len(ids) > 0 {
+			var err error
+			if id, err = strconv.Atoi(ids[0]); err != nil {
+				return response{}, errorf(codes.BadRequest, "invalid aceid('%v'): %w", ids[0], err)
+			}
+		}
+		d.security.deleteACLs(id)
+		return response{code: codes.Deleted}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
+
+// credentials returns the credentials without the private data, as real devices do.
+func (d *Device) credentials() []credential.Credential {
+	creds := make([]credential.Credential, 0, len(d.security.creds))
+	for _, c := range d.security.creds {
+		c.PrivateData = nil
+		creds = append(creds, c)
+	}
+	return creds
+}
+
+func (d *Device) updateCredentials(upd credential.CredentialUpdateRequest) error {
+	for _, c := range upd.Credentials {
+		if c.Usage == credential.CredentialUsage_CERT {
+			if c.PublicData == nil {
+				return errorf(codes.BadRequest, "identity certificate of subject %v has no public data", c.Subject)
+			}
+			crt, err := newIdentityCertificate(c.PublicData.Data(), d.privateKey)
+			if err != nil {
+				return errorf(codes.BadRequest, "invalid identity certificate: %w", err)
+			}
+			d.security.identityCert = crt
+		}
+		d.security.updateCredential(c)
+	}
+	if upd.ResourceOwner != "" {
+		if _, err := parseUUID("rowneruuid", upd.ResourceOwner); err != nil {
+			return err
+		}
+		d.security.resourceOwner = upd.ResourceOwner
+	}
+	return nil
+}
+
+func (d *Device) deleteCredentials(req request) error {
+	if ids := req.query("credid"); len(ids) > 0 {
+		id, err := strconv.Atoi(ids[0])
+		if err != nil {
+			return errorf(codes.BadRequest, "invalid credid('%v'): %w", ids[0], err)
+		}
+		d.security.deleteCredentials(func(c credential.Credential) bool { return c.ID == id })
+		return nil
+	}
+	if subjects := req.query("subjectuuid"); len(subjects) > 0 {
+		d.security.deleteCredentials(func(c credential.Credential) bool { return c.Subject == subjects[0] })
+		return nil
+	}
+	d.security.deleteCredentials(func(credential.Credential) bool { return true })
+	return nil
+}
+
+func (d *Device) handleCredential(req request) (response, error) {
+	switch req.code {
+	case codes.GET:
+		return response{code: codes.Content, body: credential.CredentialResponse{
+			ResourceOwner: d.security.resourceOwner,
+			Interfaces:    securityInterfaces,
+			ResourceTypes: []string{credential.ResourceType},
+			Credentials:   d.credentials(),
+		}}, nil
+	case codes.POST:
+		var upd credential.CredentialUpdateRequest
+		if err := req.decode(&upd); err != nil {
+			return response{}, errorf(codes.BadRequest, "cannot decode cred: %w", err)
+		}
+		if err := d.updateCredentials(upd); err != nil {
+			return response{}, err
+		}
+		return response{code: codes.Changed}, nil
+	case codes.DELETE:
+		if err := d.deleteCredentials(req); err != nil {
+			return response{}, err
+		}
+		return response{code: codes.Deleted}, nil
+	}
+	return response{}, methodNotAllowed(req)
+}
+
+func (d *Device) handleCSR(req request) (response, error) {
+	if req.code != codes.GET {
+		return response{}, methodNotAllowed(req)
+	}
+	data, err := generateCertificate.GenerateIdentityCSR(generateCertificate.Configuration{}, d.id.String(), d.privateKey)
+	if err != nil {
+		return response{}, errorf(codes.InternalServerError, "cannot generate certificate signing request: %w", err)
+	}
+	return response{code: codes.Content, body: csr.CertificateSigningRequestResponse{
+		Interfaces:                []string{interfaces.OC_IF_R, interfaces.OC_IF_BASELINE},
+		ResourceTypes:             []string{csr.ResourceType},
+		Encoding:                  csr.CertificateEncoding_PEM,
+		CertificateSigningRequest: string(data),
+	}}, nil
+}
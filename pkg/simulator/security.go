@@ -0,0 +1,313 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v2"
+	"github.com/plgd-dev/device/v2/client/core/otm/just-works/cipher"
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+)
+
+// justWorksCipherSuiteID is the anonymous cipher suite used by the just-works ownership transfer.
+const justWorksCipherSuiteID = dtls.CipherSuiteID(0xff00)
+
+var nilUUID = uuid.Nil.String()
+
+// securityState holds the content of the security resources and the cloud configuration.
+// It is guarded by Device.mutex.
+type securityState struct {
+	owned         bool
+	ownerID       string
+	resourceOwner string
+	oxmsel        doxm.OwnerTransferMethod
+	dos           pstat.OperationalState
+	om            pstat.OperationalMode
+	acls          []acl.AccessControl
+	nextACLID     int
+	creds         []credential.Credential
+	nextCredID    int
+	identityCert  *tls.Certificate
+	cloud         cloud.Configuration
+}
+
+// reset puts the device to the ready for ownership transfer state.
+func (s *securityState) reset() {
+	*s = securityState{
+		ownerID:       nilUUID,
+		resourceOwner: nilUUID,
+		oxmsel:        doxm.JustWorks,
+		dos:           pstat.OperationalState_RFOTM,
+		om:            pstat.OperationalMode_CLIENT_DIRECTED,
+		nextACLID:     1,
+		nextCredID:    1,
+		cloud: cloud.Configuration{
+			ProvisioningStatus: cloud.ProvisioningStatus_UNINITIALIZED,
+		},
+	}
+}
+
+func (s *securityState) updateACLs(acls []acl.AccessControl) {
+	for _, ace := range acls {
+		if ace.ID == 0 {
+			ace.ID = s.nextACLID
+		}
+		if ace.ID >= s.nextACLID {
+			s.nextACLID = ace.ID + 1
+		}
+		s.acls = append(s.acls, ace)
+	}
+}
+
+func (s *securityState) deleteACLs(id int) {
+	if id == 0 {
+		s.acls = nil
+		return
+	}
+	acls := make([]acl.AccessControl, 0, len(s.acls))
+	for _, ace := range s.acls {
+		if ace.ID != id {
+			acls = append(acls, ace)
+		}
+	}
+	s.acls = acls
+}
+
+func sameCredential(a, b credential.Credential) bool {
+	if a.Subject != b.Subject || a.Type != b.Type || a.Usage != b.Usage {
+		return false
+	}
+	// trust anchors of the same subject can differ
+	return a.Usage != credential.CredentialUsage_TRUST_CA && a.Usage != credential.CredentialUsage_MFG_TRUST_CA
+}
+
+func (s *securityState) updateCredential(c credential.Credential) {
+	for i := range s.creds {
+		if (c.ID != 0 && s.creds[i].ID == c.ID) || (c.ID == 0 && sameCredential(s.creds[i], c)) {
+			c.ID = s.creds[i].ID
+			s.creds[i] = c
+			return
+		}
+	}
+	if c.ID == 0 {
+		c.ID = s.nextCredID
+	}
+	if c.ID >= s.nextCredID {
+		s.nextCredID = c.ID + 1
+	}
+	s.creds = append(s.creds, c)
+}
+
+func (s *securityState) deleteCredentials(filter func(c credential.Credential) bool) {
+	creds := make([]credential.Credential, 0, len(s.creds))
+	for _, c := range s.creds {
+		if filter(c) {
+			if c.Usage == credential.CredentialUsage_CERT {
+				s.identityCert = nil
+			}
+			continue
+		}
+		creds = append(creds, c)
+	}
+	s.creds = creds
+}
+
+func (s *securityState) getPSK(subject string) ([]byte, error) {
+	for _, c := range s.creds {
+		if !c.Type.Has(credential.CredentialType_SYMMETRIC_PAIR_WISE) || c.Subject != subject || c.PrivateData == nil {
+			continue
+		}
+		data := c.PrivateData.Data()
+		if c.PrivateData.Encoding == credential.CredentialPrivateDataEncoding_BASE64 {
+			return base64.StdEncoding.DecodeString(string(data))
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("pre-shared key for subject %v not found", subject)
+}
+
+func (s *securityState) getTrustAnchors() *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, c := range s.creds {
+		if c.Usage != credential.CredentialUsage_TRUST_CA || c.PublicData == nil {
+			continue
+		}
+		certs, err := pkgX509.ParsePemCertificates(c.PublicData.Data())
+		if err != nil {
+			continue
+		}
+		for _, crt := range certs {
+			pool.AddCert(crt)
+		}
+	}
+	return pool
+}
+
+func newIdentityCertificate(chainPem []byte, privateKey *ecdsa.PrivateKey) (*tls.Certificate, error) {
+	chain, err := pkgX509.ParsePemCertificates(chainPem)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("empty certificate chain")
+	}
+	publicKey, ok := chain[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok || !publicKey.Equal(&privateKey.PublicKey) {
+		return nil, errors.New("certificate doesn't match the private key of the device")
+	}
+	crt := &tls.Certificate{
+		PrivateKey: privateKey,
+		Leaf:       chain[0],
+	}
+	for _, c := range chain {
+		crt.Certificate = append(crt.Certificate, c.Raw)
+	}
+	return crt, nil
+}
+
+func newSelfSignedCertificate(id uuid.UUID, privateKey *ecdsa.PrivateKey) (tls.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "uuid:" + id.String()},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour * 24 * 365),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  privateKey,
+		Leaf:        leaf,
+	}, nil
+}
+
+func (d *Device) getPSK(identity []byte) ([]byte, error) {
+	if d.replay != nil {
+		return d.replay.preSharedKey, nil
+	}
+	subject, err := uuid.FromBytes(identity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity: %w", err)
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.security.getPSK(subject.String())
+}
+
+func (d *Device) getCertificate(*dtls.ClientHelloInfo) (*tls.Certificate, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.security.identityCert != nil {
+		return d.security.identityCert, nil
+	}
+	return &d.selfSignedCert, nil
+}
+
+func (d *Device) verifyPeerCertificates(rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return errors.New("client certificate is required")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		crt, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("cannot parse client certificate: %w", err)
+		}
+		certs = append(certs, crt)
+	}
+	intermediates := x509.NewCertPool()
+	for _, crt := range certs[1:] {
+		intermediates.AddCert(crt)
+	}
+	d.mutex.Lock()
+	roots := d.security.getTrustAnchors()
+	d.mutex.Unlock()
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+func (d *Device) verifyConnection(state *dtls.State) error {
+	if d.replay != nil {
+		// the credentials of the captured device are not known
+		return nil
+	}
+	switch state.CipherSuiteID {
+	case justWorksCipherSuiteID:
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if d.security.owned || d.security.oxmsel != doxm.JustWorks {
+			return errors.New("just-works ownership transfer is not allowed")
+		}
+		return nil
+	case dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256:
+		// the identity has been verified by the PSK callback
+		return nil
+	}
+	return d.verifyPeerCertificates(state.PeerCertificates)
+}
+
+func (d *Device) newDTLSConfig() *dtls.Config {
+	return &dtls.Config{
+		CipherSuites: []dtls.CipherSuiteID{
+			dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256,
+			dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8,
+			dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM,
+			dtls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			dtls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+		},
+		CustomCipherSuites: func() []dtls.CipherSuite {
+			return []dtls.CipherSuite{cipher.NewTLSAecdhAes128Sha256(justWorksCipherSuiteID)}
+		},
+		PSK:              d.getPSK,
+		GetCertificate:   d.getCertificate,
+		ClientAuth:       dtls.RequestClientCert,
+		VerifyConnection: d.verifyConnection,
+	}
+}
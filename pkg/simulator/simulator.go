@@ -0,0 +1,64 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Package simulator runs virtual OCF devices inside the process. The devices serve the discovery,
+// device and security resources, support the just-works ownership transfer and can be extended
+// by custom resources.
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+)
+
+// Simulator is a set of simulated devices.
+type Simulator struct {
+	devices []*Device
+}
+
+// New starts a simulated device for each configuration.
+func New(cfgs []Config, logger log.Logger) (*Simulator, error) {
+	s := &Simulator{
+		devices: make([]*Device, 0, len(cfgs)),
+	}
+	for i := range cfgs {
+		d, err := NewDevice(cfgs[i], logger)
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("cannot create device %v: %w", cfgs[i].Name, err)
+		}
+		s.devices = append(s.devices, d)
+	}
+	return s, nil
+}
+
+// Devices returns the simulated devices in the order of the configurations.
+func (s *Simulator) Devices() []*Device {
+	return s.devices
+}
+
+// Close stops all simulated devices.
+func (s *Simulator) Close() error {
+	var errs *multierror.Error
+	for _, d := range s.devices {
+		if err := d.Close(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
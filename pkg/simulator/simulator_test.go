@@ -0,0 +1,152 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/simulator"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	serviceGrpc "github.com/plgd-dev/client-application/service/grpc"
+	serviceHttp "github.com/plgd-dev/client-application/service/http"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/device/v2/schema/device"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+func newClientApplicationServer(ctx context.Context, t *testing.T, opts ...func(cfg *configDevice.Config)) (*serviceGrpc.ClientApplicationServer, func()) {
+	cfg := test.MakeSimulatorDeviceConfig()
+	for _, o := range opts {
+		o(&cfg)
+	}
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx, test.WithDeviceConfig(cfg))
+	require.NoError(t, err)
+	return s, teardown
+}
+
+func getDevices(ctx context.Context, t *testing.T, s *serviceGrpc.ClientApplicationServer, req *pb.GetDevicesRequest) []*pb.Device {
+	srv := test.NewClientApplicationGetDevicesServer(ctx)
+	err := s.GetDevices(req, srv)
+	require.NoError(t, err)
+	return srv.Devices
+}
+
+func findDevice(devices []*pb.Device, id string) *pb.Device {
+	for _, d := range devices {
+		if d.GetId() == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := simulator.New([]simulator.Config{{}}, log.NewLogger(log.MakeDefaultConfig()))
+	require.Error(t, err)
+
+	cfg := test.MakeSimulatorConfig("sim")
+	cfg.Resources = append(cfg.Resources, simulator.Resource{Href: "/oic/sec/doxm", ResourceTypes: []string{"x"}})
+	_, err = simulator.New([]simulator.Config{cfg}, log.NewLogger(log.MakeDefaultConfig()))
+	require.Error(t, err)
+}
+
+func TestOwnDisownDevice(t *testing.T) {
+	cfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	cfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, cfg)
+	defer tearDown()
+	dev := sim.Devices()[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	s, teardown := newClientApplicationServer(ctx, t)
+	defer teardown()
+
+	devices := getDevices(ctx, t, s, &pb.GetDevicesRequest{
+		UseEndpoints: []string{dev.UDPAddress()},
+		Timeout:      (time.Second).Nanoseconds(),
+	})
+	d := findDevice(devices, dev.ID().String())
+	require.NotNil(t, d)
+	require.Equal(t, grpcgwPb.Device_UNOWNED, d.GetOwnershipStatus())
+	var deviceContent device.Device
+	err := cbor.Decode(d.GetData().GetContent().GetData(), &deviceContent)
+	require.NoError(t, err)
+	require.Equal(t, cfg.Name, deviceContent.Name)
+
+	_, err = s.OwnDevice(ctx, &pb.OwnDeviceRequest{
+		DeviceId: d.GetId(),
+	})
+	require.NoError(t, err)
+	require.True(t, dev.IsOwned())
+	require.Equal(t, test.PSK_OWNER, dev.OwnerID())
+
+	href := cfg.Resources[0].Href
+	_, err = s.UpdateResource(ctx, &pb.UpdateResourceRequest{
+		ResourceId: commands.NewResourceID(d.GetId(), href),
+		Content: &grpcgwPb.Content{
+			ContentType: serviceHttp.ApplicationJsonContentType,
+			Data:        []byte(`{"power":42}`),
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId: commands.NewResourceID(d.GetId(), href),
+	})
+	require.NoError(t, err)
+	var value map[string]interface{}
+	err = cbor.Decode(res.GetData().GetContent().GetData(), &value)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), value["power"])
+	require.Equal(t, "Light", value["name"])
+
+	_, err = s.DisownDevice(ctx, &pb.DisownDeviceRequest{
+		DeviceId: d.GetId(),
+	})
+	require.NoError(t, err)
+	require.False(t, dev.IsOwned())
+}
+
+func TestMulticastDiscovery(t *testing.T) {
+	cfgs := []simulator.Config{
+		test.MakeSimulatorConfig("sim-" + t.Name() + "-0"),
+		test.MakeSimulatorConfig("sim-" + t.Name() + "-1"),
+	}
+	sim, tearDown := test.NewSimulator(t, cfgs...)
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	s, teardown := newClientApplicationServer(ctx, t)
+	defer teardown()
+
+	devices := getDevices(ctx, t, s, &pb.GetDevicesRequest{
+		UseMulticast: []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV4},
+		Timeout:      (time.Second).Nanoseconds(),
+	})
+	for _, dev := range sim.Devices() {
+		require.NotNil(t, findDevice(devices, dev.ID().String()), "device %v not found", dev.Name())
+	}
+}
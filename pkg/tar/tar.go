@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func makeTarFileFunc(src string, tw *tar.Writer) func(file string, fi os.FileInfo, err error) error {
@@ -63,15 +64,8 @@ func makeTarFileFunc(src string, tw *tar.Writer) func(file string, fi os.FileInf
 	}
 }
 
-// Tar takes a source and variable writers and walks 'source' writing each file
-// found to the tar writer; the purpose for accepting multiple writers is to allow
-// for multiple outputs (for example a file, or md5 hash)
-func Tar(src string, writers ...io.Writer) (err error) {
-	// ensure the src actually exists before trying to tar it
-	if _, err = os.Stat(src); err != nil {
-		return fmt.Errorf("unable to tar files: %w", err)
-	}
-
+// writeTar creates gzip and tar writers over writers and calls fn to write the entries.
+func writeTar(fn func(tw *tar.Writer) error, writers ...io.Writer) (err error) {
 	mw := io.MultiWriter(writers...)
 
 	gzw := gzip.NewWriter(mw)
@@ -90,11 +84,54 @@ func Tar(src string, writers ...io.Writer) (err error) {
 		}
 	}()
 
-	// walk path
-	err = filepath.Walk(src, makeTarFileFunc(src, tw))
+	err = fn(tw)
 	return
 }
 
+// Tar takes a source and variable writers and walks 'source' writing each file
+// found to the tar writer; the purpose for accepting multiple writers is to allow
+// for multiple outputs (for example a file, or md5 hash)
+func Tar(src string, writers ...io.Writer) error {
+	// ensure the src actually exists before trying to tar it
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("unable to tar files: %w", err)
+	}
+	return writeTar(func(tw *tar.Writer) error {
+		// walk path
+		return filepath.Walk(src, makeTarFileFunc(src, tw))
+	}, writers...)
+}
+
+// File is an in-memory file of the archive.
+type File struct {
+	// Name is the path of the file in the archive.
+	Name string
+	Data []byte
+}
+
+// TarFiles writes the in-memory files to the gzipped tar archive.
+func TarFiles(files []File, writers ...io.Writer) error {
+	now := time.Now()
+	return writeTar(func(tw *tar.Writer) error {
+		for _, f := range files {
+			header := &tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     f.Name,
+				Mode:     0o600,
+				Size:     int64(len(f.Data)),
+				ModTime:  now,
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("cannot write header of %v: %w", f.Name, err)
+			}
+			if _, err := tw.Write(f.Data); err != nil {
+				return fmt.Errorf("cannot write %v: %w", f.Name, err)
+			}
+		}
+		return nil
+	}, writers...)
+}
+
 func copyFile(target string, perm fs.FileMode, tr io.Reader) error {
 	f, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR, perm) //nolint:gosec
 	if err != nil {
@@ -35,3 +35,21 @@ func TestTar(t *testing.T) {
 	err = tar.Untar(os.TempDir()+string(os.PathSeparator)+filepath.Base(ex), data)
 	require.NoError(t, err)
 }
+
+func TestTarFiles(t *testing.T) {
+	data := bytes.NewBuffer(make([]byte, 0, 512))
+	err := tar.TarFiles([]tar.File{
+		{Name: "a.txt", Data: []byte("a")},
+		{Name: "b.json", Data: []byte("{}")},
+	}, data)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	err = tar.Untar(dir, data)
+	require.NoError(t, err)
+	got, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), got)
+	got, err = os.ReadFile(filepath.Join(dir, "b.json"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("{}"), got)
+}
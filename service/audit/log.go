@@ -0,0 +1,223 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/client-application/service/config/audit"
+)
+
+// Record describes a state-changing operation.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Method is the name of the RPC, e.g. OwnDevice.
+	Method string `json:"method"`
+	// Owner is the owner claim of the JWT token or its subject.
+	Owner string `json:"owner,omitempty"`
+	// RemoteAddress is the address of the peer, for the HTTP API it is the address of the grpc-gateway.
+	RemoteAddress string `json:"remoteAddress,omitempty"`
+	// ForwardedFor is the address of the HTTP client appended by the grpc-gateway, it is not set for the gRPC API.
+	ForwardedFor string `json:"forwardedFor,omitempty"`
+	DeviceID     string `json:"deviceId,omitempty"`
+	Href         string `json:"href,omitempty"`
+	// Outcome is the gRPC status code of the operation, e.g. OK.
+	Outcome  string        `json:"outcome"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Filter selects records, the empty fields match all records.
+type Filter struct {
+	DeviceIDs []string
+	Methods   []string
+	Since     time.Time
+	Until     time.Time
+	// Limit returns only the last records.
+	Limit int
+}
+
+func (f Filter) match(r Record) bool {
+	if len(f.DeviceIDs) > 0 && !slices.Contains(f.DeviceIDs, r.DeviceID) {
+		return false
+	}
+	if len(f.Methods) > 0 && !slices.Contains(f.Methods, r.Method) {
+		return false
+	}
+	if !f.Since.IsZero() && r.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Log appends the records to the file which is rotated when it exceeds the maximal size.
+type Log struct {
+	config audit.Config
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+// New opens the audit log. It returns nil when the file path is not set.
+func New(cfg audit.Config) (*Log, error) {
+	if cfg.FilePath == "" {
+		return nil, nil
+	}
+	l := &Log{
+		config: cfg,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Log) open() error {
+	f, err := os.OpenFile(l.config.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open audit log %v: %w", l.config.FilePath, err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot open audit log %v: %w", l.config.FilePath, err)
+	}
+	l.file = f
+	l.size = fi.Size()
+	return nil
+}
+
+func (l *Log) backupPath(i int) string {
+	return fmt.Sprintf("%v.%v", l.config.FilePath, i)
+}
+
+// rotateLocked shifts the backups, the current file becomes the first backup and the oldest one is removed.
+func (l *Log) rotateLocked() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("cannot close audit log %v: %w", l.config.FilePath, err)
+	}
+	if l.config.MaxBackups == 0 {
+		if err := os.Remove(l.config.FilePath); err != nil {
+			return fmt.Errorf("cannot remove audit log %v: %w", l.config.FilePath, err)
+		}
+		return l.open()
+	}
+	if err := os.Remove(l.backupPath(l.config.MaxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot remove audit log %v: %w", l.backupPath(l.config.MaxBackups), err)
+	}
+	for i := l.config.MaxBackups - 1; i > 0; i-- {
+		if err := os.Rename(l.backupPath(i), l.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot rotate audit log %v: %w", l.backupPath(i), err)
+		}
+	}
+	if err := os.Rename(l.config.FilePath, l.backupPath(1)); err != nil {
+		return fmt.Errorf("cannot rotate audit log %v: %w", l.config.FilePath, err)
+	}
+	return l.open()
+}
+
+// Write appends the record to the log.
+func (l *Log) Write(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("cannot encode audit record: %w", err)
+	}
+	data = append(data, '\n')
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.size > 0 && l.size+int64(len(data)) > l.config.MaxFileSize {
+		if err = l.rotateLocked(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("cannot write audit record to %v: %w", l.config.FilePath, err)
+	}
+	return nil
+}
+
+func readFile(path string, filter Filter, records []Record) ([]Record, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open audit log %v: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err = json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("invalid audit record at %v:%v: %w", path, line, err)
+		}
+		if filter.match(r) {
+			records = append(records, r)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read audit log %v: %w", path, err)
+	}
+	return records, nil
+}
+
+// Get returns the records which match the filter from the oldest one.
+func (l *Log) Get(filter Filter) ([]Record, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var records []Record
+	var err error
+	for i := l.config.MaxBackups; i > 0; i-- {
+		if records, err = readFile(l.backupPath(i), filter, records); err != nil {
+			return nil, err
+		}
+	}
+	if records, err = readFile(l.config.FilePath, filter, records); err != nil {
+		return nil, err
+	}
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, nil
+}
+
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,107 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/service/audit"
+	configAudit "github.com/plgd-dev/client-application/service/config/audit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogDisabled(t *testing.T) {
+	l, err := audit.New(configAudit.Config{})
+	require.NoError(t, err)
+	require.Nil(t, l)
+}
+
+func TestLog(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := configAudit.Config{
+		FilePath:    filePath,
+		MaxFileSize: 256,
+		MaxBackups:  2,
+	}
+	require.NoError(t, cfg.Validate())
+	l, err := audit.New(cfg)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		err = l.Write(audit.Record{
+			Time:     start.Add(time.Duration(i) * time.Second),
+			Method:   "OwnDevice",
+			DeviceID: strconv.Itoa(i % 2),
+			Outcome:  "OK",
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.Write(audit.Record{Time: start.Add(time.Minute), Method: "Reset", Outcome: "OK"}))
+
+	// the file is rotated and the oldest records are removed
+	_, err = os.Stat(filePath + ".1")
+	require.NoError(t, err)
+	_, err = os.Stat(filePath + ".3")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	all, err := l.Get(audit.Filter{})
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+	require.Less(t, len(all), 11)
+	require.Equal(t, "Reset", all[len(all)-1].Method)
+	for i := 1; i < len(all); i++ {
+		require.False(t, all[i].Time.Before(all[i-1].Time))
+	}
+
+	got, err := l.Get(audit.Filter{Methods: []string{"Reset"}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	got, err = l.Get(audit.Filter{DeviceIDs: []string{"1"}, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "1", got[0].DeviceID)
+	require.Equal(t, start.Add(9*time.Second).Unix(), got[1].Time.Unix())
+	got, err = l.Get(audit.Filter{Since: start.Add(30 * time.Second)})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	got, err = l.Get(audit.Filter{Until: start.Add(8 * time.Second).Add(time.Millisecond)})
+	require.NoError(t, err)
+	require.Len(t, got, len(all)-2)
+	require.NoError(t, l.Close())
+
+	// records are appended after reopening
+	l, err = audit.New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, l.Write(audit.Record{Time: start.Add(2 * time.Minute), Method: "ClearCache", Outcome: "OK"}))
+	got, err = l.Get(audit.Filter{Methods: []string{"Reset", "ClearCache"}})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.NoError(t, l.Close())
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := configAudit.Config{FilePath: t.TempDir(), MaxFileSize: 1}
+	require.Error(t, cfg.Validate())
+	cfg = configAudit.Config{FilePath: "audit.jsonl"}
+	require.Error(t, cfg.Validate())
+	cfg = configAudit.Config{FilePath: "audit.jsonl", MaxFileSize: 1, MaxBackups: -1}
+	require.Error(t, cfg.Validate())
+}
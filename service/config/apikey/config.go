@@ -0,0 +1,96 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Hash returns the hex encoded SHA-256 hash of the API key which is stored in the configuration.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Key is a named API key, only the hash of the key is stored.
+type Key struct {
+	Name string `yaml:"name" json:"name"`
+	// Hash is the hex encoded SHA-256 hash of the key, see Hash. It is converted to lower case by Validate.
+	Hash string `yaml:"hash" json:"hash"`
+	// Scopes are checked by the authorization rules, same as the scopes of a JWT token.
+	Scopes []string `yaml:"scopes" json:"scopes"`
+}
+
+func (k *Key) Validate() error {
+	if k.Name == "" {
+		return errors.New("name('') - is empty")
+	}
+	if h, err := hex.DecodeString(k.Hash); err != nil || len(h) != sha256.Size {
+		return fmt.Errorf("hash('%v') - must be hex encoded SHA-256 hash", k.Hash)
+	}
+	// Hash returns the lower case hex, so the hashes are compared in the same case
+	k.Hash = strings.ToLower(k.Hash)
+	return nil
+}
+
+type Config struct {
+	// Keys authenticate the requests when the JWT authorization is not enabled. When it is empty, the requests are not authenticated.
+	Keys []Key `yaml:"keys" json:"keys"`
+}
+
+func (c *Config) Validate() error {
+	names := make(map[string]struct{}, len(c.Keys))
+	for i := range c.Keys {
+		if err := c.Keys[i].Validate(); err != nil {
+			return fmt.Errorf("keys[%v].%w", i, err)
+		}
+		if _, ok := names[c.Keys[i].Name]; ok {
+			return fmt.Errorf("keys[%v].name('%v') - is duplicated", i, c.Keys[i].Name)
+		}
+		names[c.Keys[i].Name] = struct{}{}
+	}
+	return nil
+}
+
+func (c *Config) IsEnabled() bool {
+	return len(c.Keys) > 0
+}
+
+// Find returns the key with the hash of the API key.
+func (c *Config) Find(key string) (Key, bool) {
+	if key == "" {
+		return Key{}, false
+	}
+	h := []byte(Hash(key))
+	for _, k := range c.Keys {
+		if subtle.ConstantTimeCompare(h, []byte(k.Hash)) == 1 {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Keys: []Key{},
+	}
+}
@@ -0,0 +1,69 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package apikey_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	hash := apikey.Hash("secret")
+	tests := []struct {
+		name    string
+		cfg     apikey.Config
+		wantErr bool
+	}{
+		{name: "disabled", cfg: apikey.Config{}},
+		{name: "valid", cfg: apikey.Config{Keys: []apikey.Key{{Name: "a", Hash: hash, Scopes: []string{"admin"}}, {Name: "b", Hash: apikey.Hash("b")}}}},
+		{name: "empty name", cfg: apikey.Config{Keys: []apikey.Key{{Hash: hash}}}, wantErr: true},
+		{name: "invalid hash", cfg: apikey.Config{Keys: []apikey.Key{{Name: "a", Hash: "secret"}}}, wantErr: true},
+		{name: "duplicated name", cfg: apikey.Config{Keys: []apikey.Key{{Name: "a", Hash: hash}, {Name: "a", Hash: apikey.Hash("b")}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigFind(t *testing.T) {
+	cfg := apikey.Config{Keys: []apikey.Key{{Name: "a", Hash: apikey.Hash("secret"), Scopes: []string{"admin"}}}}
+	require.True(t, cfg.IsEnabled())
+	key, ok := cfg.Find("secret")
+	require.True(t, ok)
+	require.Equal(t, "a", key.Name)
+	_, ok = cfg.Find("invalid")
+	require.False(t, ok)
+	_, ok = cfg.Find("")
+	require.False(t, ok)
+
+	// the upper case hash is accepted
+	cfg = apikey.Config{Keys: []apikey.Key{{Name: "a", Hash: strings.ToUpper(apikey.Hash("secret"))}}}
+	require.NoError(t, cfg.Validate())
+	key, ok = cfg.Find("secret")
+	require.True(t, ok)
+	require.Equal(t, "a", key.Name)
+}
@@ -0,0 +1,56 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+type Config struct {
+	// FilePath is the path to the JSON-lines file with the audit records. When it is empty, the audit log is disabled.
+	FilePath string `yaml:"filePath" json:"filePath"`
+	// MaxFileSize is the size in bytes after which the file is rotated.
+	MaxFileSize int64 `yaml:"maxFileSize" json:"maxFileSize"`
+	// MaxBackups is the number of kept rotated files, the oldest ones are removed.
+	MaxBackups int `yaml:"maxBackups" json:"maxBackups"`
+}
+
+func (c *Config) Validate() error {
+	if c.FilePath == "" {
+		return nil
+	}
+	if fi, err := os.Stat(c.FilePath); err == nil && fi.IsDir() {
+		return fmt.Errorf("filePath('%v') - is a directory", c.FilePath)
+	}
+	if c.MaxFileSize <= 0 {
+		return fmt.Errorf("maxFileSize('%v') - must be greater than 0", c.MaxFileSize)
+	}
+	if c.MaxBackups < 0 {
+		return fmt.Errorf("maxBackups('%v') - must not be negative", c.MaxBackups)
+	}
+	return nil
+}
+
+func DefaultConfig(directory string) Config {
+	return Config{
+		FilePath:    path.Join(directory, "audit.jsonl"),
+		MaxFileSize: 10 * 1024 * 1024,
+		MaxBackups:  5,
+	}
+}
@@ -0,0 +1,170 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/plgd-dev/client-application/pb"
+)
+
+// AllMethods in the rule allows all methods.
+const AllMethods = "*"
+
+// PublicMethods are not checked, because they are used before the token can be verified.
+var PublicMethods = []string{
+	"GetConfiguration",
+	"GetJSONWebKeys",
+}
+
+// BootstrapMethods initialize the JWT authorization, so they are not checked when it is used. With the API keys, they are checked as the other methods.
+var BootstrapMethods = []string{
+	"Initialize",
+	"FinishInitialize",
+}
+
+// AdminMethods are allowed only by the admin scope of the default rules, because they manage the client application itself
+// or expose the data of all users, e.g. the audit log.
+var AdminMethods = []string{
+	"ClearCache",
+	"GetConnections",
+	"CloseConnections",
+	"Initialize",
+	"FinishInitialize",
+	"Reset",
+	"GetDiagnostics",
+	"GetAuditLog",
+	"GetAdditionalOwners",
+	"AddAdditionalOwner",
+	"RemoveAdditionalOwner",
+}
+
+// Rule allows the methods to the tokens with the scope.
+type Rule struct {
+	Scope string `yaml:"scope" json:"scope"`
+	// Methods are names of the RPCs, e.g. GetDevices, or "*" for all methods.
+	Methods []string `yaml:"methods" json:"methods"`
+}
+
+func isMethod(name string) bool {
+	for _, m := range pb.ClientApplication_ServiceDesc.Methods {
+		if m.MethodName == name {
+			return true
+		}
+	}
+	for _, s := range pb.ClientApplication_ServiceDesc.Streams {
+		if s.StreamName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) Validate() error {
+	if r.Scope == "" {
+		return errors.New("scope('') - is empty")
+	}
+	if len(r.Methods) == 0 {
+		return errors.New("methods('[]') - is empty")
+	}
+	for i, m := range r.Methods {
+		if m != AllMethods && !isMethod(m) {
+			return fmt.Errorf("methods[%v]('%v') - unknown method", i, m)
+		}
+	}
+	return nil
+}
+
+// Allows returns true when the rule allows the method.
+func (r *Rule) Allows(method string) bool {
+	for _, m := range r.Methods {
+		if m == AllMethods || m == method {
+			return true
+		}
+	}
+	return false
+}
+
+type Config struct {
+	// Enabled enforces the rules for the authenticated requests. When it is disabled, all authenticated requests are allowed.
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Rules   []Rule `yaml:"rules" json:"rules"`
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Rules) == 0 {
+		return errors.New("rules('[]') - is empty")
+	}
+	for i := range c.Rules {
+		if err := c.Rules[i].Validate(); err != nil {
+			return fmt.Errorf("rules[%v].%w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetScopes returns the scopes which allow the method.
+func (c *Config) GetScopes(method string) []string {
+	scopes := make([]string, 0, 2)
+	for _, r := range c.Rules {
+		if r.Allows(method) {
+			scopes = append(scopes, r.Scope)
+		}
+	}
+	return scopes
+}
+
+// IsPublicMethod returns true when the method is not checked. The full method name is accepted as well.
+func IsPublicMethod(method string) bool {
+	return slices.Contains(PublicMethods, path.Base(method))
+}
+
+// IsBootstrapMethod returns true when the method initializes the JWT authorization. The full method name is accepted as well.
+func IsBootstrapMethod(method string) bool {
+	return slices.Contains(BootstrapMethods, path.Base(method))
+}
+
+// DefaultConfig returns the disabled authorization with the default rules. The AdminMethods are not listed by the rules of the other scopes.
+func DefaultConfig() Config {
+	return Config{
+		Enabled: false,
+		Rules: []Rule{
+			{
+				Scope:   "devices:read",
+				Methods: []string{"GetDevices", "GetDevice", "GetDeviceResourceLinks", "GetResource", "GetDeviceMetadata", "ExportInventory", "GetIdentityCertificate"},
+			},
+			{
+				Scope:   "resources:write",
+				Methods: []string{"UpdateResource", "CreateResource", "DeleteResource", "SetDeviceMetadata", "ImportInventory", "ApplyManifest"},
+			},
+			{
+				Scope:   "ownership:manage",
+				Methods: []string{"OwnDevice", "FinishOwnDevice", "DisownDevice", "OnboardDevice", "OffboardDevice"},
+			},
+			{
+				Scope:   "admin",
+				Methods: []string{AllMethods},
+			},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package authorization_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/config/authorization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     authorization.Config
+		wantErr bool
+	}{
+		{name: "disabled", cfg: authorization.Config{}},
+		{name: "default", cfg: authorization.DefaultConfig()},
+		{name: "no rules", cfg: authorization.Config{Enabled: true}, wantErr: true},
+		{name: "empty scope", cfg: authorization.Config{Enabled: true, Rules: []authorization.Rule{{Methods: []string{"GetDevices"}}}}, wantErr: true},
+		{name: "no methods", cfg: authorization.Config{Enabled: true, Rules: []authorization.Rule{{Scope: "a"}}}, wantErr: true},
+		{name: "unknown method", cfg: authorization.Config{Enabled: true, Rules: []authorization.Rule{{Scope: "a", Methods: []string{"Unknown"}}}}, wantErr: true},
+		{name: "valid", cfg: authorization.Config{Enabled: true, Rules: []authorization.Rule{{Scope: "a", Methods: []string{"GetDevices", authorization.AllMethods}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigGetScopes(t *testing.T) {
+	cfg := authorization.DefaultConfig()
+	require.Equal(t, []string{"devices:read", "admin"}, cfg.GetScopes("GetDevices"))
+	require.Equal(t, []string{"admin"}, cfg.GetScopes("Reset"))
+	require.True(t, authorization.IsPublicMethod("/service.pb.ClientApplication/GetConfiguration"))
+	require.False(t, authorization.IsPublicMethod("Reset"))
+	require.False(t, authorization.IsPublicMethod("Initialize"))
+	require.True(t, authorization.IsBootstrapMethod("/service.pb.ClientApplication/FinishInitialize"))
+}
+
+func TestDefaultConfigCoversAllMethods(t *testing.T) {
+	cfg := authorization.DefaultConfig()
+	methods := make([]string, 0, len(pb.ClientApplication_ServiceDesc.Methods)+len(pb.ClientApplication_ServiceDesc.Streams))
+	for _, m := range pb.ClientApplication_ServiceDesc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range pb.ClientApplication_ServiceDesc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	for _, m := range methods {
+		if authorization.IsPublicMethod(m) {
+			continue
+		}
+		scopes := cfg.GetScopes(m)
+		if slices.Contains(authorization.AdminMethods, m) {
+			require.Equal(t, []string{"admin"}, scopes, m)
+			continue
+		}
+		require.Greater(t, len(scopes), 1, "method %v is not listed by the rules nor by the admin methods", m)
+	}
+}
@@ -20,11 +20,18 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	"github.com/plgd-dev/client-application/service/config/audit"
+	"github.com/plgd-dev/client-application/service/config/authorization"
 	"github.com/plgd-dev/client-application/service/config/device"
 	"github.com/plgd-dev/client-application/service/config/grpc"
 	"github.com/plgd-dev/client-application/service/config/http"
+	"github.com/plgd-dev/client-application/service/config/metadata"
+	"github.com/plgd-dev/client-application/service/config/owners"
 	"github.com/plgd-dev/client-application/service/config/remoteProvisioning"
+	"github.com/plgd-dev/client-application/service/config/simulator"
 	"github.com/plgd-dev/hub/v2/pkg/config"
+	"github.com/plgd-dev/hub/v2/pkg/config/property/urischeme"
 	"github.com/plgd-dev/hub/v2/pkg/log"
 )
 
@@ -34,6 +41,11 @@ type Config struct {
 	APIs               APIsConfig                 `yaml:"apis" json:"apis"`
 	Clients            ClientsConfig              `yaml:"clients" json:"clients"`
 	RemoteProvisioning *remoteProvisioning.Config `yaml:"remoteProvisioning" json:"remoteProvisioning"`
+	Metadata           metadata.Config            `yaml:"metadata" json:"metadata"`
+	Simulator          simulator.Config           `yaml:"simulator" json:"simulator"`
+	Audit              audit.Config               `yaml:"audit" json:"audit"`
+	Authorization      authorization.Config       `yaml:"authorization" json:"authorization"`
+	AdditionalOwners   owners.Config              `yaml:"additionalOwners" json:"additionalOwners"`
 	configPath         string                     `yaml:"-" json:"-"`
 }
 
@@ -66,6 +78,21 @@ func (c *Config) Validate() error {
 	if err := c.RemoteProvisioning.Validate(); err != nil {
 		return fmt.Errorf("remoteProvisioning.%w", err)
 	}
+	if err := c.Metadata.Validate(); err != nil {
+		return fmt.Errorf("metadata.%w", err)
+	}
+	if err := c.Simulator.Validate(); err != nil {
+		return fmt.Errorf("simulator.%w", err)
+	}
+	if err := c.Audit.Validate(); err != nil {
+		return fmt.Errorf("audit.%w", err)
+	}
+	if err := c.Authorization.Validate(); err != nil {
+		return fmt.Errorf("authorization.%w", err)
+	}
+	if err := c.AdditionalOwners.Validate(); err != nil {
+		return fmt.Errorf("additionalOwners.%w", err)
+	}
 	return nil
 }
 
@@ -82,6 +109,8 @@ type GRPCConfig struct {
 type APIsConfig struct {
 	HTTP HTTPConfig `yaml:"http" json:"http"`
 	GRPC GRPCConfig `yaml:"grpc" json:"grpc"`
+	// APIKeys authenticate the requests of the HTTP and gRPC APIs in the pre-shared key mode.
+	APIKeys apikey.Config `yaml:"apiKeys" json:"apiKeys"`
 }
 
 func (c *APIsConfig) Validate() error {
@@ -98,6 +127,9 @@ func (c *APIsConfig) Validate() error {
 			return fmt.Errorf("grpc.%w", err)
 		}
 	}
+	if err := c.APIKeys.Validate(); err != nil {
+		return fmt.Errorf("apiKeys.%w", err)
+	}
 	return nil
 }
 
@@ -117,6 +149,44 @@ func (c Config) String() string {
 	return config.ToString(c)
 }
 
+// redactedValue replaces the secrets in the redacted configuration.
+const redactedValue = "<redacted>"
+
+// redactURI replaces the private key embedded in the data URI, the file path is kept.
+func redactURI(uri urischeme.URIScheme) urischeme.URIScheme {
+	if uri.IsData() {
+		return redactedValue
+	}
+	return uri
+}
+
+// Redacted returns a copy of the configuration without secrets: the pre-shared key, the hashes of the API keys,
+// the values of the additional owners and the private keys embedded in the configuration.
+func (c Config) Redacted() Config {
+	if c.Clients.Device.COAP.TLS.PreSharedKey.Key != "" {
+		c.Clients.Device.COAP.TLS.PreSharedKey.Key = redactedValue
+	}
+	c.APIs.HTTP.TLS.KeyFile = redactURI(c.APIs.HTTP.TLS.KeyFile)
+	c.APIs.GRPC.TLS.KeyFile = redactURI(c.APIs.GRPC.TLS.KeyFile)
+	if len(c.APIs.APIKeys.Keys) > 0 {
+		keys := make([]apikey.Key, 0, len(c.APIs.APIKeys.Keys))
+		for _, k := range c.APIs.APIKeys.Keys {
+			k.Hash = redactedValue
+			keys = append(keys, k)
+		}
+		c.APIs.APIKeys.Keys = keys
+	}
+	if len(c.AdditionalOwners.Subjects) > 0 {
+		subjects := make([]owners.Subject, 0, len(c.AdditionalOwners.Subjects))
+		for _, s := range c.AdditionalOwners.Subjects {
+			s.Value = redactedValue
+			subjects = append(subjects, s)
+		}
+		c.AdditionalOwners.Subjects = subjects
+	}
+	return c
+}
+
 func (c Config) Store() error {
 	return Store(c, c.configPath)
 }
@@ -135,10 +205,15 @@ func DefaultConfig(directory string) Config {
 				Enabled: true,
 				Config:  grpc.DefaultConfig(directory),
 			},
+			APIKeys: apikey.DefaultConfig(),
 		},
 		Clients: ClientsConfig{
 			Device: device.DefaultConfig(),
 		},
 		RemoteProvisioning: remoteProvisioning.DefaultConfig(),
+		Metadata:           metadata.DefaultConfig(directory),
+		Audit:              audit.DefaultConfig(directory),
+		Authorization:      authorization.DefaultConfig(),
+		AdditionalOwners:   owners.DefaultConfig(),
 	}
 }
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	"github.com/plgd-dev/client-application/service/config/owners"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,3 +70,29 @@ func TestNew(t *testing.T) {
 	require.Empty(t, cfg2.Clients.Device.COAP.TLS.PreSharedKey.Key)
 	require.Empty(t, cfg2.Clients.Device.COAP.TLS.PreSharedKey.SubjectIDStr)
 }
+
+func TestRedacted(t *testing.T) {
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.Clients.Device.COAP.TLS.PreSharedKey.Key = "secret"
+	redacted := cfg.Redacted()
+	require.Equal(t, "secret", cfg.Clients.Device.COAP.TLS.PreSharedKey.Key)
+	require.NotContains(t, redacted.String(), "secret")
+	require.Equal(t, cfg.APIs, redacted.APIs)
+
+	cfg.Clients.Device.COAP.TLS.PreSharedKey.Key = ""
+	require.Empty(t, cfg.Redacted().Clients.Device.COAP.TLS.PreSharedKey.Key)
+
+	cfg.APIs.APIKeys.Keys = []apikey.Key{{Name: "ci", Hash: apikey.Hash("secret-api-key"), Scopes: []string{"admin"}}}
+	cfg.AdditionalOwners.Subjects = []owners.Subject{{Claim: "groups", Value: "secret-group"}}
+	cfg.APIs.HTTP.TLS.KeyFile = "data:;base64,c2VjcmV0LWtleQ=="
+	redacted = cfg.Redacted()
+	for _, secret := range []string{cfg.APIs.APIKeys.Keys[0].Hash, "secret-group", string(cfg.APIs.HTTP.TLS.KeyFile)} {
+		require.NotContains(t, redacted.String(), secret)
+	}
+	// the names are kept and the original configuration is not modified
+	require.Equal(t, "ci", redacted.APIs.APIKeys.Keys[0].Name)
+	require.Equal(t, "groups", redacted.AdditionalOwners.Subjects[0].Claim)
+	require.Equal(t, apikey.Hash("secret-api-key"), cfg.APIs.APIKeys.Keys[0].Hash)
+	require.Equal(t, "secret-group", cfg.AdditionalOwners.Subjects[0].Value)
+	require.Equal(t, cfg.APIs.GRPC.TLS.KeyFile, redacted.APIs.GRPC.TLS.KeyFile)
+}
@@ -20,10 +20,17 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pion/dtls/v2"
+	dtlsElliptic "github.com/pion/dtls/v2/pkg/crypto/elliptic"
+	"github.com/plgd-dev/client-application/pkg/dnssd"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
 	"github.com/plgd-dev/go-coap/v3/net/blockwise"
 	"github.com/plgd-dev/hub/v2/identity-store/events"
 	"github.com/plgd-dev/hub/v2/pkg/config/property/urischeme"
@@ -33,13 +40,132 @@ import (
 )
 
 type Config struct {
-	COAP CoapConfig `yaml:"coap" json:"coap"`
+	COAP      CoapConfig      `yaml:"coap" json:"coap"`
+	Discovery DiscoveryConfig `yaml:"discovery" json:"discovery"`
 }
 
 func (c *Config) Validate() error {
 	if err := c.COAP.Validate(); err != nil {
 		return fmt.Errorf("coap.%w", err)
 	}
+	if err := c.Discovery.Validate(); err != nil {
+		return fmt.Errorf("discovery.%w", err)
+	}
+	return nil
+}
+
+type MulticastScope string
+
+const (
+	// MulticastScopeIPv4 is 224.0.1.187
+	MulticastScopeIPv4 MulticastScope = "ipv4"
+	// MulticastScopeIPv6LinkLocal is ff02::158
+	MulticastScopeIPv6LinkLocal MulticastScope = "ipv6LinkLocal"
+	// MulticastScopeIPv6RealmLocal is ff03::158
+	MulticastScopeIPv6RealmLocal MulticastScope = "ipv6RealmLocal"
+	// MulticastScopeIPv6SiteLocal is ff05::158
+	MulticastScopeIPv6SiteLocal MulticastScope = "ipv6SiteLocal"
+)
+
+var validMulticastScopes = map[MulticastScope]bool{
+	MulticastScopeIPv4:           true,
+	MulticastScopeIPv6LinkLocal:  true,
+	MulticastScopeIPv6RealmLocal: true,
+	MulticastScopeIPv6SiteLocal:  true,
+}
+
+type DiscoveryConfig struct {
+	// MulticastInterfaces are the names of the network interfaces used for the multicast discovery, when it is empty all interfaces are used.
+	MulticastInterfaces []string `yaml:"multicastInterfaces" json:"multicastInterfaces"`
+	// MulticastScopes are the multicast addresses used for the discovery, when it is empty all scopes are used.
+	MulticastScopes []MulticastScope `yaml:"multicastScopes" json:"multicastScopes"`
+	// SubnetSweep configures the unicast discovery of the hosts of the subnets.
+	SubnetSweep SubnetSweepConfig `yaml:"subnetSweep" json:"subnetSweep"`
+	// DNSSD configures the discovery of the devices announced by DNS-SD over multicast DNS.
+	DNSSD DNSSDConfig `yaml:"dnsSD" json:"dnsSD"`
+}
+
+func (c *DiscoveryConfig) Validate() error {
+	for idx, iface := range c.MulticastInterfaces {
+		if iface == "" {
+			return fmt.Errorf("multicastInterfaces[%v]('%v') - is empty", idx, iface)
+		}
+	}
+	for idx, scope := range c.MulticastScopes {
+		if !validMulticastScopes[scope] {
+			return fmt.Errorf("multicastScopes[%v]('%v') - supports only '%v,%v,%v,%v'", idx, scope, MulticastScopeIPv4, MulticastScopeIPv6LinkLocal, MulticastScopeIPv6RealmLocal, MulticastScopeIPv6SiteLocal)
+		}
+	}
+	if err := c.SubnetSweep.Validate(); err != nil {
+		return fmt.Errorf("subnetSweep.%w", err)
+	}
+	if err := c.DNSSD.Validate(); err != nil {
+		return fmt.Errorf("dnsSD.%w", err)
+	}
+	return nil
+}
+
+type DNSSDConfig struct {
+	// Services are the browsed DNS-SD service types, e.g. "_ocf._udp", when it is empty "_ocf._udp" and "_coap._udp" are used.
+	// The services with the protocol "_tcp" are probed by CoAP over TCP.
+	Services []string `yaml:"services" json:"services"`
+	// Addresses are the multicast DNS addresses to which the queries are sent, when it is empty the IPv4 and IPv6 addresses are used.
+	Addresses []string `yaml:"addresses" json:"addresses"`
+}
+
+func (c *DNSSDConfig) Validate() error {
+	if len(c.Services) == 0 {
+		c.Services = []string{"_ocf._udp", "_coap._udp"}
+	}
+	for idx, service := range c.Services {
+		if !strings.HasSuffix(service, "._udp") && !strings.HasSuffix(service, "._tcp") {
+			return fmt.Errorf("services[%v]('%v') - must be in format _<service>._udp or _<service>._tcp", idx, service)
+		}
+	}
+	if len(c.Addresses) == 0 {
+		c.Addresses = []string{dnssd.MulticastAddressUDP4, dnssd.MulticastAddressUDP6}
+	}
+	for idx, address := range c.Addresses {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("addresses[%v]('%v') - %w", idx, address, err)
+		}
+	}
+	return nil
+}
+
+type SubnetSweepConfig struct {
+	// MaxConcurrency is the max number of the hosts which are probed at the same time, 0 means 64.
+	MaxConcurrency int `yaml:"maxConcurrency" json:"maxConcurrency"`
+	// RateLimit is the max number of the probes sent per second, 0 means unlimited.
+	RateLimit float64 `yaml:"rateLimit" json:"rateLimit"`
+	// HostTimeout is the time limit of the probe of one host, 0 means 1s.
+	HostTimeout time.Duration `yaml:"hostTimeout" json:"hostTimeout"`
+	// MaxHosts is the max number of the hosts of all subnets of one request, 0 means 65536.
+	MaxHosts int `yaml:"maxHosts" json:"maxHosts"`
+}
+
+func (c *SubnetSweepConfig) Validate() error {
+	if c.MaxConcurrency < 0 {
+		return fmt.Errorf("maxConcurrency('%v') - must be greater than or equal to 0", c.MaxConcurrency)
+	}
+	if c.MaxConcurrency == 0 {
+		c.MaxConcurrency = 64
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rateLimit('%v') - must be greater than or equal to 0", c.RateLimit)
+	}
+	if c.HostTimeout < 0 {
+		return fmt.Errorf("hostTimeout('%v') - must be greater than or equal to 0", c.HostTimeout)
+	}
+	if c.HostTimeout == 0 {
+		c.HostTimeout = time.Second
+	}
+	if c.MaxHosts < 0 {
+		return fmt.Errorf("maxHosts('%v') - must be greater than or equal to 0", c.MaxHosts)
+	}
+	if c.MaxHosts == 0 {
+		c.MaxHosts = 65536
+	}
 	return nil
 }
 
@@ -141,11 +267,15 @@ func (c *OwnershipTransferConfig) Validate() error {
 }
 
 type CoapConfig struct {
-	MaxMessageSize    uint32                  `yaml:"maxMessageSize" json:"maxMessageSize"`
-	InactivityMonitor InactivityMonitor       `yaml:"inactivityMonitor" json:"inactivityMonitor"`
-	BlockwiseTransfer BlockwiseTransferConfig `yaml:"blockwiseTransfer" json:"blockwiseTransfer"`
-	OwnershipTransfer OwnershipTransferConfig `yaml:"ownershipTransfer" json:"ownershipTransfer"`
-	TLS               TLSConfig               `yaml:"tls" json:"tls"`
+	MaxMessageSize     uint32                   `yaml:"maxMessageSize" json:"maxMessageSize"`
+	InactivityMonitor  InactivityMonitor        `yaml:"inactivityMonitor" json:"inactivityMonitor"`
+	BlockwiseTransfer  BlockwiseTransferConfig  `yaml:"blockwiseTransfer" json:"blockwiseTransfer"`
+	OwnershipTransfer  OwnershipTransferConfig  `yaml:"ownershipTransfer" json:"ownershipTransfer"`
+	TLS                TLSConfig                `yaml:"tls" json:"tls"`
+	Capture            CaptureConfig            `yaml:"capture" json:"capture"`
+	Retry              RetryConfig              `yaml:"retry" json:"retry"`
+	EndpointSelection  EndpointSelectionConfig  `yaml:"endpointSelection" json:"endpointSelection"`
+	ResourceLinksCache ResourceLinksCacheConfig `yaml:"resourceLinksCache" json:"resourceLinksCache"`
 }
 
 func (c *CoapConfig) Validate() error {
@@ -164,6 +294,160 @@ func (c *CoapConfig) Validate() error {
 	if err := c.TLS.Validate(); err != nil {
 		return fmt.Errorf("tls.%w", err)
 	}
+	if err := c.Capture.Validate(); err != nil {
+		return fmt.Errorf("capture.%w", err)
+	}
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("retry.%w", err)
+	}
+	if err := c.EndpointSelection.Validate(); err != nil {
+		return fmt.Errorf("endpointSelection.%w", err)
+	}
+	if err := c.ResourceLinksCache.Validate(); err != nil {
+		return fmt.Errorf("resourceLinksCache.%w", err)
+	}
+	return nil
+}
+
+type CaptureConfig struct {
+	// FilePath is the path to the JSON-lines file where CoAP requests and responses are appended. When it is empty, the capture is disabled.
+	FilePath string `yaml:"filePath" json:"filePath"`
+}
+
+func (c *CaptureConfig) Validate() error {
+	if c.FilePath == "" {
+		return nil
+	}
+	if fi, err := os.Stat(c.FilePath); err == nil && fi.IsDir() {
+		return fmt.Errorf("filePath('%v') - is a directory", c.FilePath)
+	}
+	return nil
+}
+
+type RetryableError string
+
+const (
+	RetryableErrorTimeout           RetryableError = "timeout"
+	RetryableErrorConnectionRefused RetryableError = "connectionRefused"
+	RetryableErrorConnectionReset   RetryableError = "connectionReset"
+	RetryableErrorUnreachable       RetryableError = "unreachable"
+)
+
+var validRetryableErrors = map[RetryableError]bool{
+	RetryableErrorTimeout:           true,
+	RetryableErrorConnectionRefused: true,
+	RetryableErrorConnectionReset:   true,
+	RetryableErrorUnreachable:       true,
+}
+
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts including the first one, 0 or 1 disables the retries.
+	MaxAttempts int `yaml:"maxAttempts" json:"maxAttempts"`
+	// AttemptTimeout limits each attempt so that the timed out attempt can be retried, 0 means the attempt is limited only by the request and the timeouts cannot be retried.
+	AttemptTimeout time.Duration `yaml:"attemptTimeout" json:"attemptTimeout"`
+	// InitialBackoff is the delay before the second attempt, it is doubled for each next attempt up to MaxBackoff.
+	InitialBackoff time.Duration `yaml:"initialBackoff" json:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff" json:"maxBackoff"`
+	// Jitter randomizes the backoff by the fraction <0,1>.
+	Jitter float64 `yaml:"jitter" json:"jitter"`
+	// RetryableCoapCodes are the codes of the device responses which are retried, e.g. ServiceUnavailable.
+	RetryableCoapCodes []string `yaml:"retryableCoapCodes" json:"retryableCoapCodes"`
+	// RetryableErrors are the network errors which are retried.
+	RetryableErrors    []RetryableError `yaml:"retryableErrors" json:"retryableErrors"`
+	retryableCoapCodes map[codes.Code]bool
+}
+
+func (c *RetryConfig) IsEnabled() bool {
+	return c.MaxAttempts > 1
+}
+
+func (c *RetryConfig) IsRetryableCoapCode(code codes.Code) bool {
+	return c.retryableCoapCodes[code]
+}
+
+func (c *RetryConfig) IsRetryableError(err RetryableError) bool {
+	return slices.Contains(c.RetryableErrors, err)
+}
+
+func (c *RetryConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("maxAttempts('%v') - is negative", c.MaxAttempts)
+	}
+	if c.AttemptTimeout < 0 {
+		return fmt.Errorf("attemptTimeout('%v') - is negative", c.AttemptTimeout)
+	}
+	if c.IsEnabled() {
+		if c.InitialBackoff <= 0 {
+			return fmt.Errorf("initialBackoff('%v') - must be greater than 0", c.InitialBackoff)
+		}
+		if c.MaxBackoff < c.InitialBackoff {
+			return fmt.Errorf("maxBackoff('%v') - must be greater than or equal to initialBackoff('%v')", c.MaxBackoff, c.InitialBackoff)
+		}
+		if c.AttemptTimeout == 0 && c.IsRetryableError(RetryableErrorTimeout) {
+			return fmt.Errorf("attemptTimeout('%v') - must be greater than 0 to retry the %v", c.AttemptTimeout, RetryableErrorTimeout)
+		}
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("jitter('%v') - must be in range <0,1>", c.Jitter)
+	}
+	retryableCoapCodes := make(map[codes.Code]bool, len(c.RetryableCoapCodes))
+	for idx, code := range c.RetryableCoapCodes {
+		v, err := codes.ToCode(code)
+		if err != nil {
+			return fmt.Errorf("retryableCoapCodes[%v]('%v') - %w", idx, code, err)
+		}
+		retryableCoapCodes[v] = true
+	}
+	for idx, e := range c.RetryableErrors {
+		if !validRetryableErrors[e] {
+			return fmt.Errorf("retryableErrors[%v]('%v') - supports only '%v,%v,%v,%v'", idx, e, RetryableErrorTimeout, RetryableErrorConnectionRefused, RetryableErrorConnectionReset, RetryableErrorUnreachable)
+		}
+	}
+	c.retryableCoapCodes = retryableCoapCodes
+	return nil
+}
+
+type EndpointSelectionStrategy string
+
+const (
+	// EndpointSelectionDefault uses the endpoints in the order of the priority set by the device.
+	EndpointSelectionDefault    EndpointSelectionStrategy = "default"
+	EndpointSelectionPreferTCP  EndpointSelectionStrategy = "preferTCP"
+	EndpointSelectionPreferIPv6 EndpointSelectionStrategy = "preferIPv6"
+	// EndpointSelectionLowestRTT prefers the endpoint with the lowest measured time to establish the connection.
+	EndpointSelectionLowestRTT EndpointSelectionStrategy = "lowestRTT"
+)
+
+var validEndpointSelectionStrategies = map[EndpointSelectionStrategy]bool{
+	EndpointSelectionDefault:    true,
+	EndpointSelectionPreferTCP:  true,
+	EndpointSelectionPreferIPv6: true,
+	EndpointSelectionLowestRTT:  true,
+}
+
+type EndpointSelectionConfig struct {
+	Strategy EndpointSelectionStrategy `yaml:"strategy" json:"strategy"`
+}
+
+func (c *EndpointSelectionConfig) Validate() error {
+	if c.Strategy == "" {
+		c.Strategy = EndpointSelectionDefault
+	}
+	if !validEndpointSelectionStrategies[c.Strategy] {
+		return fmt.Errorf("strategy('%v') - supports only '%v,%v,%v,%v'", c.Strategy, EndpointSelectionDefault, EndpointSelectionPreferTCP, EndpointSelectionPreferIPv6, EndpointSelectionLowestRTT)
+	}
+	return nil
+}
+
+type ResourceLinksCacheConfig struct {
+	// TTL is how long the resource links of the device are used for the requests to the device, 0 disables the cache.
+	TTL time.Duration `yaml:"ttl" json:"ttl"`
+}
+
+func (c *ResourceLinksCacheConfig) Validate() error {
+	if c.TTL < 0 {
+		return fmt.Errorf("ttl('%v') - must be greater than or equal to 0", c.TTL)
+	}
 	return nil
 }
 
@@ -200,9 +484,132 @@ const (
 	AuthenticationUninitialized Authentication = "uninitialized"
 )
 
+type dtlsCipherSuite struct {
+	id dtls.CipherSuiteID
+	// psk is true for the pre-shared key cipher suites
+	psk bool
+}
+
+// dtlsCipherSuites are the cipher suites supported by the DTLS connections.
+var dtlsCipherSuites = map[string]dtlsCipherSuite{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CCM":        {id: dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM, psk: false},
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8":      {id: dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8, psk: false},
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": {id: dtls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, psk: false},
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   {id: dtls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, psk: false},
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": {id: dtls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, psk: false},
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   {id: dtls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, psk: false},
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    {id: dtls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA, psk: false},
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      {id: dtls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA, psk: false},
+	"TLS_PSK_WITH_AES_128_CCM":                {id: dtls.TLS_PSK_WITH_AES_128_CCM, psk: true},
+	"TLS_PSK_WITH_AES_128_CCM_8":              {id: dtls.TLS_PSK_WITH_AES_128_CCM_8, psk: true},
+	"TLS_PSK_WITH_AES_256_CCM_8":              {id: dtls.TLS_PSK_WITH_AES_256_CCM_8, psk: true},
+	"TLS_PSK_WITH_AES_128_GCM_SHA256":         {id: dtls.TLS_PSK_WITH_AES_128_GCM_SHA256, psk: true},
+	"TLS_PSK_WITH_AES_128_CBC_SHA256":         {id: dtls.TLS_PSK_WITH_AES_128_CBC_SHA256, psk: true},
+	"TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256":   {id: dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256, psk: true},
+}
+
+type curve struct {
+	dtls dtlsElliptic.Curve
+	tls  tls.CurveID
+}
+
+var curves = map[string]curve{
+	"X25519": {dtls: dtlsElliptic.X25519, tls: tls.X25519},
+	"P-256":  {dtls: dtlsElliptic.P256, tls: tls.CurveP256},
+	"P-384":  {dtls: dtlsElliptic.P384, tls: tls.CurveP384},
+}
+
+func getTLSCipherSuite(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
 type TLSConfig struct {
 	Authentication Authentication     `yaml:"authentication" json:"authentication"`
 	PreSharedKey   PreSharedKeyConfig `yaml:"preSharedKey" json:"preSharedKey"`
+	// CipherSuites are the names of the allowed cipher suites, empty means the defaults of the authentication.
+	// The TLS connections are limited to TLS 1.2, because the cipher suites of TLS 1.3 are not configurable.
+	CipherSuites []string `yaml:"cipherSuites" json:"cipherSuites"`
+	// Curves are the names of the allowed elliptic curves, empty means the defaults of the libraries.
+	Curves []string `yaml:"curves" json:"curves"`
+	// HandshakeTimeout limits the DTLS and TLS handshake, 0 means that it is limited only by the request.
+	HandshakeTimeout  time.Duration           `yaml:"handshakeTimeout" json:"handshakeTimeout"`
+	ConnectionID      ConnectionIDConfig      `yaml:"connectionId" json:"connectionId"`
+	SessionResumption SessionResumptionConfig `yaml:"sessionResumption" json:"sessionResumption"`
+
+	cipherSuites    []dtls.CipherSuiteID `yaml:"-" json:"-"`
+	tlsCipherSuites []uint16             `yaml:"-" json:"-"`
+	curves          []dtlsElliptic.Curve `yaml:"-" json:"-"`
+	tlsCurves       []tls.CurveID        `yaml:"-" json:"-"`
+}
+
+// GetCipherSuites returns the allowed cipher suites of the DTLS connections, nil means the defaults.
+func (c *TLSConfig) GetCipherSuites() []dtls.CipherSuiteID {
+	return c.cipherSuites
+}
+
+// GetTLSCipherSuites returns the allowed cipher suites of the TLS connections, nil means the defaults.
+func (c *TLSConfig) GetTLSCipherSuites() []uint16 {
+	return c.tlsCipherSuites
+}
+
+// GetCurves returns the allowed elliptic curves of the DTLS connections, nil means the defaults.
+func (c *TLSConfig) GetCurves() []dtlsElliptic.Curve {
+	return c.curves
+}
+
+// GetTLSCurves returns the allowed elliptic curves of the TLS connections, nil means the defaults.
+func (c *TLSConfig) GetTLSCurves() []tls.CurveID {
+	return c.tlsCurves
+}
+
+func (c *TLSConfig) validateCipherSuites() error {
+	c.cipherSuites = nil
+	c.tlsCipherSuites = nil
+	for _, name := range c.CipherSuites {
+		suite, ok := dtlsCipherSuites[name]
+		if !ok {
+			return fmt.Errorf("cipherSuites('%v') - unsupported cipher suite %v", c.CipherSuites, name)
+		}
+		if suite.psk && c.Authentication == AuthenticationX509 {
+			return fmt.Errorf("cipherSuites('%v') - pre-shared key cipher suite %v cannot be used with %v authentication", c.CipherSuites, name, c.Authentication)
+		}
+		if !suite.psk && c.Authentication == AuthenticationPreSharedKey {
+			return fmt.Errorf("cipherSuites('%v') - certificate cipher suite %v cannot be used with %v authentication", c.CipherSuites, name, c.Authentication)
+		}
+		c.cipherSuites = append(c.cipherSuites, suite.id)
+		// the TLS connections use only the cipher suites supported by crypto/tls
+		if tlsID, ok := getTLSCipherSuite(name); ok {
+			c.tlsCipherSuites = append(c.tlsCipherSuites, tlsID)
+		}
+	}
+	return nil
+}
+
+func (c *TLSConfig) validateCurves() error {
+	c.curves = nil
+	c.tlsCurves = nil
+	if len(c.Curves) == 0 {
+		return nil
+	}
+	for _, name := range c.Curves {
+		v, ok := curves[name]
+		if !ok {
+			return fmt.Errorf("curves('%v') - unsupported curve %v, supports only 'X25519,P-256,P-384'", c.Curves, name)
+		}
+		c.curves = append(c.curves, v.dtls)
+		c.tlsCurves = append(c.tlsCurves, v.tls)
+	}
+	if len(c.CipherSuites) > 0 && !slices.ContainsFunc(c.CipherSuites, func(name string) bool {
+		return strings.HasPrefix(name, "TLS_ECDHE_")
+	}) {
+		return fmt.Errorf("curves('%v') - requires an ECDHE cipher suite in cipherSuites('%v')", c.Curves, c.CipherSuites)
+	}
+	return nil
 }
 
 func (c *TLSConfig) Validate() error {
@@ -216,6 +623,63 @@ func (c *TLSConfig) Validate() error {
 	default:
 		return fmt.Errorf("authentication('%v') - supports only '%v,%v'", c.Authentication, AuthenticationPreSharedKey, AuthenticationX509)
 	}
+	if err := c.validateCipherSuites(); err != nil {
+		return err
+	}
+	if err := c.validateCurves(); err != nil {
+		return err
+	}
+	if c.HandshakeTimeout < 0 {
+		return fmt.Errorf("handshakeTimeout('%v') - must be greater than or equal to 0", c.HandshakeTimeout)
+	}
+	if err := c.ConnectionID.Validate(); err != nil {
+		return fmt.Errorf("connectionId.%w", err)
+	}
+	if err := c.SessionResumption.Validate(); err != nil {
+		return fmt.Errorf("sessionResumption.%w", err)
+	}
+	return nil
+}
+
+// maxConnectionIDLength is the max length of the DTLS connection ID defined by RFC 9146.
+const maxConnectionIDLength = 255
+
+type ConnectionIDConfig struct {
+	// Enabled negotiates the DTLS connection ID extension, so the connection survives the change of the address of the device.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Length of the connection ID sent by the device, 0 means that only the client application sends the connection ID.
+	Length int `yaml:"length" json:"length"`
+}
+
+func (c *ConnectionIDConfig) Validate() error {
+	if c.Length < 0 || c.Length > maxConnectionIDLength {
+		return fmt.Errorf("length('%v') - must be in range 0-%v", c.Length, maxConnectionIDLength)
+	}
+	return nil
+}
+
+// defaultMaxSessions is used when the max number of the stored sessions is not set.
+const defaultMaxSessions = 64
+
+type SessionResumptionConfig struct {
+	// Enabled stores the DTLS and TLS sessions, so the next connection to the device uses the abbreviated handshake.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// MaxSessions is the max number of the stored sessions, 0 means 64.
+	MaxSessions int `yaml:"maxSessions" json:"maxSessions"`
+}
+
+// GetMaxSessions returns the max number of the stored sessions.
+func (c *SessionResumptionConfig) GetMaxSessions() int {
+	if c.MaxSessions == 0 {
+		return defaultMaxSessions
+	}
+	return c.MaxSessions
+}
+
+func (c *SessionResumptionConfig) Validate() error {
+	if c.MaxSessions < 0 {
+		return fmt.Errorf("maxSessions('%v') - must be greater than or equal to 0", c.MaxSessions)
+	}
 	return nil
 }
 
@@ -283,10 +747,43 @@ var defaultConfig = Config{
 				SubjectIDStr: "",
 				Key:          "",
 			},
+			CipherSuites: []string{},
+			Curves:       []string{},
+			SessionResumption: SessionResumptionConfig{
+				MaxSessions: defaultMaxSessions,
+			},
 		},
 		OwnershipTransfer: OwnershipTransferConfig{
 			Methods: []OwnershipTransferMethod{OwnershipTransferJustWorks},
 		},
+		Retry: RetryConfig{
+			MaxAttempts:        1,
+			InitialBackoff:     time.Millisecond * 100,
+			MaxBackoff:         time.Second * 2,
+			Jitter:             0.2,
+			RetryableCoapCodes: []string{codes.ServiceUnavailable.String(), codes.GatewayTimeout.String()},
+			RetryableErrors:    []RetryableError{RetryableErrorTimeout, RetryableErrorConnectionRefused, RetryableErrorConnectionReset, RetryableErrorUnreachable},
+		},
+		EndpointSelection: EndpointSelectionConfig{
+			Strategy: EndpointSelectionDefault,
+		},
+		ResourceLinksCache: ResourceLinksCacheConfig{
+			TTL: 0,
+		},
+	},
+	Discovery: DiscoveryConfig{
+		MulticastInterfaces: []string{},
+		MulticastScopes:     []MulticastScope{MulticastScopeIPv4, MulticastScopeIPv6LinkLocal, MulticastScopeIPv6RealmLocal, MulticastScopeIPv6SiteLocal},
+		SubnetSweep: SubnetSweepConfig{
+			MaxConcurrency: 64,
+			RateLimit:      200,
+			HostTimeout:    time.Second,
+			MaxHosts:       65536,
+		},
+		DNSSD: DNSSDConfig{
+			Services:  []string{"_ocf._udp", "_coap._udp"},
+			Addresses: []string{dnssd.MulticastAddressUDP4, dnssd.MulticastAddressUDP6},
+		},
 	},
 }
 
@@ -17,10 +17,13 @@
 package device_test
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/plgd-dev/client-application/service/config/device"
 	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -188,6 +191,7 @@ func TestCoapConfigValidate(t *testing.T) {
 		BlockwiseTransfer device.BlockwiseTransferConfig
 		OwnershipTransfer device.OwnershipTransferConfig
 		TLS               device.TLSConfig
+		Capture           device.CaptureConfig
 	}
 	tests := []struct {
 		name    string
@@ -257,6 +261,20 @@ func TestCoapConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid capture",
+			fields: fields{
+				MaxMessageSize:    test.MakeDeviceConfig().COAP.MaxMessageSize,
+				InactivityMonitor: test.MakeDeviceConfig().COAP.InactivityMonitor,
+				BlockwiseTransfer: test.MakeDeviceConfig().COAP.BlockwiseTransfer,
+				OwnershipTransfer: test.MakeDeviceConfig().COAP.OwnershipTransfer,
+				TLS:               test.MakeDeviceConfig().COAP.TLS,
+				Capture: device.CaptureConfig{
+					FilePath: os.TempDir(),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -266,6 +284,7 @@ func TestCoapConfigValidate(t *testing.T) {
 				BlockwiseTransfer: tt.fields.BlockwiseTransfer,
 				OwnershipTransfer: tt.fields.OwnershipTransfer,
 				TLS:               tt.fields.TLS,
+				Capture:           tt.fields.Capture,
 			}
 			err := c.Validate()
 			if tt.wantErr {
@@ -276,3 +295,277 @@ func TestCoapConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     device.RetryConfig
+		wantErr bool
+	}{
+		{
+			name: "default",
+			cfg:  device.DefaultConfig().COAP.Retry,
+		},
+		{
+			name: "disabled",
+			cfg:  device.RetryConfig{},
+		},
+		{
+			name:    "negative maxAttempts",
+			cfg:     device.RetryConfig{MaxAttempts: -1},
+			wantErr: true,
+		},
+		{
+			name:    "missing initialBackoff",
+			cfg:     device.RetryConfig{MaxAttempts: 3, MaxBackoff: time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "maxBackoff less than initialBackoff",
+			cfg:     device.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond},
+			wantErr: true,
+		},
+		{
+			name:    "timeout without attemptTimeout",
+			cfg:     device.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second, RetryableErrors: []device.RetryableError{device.RetryableErrorTimeout}},
+			wantErr: true,
+		},
+		{
+			name: "without attemptTimeout",
+			cfg:  device.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second, RetryableErrors: []device.RetryableError{device.RetryableErrorConnectionReset}},
+		},
+		{
+			name:    "invalid jitter",
+			cfg:     device.RetryConfig{Jitter: 1.5},
+			wantErr: true,
+		},
+		{
+			name:    "invalid retryableCoapCodes",
+			cfg:     device.RetryConfig{RetryableCoapCodes: []string{"NotACode"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid retryableErrors",
+			cfg:     device.RetryConfig{RetryableErrors: []device.RetryableError{"notAnError"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	cfg := device.DefaultConfig().COAP.Retry
+	require.NoError(t, cfg.Validate())
+	// the retries are opt-in
+	require.False(t, cfg.IsEnabled())
+	cfg.MaxAttempts = 3
+	require.Error(t, cfg.Validate())
+	cfg.AttemptTimeout = time.Second * 3
+	require.NoError(t, cfg.Validate())
+	require.True(t, cfg.IsEnabled())
+	require.True(t, cfg.IsRetryableCoapCode(codes.ServiceUnavailable))
+	require.False(t, cfg.IsRetryableCoapCode(codes.Forbidden))
+	require.True(t, cfg.IsRetryableError(device.RetryableErrorTimeout))
+}
+
+func TestEndpointSelectionConfigValidate(t *testing.T) {
+	cfg := device.EndpointSelectionConfig{}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, device.EndpointSelectionDefault, cfg.Strategy)
+
+	cfg = device.EndpointSelectionConfig{Strategy: device.EndpointSelectionLowestRTT}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.EndpointSelectionConfig{Strategy: "preferUDP"}
+	require.Error(t, cfg.Validate())
+}
+
+func TestDiscoveryConfigValidate(t *testing.T) {
+	cfg := device.DiscoveryConfig{}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.DiscoveryConfig{
+		MulticastInterfaces: []string{"eth0"},
+		MulticastScopes:     []device.MulticastScope{device.MulticastScopeIPv4, device.MulticastScopeIPv6LinkLocal},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.DiscoveryConfig{MulticastInterfaces: []string{""}}
+	require.Error(t, cfg.Validate())
+
+	cfg = device.DiscoveryConfig{MulticastScopes: []device.MulticastScope{"ipv6GlobalScope"}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestSubnetSweepConfigValidate(t *testing.T) {
+	cfg := device.SubnetSweepConfig{}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, 64, cfg.MaxConcurrency)
+	require.Equal(t, time.Second, cfg.HostTimeout)
+	require.Equal(t, 65536, cfg.MaxHosts)
+
+	cfg = device.SubnetSweepConfig{MaxConcurrency: -1}
+	require.Error(t, cfg.Validate())
+
+	cfg = device.SubnetSweepConfig{RateLimit: -1}
+	require.Error(t, cfg.Validate())
+
+	cfg = device.SubnetSweepConfig{HostTimeout: -time.Second}
+	require.Error(t, cfg.Validate())
+
+	cfg = device.SubnetSweepConfig{MaxHosts: -1}
+	require.Error(t, cfg.Validate())
+}
+
+func TestDNSSDConfigValidate(t *testing.T) {
+	cfg := device.DNSSDConfig{}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, device.DefaultConfig().Discovery.DNSSD, cfg)
+
+	cfg = device.DNSSDConfig{Services: []string{"_coap._tcp"}, Addresses: []string{"224.0.0.251:5353"}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.DNSSDConfig{Services: []string{"_ocf"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = device.DNSSDConfig{Addresses: []string{"224.0.0.251"}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestResourceLinksCacheConfigValidate(t *testing.T) {
+	cfg := device.ResourceLinksCacheConfig{}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.ResourceLinksCacheConfig{TTL: time.Minute}
+	require.NoError(t, cfg.Validate())
+
+	cfg = device.ResourceLinksCacheConfig{TTL: -time.Second}
+	require.Error(t, cfg.Validate())
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     device.TLSConfig
+		wantErr bool
+	}{
+		{
+			name: "default",
+			cfg:  device.DefaultConfig().COAP.TLS,
+		},
+		{
+			name: "x509",
+			cfg: device.TLSConfig{
+				Authentication:    device.AuthenticationX509,
+				CipherSuites:      []string{"TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				Curves:            []string{"P-256"},
+				HandshakeTimeout:  time.Second,
+				ConnectionID:      device.ConnectionIDConfig{Enabled: true, Length: 8},
+				SessionResumption: device.SessionResumptionConfig{Enabled: true},
+			},
+		},
+		{
+			name: "preSharedKey",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationPreSharedKey,
+				PreSharedKey:   device.PreSharedKeyConfig{SubjectIDStr: "57b3b2b4-1f0f-4d5e-9a26-4f3a36c4a5b1", Key: "secret"},
+				CipherSuites:   []string{"TLS_PSK_WITH_AES_128_CCM_8", "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256"},
+				Curves:         []string{"X25519"},
+			},
+		},
+		{
+			name: "invalid cipher suite",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationX509,
+				CipherSuites:   []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pre-shared key cipher suite with x509",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationX509,
+				CipherSuites:   []string{"TLS_PSK_WITH_AES_128_CCM_8"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate cipher suite with pre-shared key",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationPreSharedKey,
+				PreSharedKey:   device.PreSharedKeyConfig{SubjectIDStr: "57b3b2b4-1f0f-4d5e-9a26-4f3a36c4a5b1", Key: "secret"},
+				CipherSuites:   []string{"TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid curve",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationX509,
+				Curves:         []string{"P-521"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "curves without ECDHE cipher suite",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationPreSharedKey,
+				PreSharedKey:   device.PreSharedKeyConfig{SubjectIDStr: "57b3b2b4-1f0f-4d5e-9a26-4f3a36c4a5b1", Key: "secret"},
+				CipherSuites:   []string{"TLS_PSK_WITH_AES_128_CCM_8"},
+				Curves:         []string{"P-256"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid handshakeTimeout",
+			cfg: device.TLSConfig{
+				Authentication:   device.AuthenticationX509,
+				HandshakeTimeout: -time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid connectionId.length",
+			cfg: device.TLSConfig{
+				Authentication: device.AuthenticationX509,
+				ConnectionID:   device.ConnectionIDConfig{Enabled: true, Length: 256},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid sessionResumption.maxSessions",
+			cfg: device.TLSConfig{
+				Authentication:    device.AuthenticationX509,
+				SessionResumption: device.SessionResumptionConfig{Enabled: true, MaxSessions: -1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, tt.cfg.GetCipherSuites(), len(tt.cfg.CipherSuites))
+			require.Len(t, tt.cfg.GetCurves(), len(tt.cfg.Curves))
+			require.Len(t, tt.cfg.GetTLSCurves(), len(tt.cfg.Curves))
+			require.Positive(t, tt.cfg.SessionResumption.GetMaxSessions())
+		})
+	}
+
+	// the validation does not set the default
+	cfg := device.TLSConfig{SessionResumption: device.SessionResumptionConfig{Enabled: true}}
+	require.NoError(t, cfg.SessionResumption.Validate())
+	require.Zero(t, cfg.SessionResumption.MaxSessions)
+	require.Equal(t, 64, cfg.SessionResumption.GetMaxSessions())
+}
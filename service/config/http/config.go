@@ -81,7 +81,7 @@ func DefaultConfig(directory string) Config {
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: []string{"*"},
-			AllowedHeaders: []string{"Accept", "Accept-Language", "Accept-Encoding", "Content-Type", "Content-Language", "Content-Length", "Origin", "X-CSRF-Token", "Authorization"},
+			AllowedHeaders: []string{"Accept", "Accept-Language", "Accept-Encoding", "Content-Type", "Content-Language", "Content-Length", "Origin", "X-CSRF-Token", "Authorization", "X-API-Key"},
 			AllowedMethods: []string{"GET", "PATCH", "HEAD", "POST", "PUT", "OPTIONS", "DELETE"},
 		},
 		UI: UIConfig{
@@ -0,0 +1,44 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package metadata
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+type Config struct {
+	// FilePath is the path to the yaml file with the user-defined metadata of devices. When it is empty, the metadata are kept only in memory.
+	FilePath string `yaml:"filePath" json:"filePath"`
+}
+
+func (c *Config) Validate() error {
+	if c.FilePath == "" {
+		return nil
+	}
+	if fi, err := os.Stat(c.FilePath); err == nil && fi.IsDir() {
+		return fmt.Errorf("filePath('%v') - is a directory", c.FilePath)
+	}
+	return nil
+}
+
+func DefaultConfig(directory string) Config {
+	return Config{
+		FilePath: path.Join(directory, "metadata.yaml"),
+	}
+}
@@ -0,0 +1,108 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package owners
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Subject identifies the users by a claim of the JWT token, e.g. the subject by the "sub" claim or a group by the "groups" claim.
+type Subject struct {
+	Claim string `yaml:"claim" json:"claim"`
+	Value string `yaml:"value" json:"value"`
+}
+
+func (s *Subject) Validate() error {
+	if s.Claim == "" {
+		return errors.New("claim('') - is empty")
+	}
+	if s.Value == "" {
+		return errors.New("value('') - is empty")
+	}
+	return nil
+}
+
+// Matches returns true when the claim of the token is equal to the value or when the claim is an array which contains the value.
+func (s *Subject) Matches(claims map[string]interface{}) bool {
+	switch v := claims[s.Claim].(type) {
+	case string:
+		return v == s.Value
+	case []string:
+		return slices.Contains(v, s.Value)
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok && str == s.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Config contains the additional subjects who may operate the devices owned by the owner of the client application in the X509 mode.
+type Config struct {
+	Subjects []Subject `yaml:"subjects" json:"subjects"`
+}
+
+func (c *Config) Validate() error {
+	for i := range c.Subjects {
+		if err := c.Subjects[i].Validate(); err != nil {
+			return fmt.Errorf("subjects[%v].%w", i, err)
+		}
+		if slices.Contains(c.Subjects[:i], c.Subjects[i]) {
+			return fmt.Errorf("subjects[%v] - is duplicated", i)
+		}
+	}
+	return nil
+}
+
+// Find returns the first subject which matches the claims of the token.
+func (c *Config) Find(claims map[string]interface{}) (Subject, bool) {
+	for _, s := range c.Subjects {
+		if s.Matches(claims) {
+			return s, true
+		}
+	}
+	return Subject{}, false
+}
+
+// Add adds the subject, it returns false when the subject is already present.
+func (c *Config) Add(s Subject) bool {
+	if slices.Contains(c.Subjects, s) {
+		return false
+	}
+	c.Subjects = append(slices.Clone(c.Subjects), s)
+	return true
+}
+
+// Remove removes the subject, it returns false when the subject is not present.
+func (c *Config) Remove(s Subject) bool {
+	i := slices.Index(c.Subjects, s)
+	if i < 0 {
+		return false
+	}
+	c.Subjects = slices.Delete(slices.Clone(c.Subjects), i, i+1)
+	return true
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Subjects: []Subject{},
+	}
+}
@@ -0,0 +1,69 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package owners_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/service/config/owners"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     owners.Config
+		wantErr bool
+	}{
+		{name: "default", cfg: owners.DefaultConfig()},
+		{name: "valid", cfg: owners.Config{Subjects: []owners.Subject{{Claim: "sub", Value: "a"}, {Claim: "groups", Value: "a"}}}},
+		{name: "empty claim", cfg: owners.Config{Subjects: []owners.Subject{{Value: "a"}}}, wantErr: true},
+		{name: "empty value", cfg: owners.Config{Subjects: []owners.Subject{{Claim: "sub"}}}, wantErr: true},
+		{name: "duplicated", cfg: owners.Config{Subjects: []owners.Subject{{Claim: "sub", Value: "a"}, {Claim: "sub", Value: "a"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigFind(t *testing.T) {
+	cfg := owners.DefaultConfig()
+	group := owners.Subject{Claim: "groups", Value: "operators"}
+	require.True(t, cfg.Add(owners.Subject{Claim: "sub", Value: "user"}))
+	require.True(t, cfg.Add(group))
+	require.False(t, cfg.Add(group))
+
+	_, ok := cfg.Find(map[string]interface{}{"sub": "user"})
+	require.True(t, ok)
+	s, ok := cfg.Find(map[string]interface{}{"sub": "other", "groups": []interface{}{"users", "operators"}})
+	require.True(t, ok)
+	require.Equal(t, group, s)
+	_, ok = cfg.Find(map[string]interface{}{"sub": "other", "groups": "users"})
+	require.False(t, ok)
+
+	require.True(t, cfg.Remove(group))
+	require.False(t, cfg.Remove(group))
+	_, ok = cfg.Find(map[string]interface{}{"groups": []string{"operators"}})
+	require.False(t, ok)
+}
@@ -34,6 +34,9 @@ var defaultConfig = Config{
 	WebOauthClient:    &grpcgwPb.OAuthClient{},
 	DeviceOauthClient: &grpcgwPb.OAuthClient{},
 	M2MOauthClient:    &grpcgwPb.OAuthClient{},
+	OpenIdDiscovery: &pb.OpenIDDiscovery{
+		RefreshInterval: (time.Minute * 10).Nanoseconds(),
+	},
 }
 
 func DefaultConfig() *Config {
@@ -0,0 +1,68 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/plgd-dev/client-application/pkg/simulator"
+)
+
+type Config struct {
+	// NumDevices is the number of simulated devices started inside the client application, 0 disables the simulator.
+	NumDevices int `yaml:"numDevices" json:"numDevices"`
+	// DescriptionFile is the path to the yaml or json description of the simulated devices. When it is empty, light devices are simulated.
+	DescriptionFile string `yaml:"descriptionFile" json:"descriptionFile"`
+}
+
+func (c *Config) Validate() error {
+	if c.NumDevices < 0 {
+		return fmt.Errorf("numDevices('%v') - must be greater than or equal to 0", c.NumDevices)
+	}
+	if c.NumDevices == 0 || c.DescriptionFile == "" {
+		return nil
+	}
+	if _, err := simulator.LoadDescription(c.DescriptionFile); err != nil {
+		return fmt.Errorf("descriptionFile('%v') - %w", c.DescriptionFile, err)
+	}
+	return nil
+}
+
+// Description returns the description of the simulated devices.
+func (c *Config) Description() (*simulator.Description, error) {
+	if c.DescriptionFile == "" {
+		return simulator.DefaultDescription(), nil
+	}
+	return simulator.LoadDescription(c.DescriptionFile)
+}
+
+// MakeDeviceConfigs returns configurations of the simulated devices. The devices respond to multicast
+// discovery so they are found by GetDevices as the real ones.
+func (c *Config) MakeDeviceConfigs() ([]simulator.Config, error) {
+	if c.NumDevices == 0 {
+		return nil, nil
+	}
+	d, err := c.Description()
+	if err != nil {
+		return nil, err
+	}
+	cfgs := d.MakeConfigs(c.NumDevices)
+	for i := range cfgs {
+		cfgs[i].Multicast = true
+	}
+	return cfgs, nil
+}
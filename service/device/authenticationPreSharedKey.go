@@ -33,14 +33,18 @@ import (
 )
 
 type authenticationPreSharedKey struct {
-	getConfig func() configDevice.Config
+	getConfig     func() configDevice.Config
+	dialer        *dialer
+	tlsParameters *tlsParameters
 }
 
 var errPreSharedKeyAuthentication = status.Errorf(codes.Unimplemented, "authentication method is set to %v: not supported", configDevice.AuthenticationPreSharedKey)
 
-func newAuthenticationPreSharedKey(getConfig func() configDevice.Config) *authenticationPreSharedKey {
+func newAuthenticationPreSharedKey(getConfig func() configDevice.Config, dialer *dialer, tlsParameters *tlsParameters) *authenticationPreSharedKey {
 	return &authenticationPreSharedKey{
-		getConfig: getConfig,
+		getConfig:     getConfig,
+		dialer:        dialer,
+		tlsParameters: tlsParameters,
 	}
 }
 
@@ -74,7 +78,8 @@ func (s *authenticationPreSharedKey) DialDTLS(ctx context.Context, addr string,
 		},
 		CipherSuites: []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256},
 	}
-	return coap.DialUDPSecure(ctx, addr, dtlsCfg, opts...)
+	s.tlsParameters.setupDTLS(ctx, dtlsCfg)
+	return s.dialer.DialUDPSecure(ctx, addr, dtlsCfg, opts...)
 }
 
 func (s *authenticationPreSharedKey) DialTLS(_ context.Context, _ string, _ *tls.Config, _ ...tcp.Option) (*coap.ClientCloseHandler, error) {
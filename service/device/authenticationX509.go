@@ -38,16 +38,24 @@ import (
 	"go.uber.org/atomic"
 )
 
+// x509CipherSuites are the default cipher suites of the DTLS connections authenticated by the identity certificate, the same as used by the device library.
+var x509CipherSuites = []dtls.CipherSuiteID{dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8, dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM}
+
 type authenticationX509 struct {
 	config      configDevice.Config
 	privateKey  atomic.Pointer[ecdsa.PrivateKey]
 	certificate atomic.Pointer[tls.Certificate]
 	owner       atomic.String
+	dialer      *dialer
+	// tlsParameters are applied to the connections authenticated by the identity certificate
+	tlsParameters *tlsParameters
 }
 
-func newAuthenticationX509(config configDevice.Config) *authenticationX509 {
+func newAuthenticationX509(config configDevice.Config, dialer *dialer, tlsParameters *tlsParameters) *authenticationX509 {
 	return &authenticationX509{
-		config: config,
+		config:        config,
+		dialer:        dialer,
+		tlsParameters: tlsParameters,
 	}
 }
 
@@ -97,7 +105,11 @@ func (s *authenticationX509) DialDTLS(ctx context.Context, addr string, dtlsCfg
 	}
 	dtlsCfg.Certificates = []tls.Certificate{*crt}
 	dtlsCfg.ClientCAs = clientCAs
-	return coap.DialUDPSecure(ctx, addr, dtlsCfg, opts...)
+	if len(dtlsCfg.CipherSuites) == 0 {
+		dtlsCfg.CipherSuites = x509CipherSuites
+	}
+	s.tlsParameters.setupDTLS(ctx, dtlsCfg)
+	return s.dialer.DialUDPSecure(ctx, addr, dtlsCfg, opts...)
 }
 
 func (s *authenticationX509) DialTLS(ctx context.Context, addr string, tlsCfg *tls.Config, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
@@ -107,7 +119,8 @@ func (s *authenticationX509) DialTLS(ctx context.Context, addr string, tlsCfg *t
 	}
 	tlsCfg.Certificates = []tls.Certificate{*crt}
 	tlsCfg.ClientCAs = clientCAs
-	return coap.DialTCPSecure(ctx, addr, tlsCfg, opts...)
+	s.tlsParameters.setupTLS(tlsCfg)
+	return s.dialer.DialTCPSecure(ctx, addr, tlsCfg, append(opts, s.tlsParameters.getDialTCPOptions(ctx)...)...)
 }
 
 func (s *authenticationX509) GetOwnerID() (string, error) {
@@ -0,0 +1,155 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/pion/dtls/v2"
+	"github.com/plgd-dev/client-application/pkg/capture"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	coapDtls "github.com/plgd-dev/go-coap/v3/dtls"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/go-coap/v3/options"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/plgd-dev/go-coap/v3/udp"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+)
+
+// dialer creates connections to devices and tracks their statistics, when the recorder is set then the connections are captured.
+type dialer struct {
+	recorder *capture.Recorder
+	logger   log.Logger
+	// connections are the open connections with their statistics
+	connections *coapSync.Map[*coap.ClientCloseHandler, *statisticsConn]
+}
+
+func (d *dialer) wrap(conn coap.ClientConn, scheme schema.Scheme) coap.ClientConn {
+	if d.recorder == nil {
+		return conn
+	}
+	return capture.NewConn(conn, scheme, d.recorder, func(err error) {
+		d.logger.Warnf("cannot capture CoAP message: %v", err)
+	})
+}
+
+func getDefaultDialUDPOptions(ctx context.Context) []udp.Option {
+	dopts := []udp.Option{
+		options.WithErrors(func(error) {
+			// ignore by default
+		}),
+		options.WithMessagePool(pool.New(0, 0)),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		dopts = append(dopts, options.WithDialer(&net.Dialer{
+			Timeout: time.Until(deadline),
+		}))
+	}
+	return dopts
+}
+
+func getDefaultDialTCPOptions(ctx context.Context) []tcp.Option {
+	dopts := []tcp.Option{
+		options.WithErrors(func(error) {
+			// ignore by default
+		}),
+		options.WithMessagePool(pool.New(0, 0)),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		dopts = append(dopts, options.WithDialer(&net.Dialer{
+			Timeout: time.Until(deadline),
+		}))
+	}
+	return dopts
+}
+
+// DialUDPSecure is equivalent to coap.DialUDPSecure.
+func (d *dialer) DialUDPSecure(ctx context.Context, addr string, dtlsCfg *dtls.Config, opts ...udp.Option) (*coap.ClientCloseHandler, error) {
+	if dtlsCfg.ConnectContextMaker == nil {
+		dtlsCfg.ConnectContextMaker = func() (context.Context, func()) {
+			return ctx, func() {
+				// no-op
+			}
+		}
+	}
+	h := coap.NewOnCloseHandler()
+	c, err := coapDtls.Dial(addr, dtlsCfg, append(getDefaultDialUDPOptions(ctx), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	c.AddOnClose(func() {
+		h.OnClose(nil)
+	})
+	return d.newClientCloseHandler(c, schema.UDPSecureScheme, h), nil
+}
+
+// DialTCPSecure is equivalent to coap.DialTCPSecure.
+func (d *dialer) DialTCPSecure(ctx context.Context, addr string, tlsCfg *tls.Config, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
+	dopts := append(getDefaultDialTCPOptions(ctx), options.WithTLS(tlsCfg))
+	return d.dialTCP(addr, schema.TCPSecureScheme, append(dopts, opts...)...)
+}
+
+// DialTCP is equivalent to coap.DialTCP.
+func (d *dialer) DialTCP(ctx context.Context, addr string, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
+	return d.dialTCP(addr, schema.TCPScheme, append(getDefaultDialTCPOptions(ctx), opts...)...)
+}
+
+func (d *dialer) dialTCP(addr string, scheme schema.Scheme, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
+	h := coap.NewOnCloseHandler()
+	c, err := tcp.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.AddOnClose(func() {
+		h.OnClose(nil)
+	})
+	return d.newClientCloseHandler(c, scheme, h), nil
+}
+
+func (d *dialer) Close() error {
+	if d.recorder == nil {
+		return nil
+	}
+	return d.recorder.Close()
+}
+
+// WrapConnection returns the connection which is captured when the capture is enabled.
+func (s *Service) WrapConnection(conn coap.ClientConn, scheme schema.Scheme) coap.ClientConn {
+	return s.dialer.wrap(conn, scheme)
+}
+
+// CaptureMulticastResponse records the response to the multicast request when the capture is enabled.
+func (s *Service) CaptureMulticastResponse(remoteAddr net.Addr, path string, queries []string, resp *pool.Message) {
+	if s.dialer.recorder == nil {
+		return
+	}
+	opts := make([]message.Option, 0, len(queries))
+	for _, q := range queries {
+		opts = append(opts, message.Option{ID: message.URIQuery, Value: []byte(q)})
+	}
+	req := capture.NewRequest(codes.GET, path, 0, nil, opts...)
+	if err := s.dialer.recorder.RecordResponse(schema.UDPScheme, remoteAddr, true, time.Now(), req, resp, nil); err != nil {
+		s.logger.Warnf("cannot capture CoAP message: %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	tcpCoder "github.com/plgd-dev/go-coap/v3/tcp/coder"
+	udpCoder "github.com/plgd-dev/go-coap/v3/udp/coder"
+)
+
+// ConnectionStatistics are the counters of the connection to the device.
+type ConnectionStatistics struct {
+	// BytesIn is the approximate size of the received CoAP messages, it is estimated from the encoded size of the requests, responses and notifications,
+	// so the retransmissions, the empty messages, the headers of the additional blocks of the blockwise transfers and the DTLS/TLS records are not counted.
+	BytesIn uint64
+	// BytesOut is the approximate size of the sent CoAP messages, see BytesIn.
+	BytesOut uint64
+	// LastActivity is the time of the last sent or received CoAP message.
+	LastActivity time.Time
+}
+
+// statisticsConn estimates the size of the CoAP messages of the wrapped connection by encoding their headers.
+type statisticsConn struct {
+	coap.ClientConn
+	encoder      pool.Encoder
+	bytesIn      atomic.Uint64
+	bytesOut     atomic.Uint64
+	lastActivity atomic.Int64
+}
+
+func newStatisticsConn(conn coap.ClientConn, scheme schema.Scheme) *statisticsConn {
+	var encoder pool.Encoder = udpCoder.DefaultCoder
+	if scheme == schema.TCPScheme || scheme == schema.TCPSecureScheme {
+		encoder = tcpCoder.DefaultCoder
+	}
+	c := &statisticsConn{
+		ClientConn: conn,
+		encoder:    encoder,
+	}
+	c.lastActivity.Store(time.Now().UnixNano())
+	return c
+}
+
+// tokenSize is the size of the token generated by go-coap for the requests.
+const tokenSize = 8
+
+// messageSize returns the encoded size of the message, the payload is not read so the size of its
+// length in the header of the TCP message is not counted.
+func (c *statisticsConn) messageSize(code codes.Code, token message.Token, opts message.Options, payloadSize int64) uint64 {
+	size, err := c.encoder.Size(message.Message{
+		Code:    code,
+		Token:   token,
+		Options: opts,
+		Type:    message.Confirmable,
+	})
+	if err != nil {
+		return 0
+	}
+	if payloadSize > 0 {
+		// payload marker
+		size++
+	}
+	return uint64(size) + uint64(payloadSize)
+}
+
+func (c *statisticsConn) onRequest(ctx context.Context, code codes.Code, path string, payload io.ReadSeeker, opts ...message.Option) {
+	req := pool.NewMessage(ctx)
+	req.SetCode(code)
+	req.SetToken(make(message.Token, tokenSize))
+	if err := req.SetPath(path); err != nil {
+		return
+	}
+	for _, o := range opts {
+		req.AddOptionBytes(o.ID, o.Value)
+	}
+	req.SetBody(payload)
+	payloadSize, err := req.BodySize()
+	if err != nil {
+		payloadSize = 0
+	}
+	c.bytesOut.Add(c.messageSize(code, req.Token(), req.Options(), payloadSize))
+	c.lastActivity.Store(time.Now().UnixNano())
+}
+
+func (c *statisticsConn) onResponse(resp *pool.Message) {
+	if resp == nil {
+		return
+	}
+	payloadSize, err := resp.BodySize()
+	if err != nil {
+		payloadSize = 0
+	}
+	c.bytesIn.Add(c.messageSize(resp.Code(), resp.Token(), resp.Options(), payloadSize))
+	c.lastActivity.Store(time.Now().UnixNano())
+}
+
+func (c *statisticsConn) Post(ctx context.Context, path string, contentFormat message.MediaType, payload io.ReadSeeker, opts ...message.Option) (*pool.Message, error) {
+	c.onRequest(ctx, codes.POST, path, payload, append(opts, message.Option{ID: message.ContentFormat, Value: contentFormatValue(contentFormat)})...)
+	resp, err := c.ClientConn.Post(ctx, path, contentFormat, payload, opts...)
+	c.onResponse(resp)
+	return resp, err
+}
+
+func (c *statisticsConn) Get(ctx context.Context, path string, opts ...message.Option) (*pool.Message, error) {
+	c.onRequest(ctx, codes.GET, path, nil, opts...)
+	resp, err := c.ClientConn.Get(ctx, path, opts...)
+	c.onResponse(resp)
+	return resp, err
+}
+
+func (c *statisticsConn) Delete(ctx context.Context, path string, opts ...message.Option) (*pool.Message, error) {
+	c.onRequest(ctx, codes.DELETE, path, nil, opts...)
+	resp, err := c.ClientConn.Delete(ctx, path, opts...)
+	c.onResponse(resp)
+	return resp, err
+}
+
+// Observe counts each notification as the received message.
+func (c *statisticsConn) Observe(ctx context.Context, path string, observeFunc func(notification *pool.Message), opts ...message.Option) (coap.Observation, error) {
+	c.onRequest(ctx, codes.GET, path, nil, append(opts, message.Option{ID: message.Observe, Value: []byte{0}})...)
+	return c.ClientConn.Observe(ctx, path, func(notification *pool.Message) {
+		c.onResponse(notification)
+		observeFunc(notification)
+	}, opts...)
+}
+
+func (c *statisticsConn) statistics() ConnectionStatistics {
+	return ConnectionStatistics{
+		BytesIn:      c.bytesIn.Load(),
+		BytesOut:     c.bytesOut.Load(),
+		LastActivity: time.Unix(0, c.lastActivity.Load()),
+	}
+}
+
+func contentFormatValue(contentFormat message.MediaType) []byte {
+	buf := make([]byte, 4)
+	n, err := message.EncodeUint32(buf, uint32(contentFormat))
+	if err != nil {
+		return nil
+	}
+	return buf[:n]
+}
+
+// newClientCloseHandler wraps the connection and tracks its statistics until the connection is closed.
+func (d *dialer) newClientCloseHandler(conn coap.ClientConn, scheme schema.Scheme, h *coap.OnCloseHandler) *coap.ClientCloseHandler {
+	sc := newStatisticsConn(d.wrap(conn, scheme), scheme)
+	c := coap.NewClientCloseHandler(sc, h)
+	d.connections.Store(c, sc)
+	c.RegisterCloseHandler(func(error) {
+		d.connections.Delete(c)
+	})
+	if conn.Context().Err() != nil {
+		// the connection was closed before the close handler was registered
+		d.connections.Delete(c)
+	}
+	return c
+}
+
+// GetConnectionStatistics returns the statistics of the open connection created by the dialers of the service.
+func (s *Service) GetConnectionStatistics(conn *coap.ClientCloseHandler) (ConnectionStatistics, bool) {
+	sc, ok := s.dialer.connections.Load(conn)
+	if !ok {
+		return ConnectionStatistics{}, false
+	}
+	return sc.statistics(), true
+}
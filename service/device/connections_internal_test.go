@@ -0,0 +1,150 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+type testConn struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	body   []byte
+}
+
+func newTestConn(body []byte) *testConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &testConn{ctx: ctx, cancel: cancel, body: body}
+}
+
+func (c *testConn) response(ctx context.Context) *pool.Message {
+	resp := pool.NewMessage(ctx)
+	resp.SetCode(codes.Content)
+	resp.SetToken(make(message.Token, tokenSize))
+	resp.SetContentFormat(message.AppOcfCbor)
+	resp.SetBody(bytes.NewReader(c.body))
+	return resp
+}
+
+func (c *testConn) Post(ctx context.Context, _ string, _ message.MediaType, _ io.ReadSeeker, _ ...message.Option) (*pool.Message, error) {
+	return c.response(ctx), nil
+}
+
+func (c *testConn) Get(ctx context.Context, _ string, _ ...message.Option) (*pool.Message, error) {
+	return c.response(ctx), nil
+}
+
+func (c *testConn) Delete(ctx context.Context, _ string, _ ...message.Option) (*pool.Message, error) {
+	return c.response(ctx), nil
+}
+
+func (c *testConn) Observe(ctx context.Context, _ string, observeFunc func(notification *pool.Message), _ ...message.Option) (coap.Observation, error) {
+	observeFunc(c.response(ctx))
+	return nil, nil
+}
+
+func (c *testConn) RemoteAddr() net.Addr {
+	return &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5684}
+}
+
+func (c *testConn) Close() error {
+	c.cancel()
+	return nil
+}
+
+func (c *testConn) Context() context.Context {
+	return c.ctx
+}
+
+func (c *testConn) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+func TestStatisticsConn(t *testing.T) {
+	body := []byte("payload")
+	c := newStatisticsConn(newTestConn(body), schema.UDPSecureScheme)
+	stats := c.statistics()
+	require.Equal(t, uint64(0), stats.BytesIn)
+	require.Equal(t, uint64(0), stats.BytesOut)
+	established := stats.LastActivity
+
+	ctx := context.Background()
+	time.Sleep(time.Millisecond)
+	_, err := c.Get(ctx, "/oic/d")
+	require.NoError(t, err)
+	stats = c.statistics()
+	// header 4 + token 8 + Uri-Path 1+3 + 1+1
+	require.Equal(t, uint64(18), stats.BytesOut)
+	// header 4 + token 8 + Content-Format 1+2 + payload marker 1 + payload 7
+	require.Equal(t, uint64(23), stats.BytesIn)
+	require.True(t, stats.LastActivity.After(established))
+
+	_, err = c.Post(ctx, "/oic/d", message.AppOcfCbor, bytes.NewReader(body))
+	require.NoError(t, err)
+	stats = c.statistics()
+	// + Content-Format 1+2 + payload marker 1 + payload 7
+	require.Equal(t, uint64(18+29), stats.BytesOut)
+	require.Equal(t, uint64(2*23), stats.BytesIn)
+
+	notifications := 0
+	_, err = c.Observe(ctx, "/oic/d", func(*pool.Message) { notifications++ })
+	require.NoError(t, err)
+	require.Equal(t, 1, notifications)
+	stats = c.statistics()
+	require.Equal(t, uint64(3*23), stats.BytesIn)
+
+	tcp := newStatisticsConn(newTestConn(body), schema.TCPSecureScheme)
+	_, err = tcp.Get(ctx, "/oic/d")
+	require.NoError(t, err)
+	// the TCP header is shorter than the UDP one
+	require.Less(t, tcp.statistics().BytesOut, uint64(18))
+}
+
+func TestDialerTracksConnections(t *testing.T) {
+	d := &dialer{
+		connections: coapSync.NewMap[*coap.ClientCloseHandler, *statisticsConn](),
+	}
+	h := coap.NewOnCloseHandler()
+	conn := newTestConn(nil)
+	c := d.newClientCloseHandler(conn, schema.UDPSecureScheme, h)
+	s := &Service{dialer: d}
+	_, ok := s.GetConnectionStatistics(c)
+	require.True(t, ok)
+
+	require.NoError(t, conn.Close())
+	h.OnClose(nil)
+	_, ok = s.GetConnectionStatistics(c)
+	require.False(t, ok)
+
+	// the closed connection is not tracked
+	c = d.newClientCloseHandler(conn, schema.UDPSecureScheme, coap.NewOnCloseHandler())
+	_, ok = s.GetConnectionStatistics(c)
+	require.False(t, ok)
+}
@@ -0,0 +1,108 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
+)
+
+func getRetryableError(err error) (configDevice.RetryableError, bool) {
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return configDevice.RetryableErrorConnectionRefused, true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return configDevice.RetryableErrorConnectionReset, true
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return configDevice.RetryableErrorUnreachable, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return configDevice.RetryableErrorTimeout, true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return configDevice.RetryableErrorTimeout, true
+	}
+	return "", false
+}
+
+// isRetryable returns true when the error is caused by the response code or the network error configured as retryable.
+func isRetryable(cfg configDevice.RetryConfig, err error) bool {
+	var coapErr coapStatus.Status
+	if errors.As(err, &coapErr) {
+		return cfg.IsRetryableCoapCode(coapErr.Code())
+	}
+	if v, ok := getRetryableError(err); ok {
+		return cfg.IsRetryableError(v)
+	}
+	return false
+}
+
+// getBackoff returns the delay after the failed attempt, the attempts are numbered from 1.
+func getBackoff(cfg configDevice.RetryConfig, attempt int) time.Duration {
+	backoff := cfg.InitialBackoff
+	for i := 1; i < attempt && backoff < cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > cfg.MaxBackoff {
+		backoff = cfg.MaxBackoff
+	}
+	if cfg.Jitter > 0 {
+		backoff += time.Duration((rand.Float64()*2 - 1) * cfg.Jitter * float64(backoff)) //nolint:gosec
+	}
+	return backoff
+}
+
+func doAttempt(ctx context.Context, cfg configDevice.RetryConfig, fn func(ctx context.Context) error) error {
+	if cfg.AttemptTimeout <= 0 {
+		return fn(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, cfg.AttemptTimeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// Retry calls fn until it succeeds, the error is not retryable, the attempts are exhausted or the context is done.
+func (s *Service) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	return retry(ctx, s.getConfig().COAP.Retry, s.logger.Debugf, fn)
+}
+
+func retry(ctx context.Context, cfg configDevice.RetryConfig, debugf func(fmt string, a ...any), fn func(ctx context.Context) error) error {
+	if !cfg.IsEnabled() {
+		return fn(ctx)
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = doAttempt(ctx, cfg, fn)
+		if err == nil || attempt >= cfg.MaxAttempts || ctx.Err() != nil || !isRetryable(cfg, err) {
+			return err
+		}
+		backoff := getBackoff(cfg, attempt)
+		debugf("attempt %v/%v failed, retrying in %v: %v", attempt, cfg.MaxAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
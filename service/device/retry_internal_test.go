@@ -0,0 +1,140 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryConfig(t *testing.T) configDevice.RetryConfig {
+	cfg := configDevice.DefaultConfig().COAP.Retry
+	cfg.MaxAttempts = 3
+	cfg.AttemptTimeout = time.Second
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = time.Millisecond * 4
+	cfg.Jitter = 0
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func newCoapError(code codes.Code) error {
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(code)
+	return fmt.Errorf("cannot get resource: %w", coapStatus.Errorf(msg, "unexpected code %v", code))
+}
+
+func debugf(string, ...any) {
+	// ignore
+}
+
+func TestRetry(t *testing.T) {
+	cfg := newRetryConfig(t)
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "retryableCoapCode", err: newCoapError(codes.ServiceUnavailable), wantAttempts: cfg.MaxAttempts},
+		{name: "notRetryableCoapCode", err: newCoapError(codes.Forbidden), wantAttempts: 1},
+		{name: "connectionRefused", err: fmt.Errorf("cannot dial: %w", syscall.ECONNREFUSED), wantAttempts: cfg.MaxAttempts},
+		{name: "timeout", err: fmt.Errorf("cannot get resource: %w", context.DeadlineExceeded), wantAttempts: cfg.MaxAttempts},
+		{name: "other", err: errors.New("invalid response"), wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := retry(context.Background(), cfg, debugf, func(context.Context) error {
+				attempts++
+				return tt.err
+			})
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+
+	// succeeds after the failed attempt
+	attempts := 0
+	err := retry(context.Background(), cfg, debugf, func(context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return syscall.ECONNRESET
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+
+	// the first attempt times out
+	cfg.AttemptTimeout = time.Millisecond * 10
+	attempts = 0
+	err = retry(context.Background(), cfg, debugf, func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+
+	// disabled
+	attempts = 0
+	err = retry(context.Background(), configDevice.RetryConfig{}, debugf, func(context.Context) error {
+		attempts++
+		return syscall.ECONNRESET
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+
+	// the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	err = retry(ctx, cfg, debugf, func(context.Context) error {
+		attempts++
+		return syscall.ECONNRESET
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestGetBackoff(t *testing.T) {
+	cfg := newRetryConfig(t)
+	require.Equal(t, time.Millisecond, getBackoff(cfg, 1))
+	require.Equal(t, time.Millisecond*2, getBackoff(cfg, 2))
+	require.Equal(t, time.Millisecond*4, getBackoff(cfg, 3))
+	require.Equal(t, time.Millisecond*4, getBackoff(cfg, 10))
+
+	cfg.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := getBackoff(cfg, 1)
+		require.GreaterOrEqual(t, backoff, time.Millisecond/2)
+		require.LessOrEqual(t, backoff, time.Millisecond*3/2)
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/pion/dtls/v2"
 	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/capture"
 	configDevice "github.com/plgd-dev/client-application/service/config/device"
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/client/core/otm"
@@ -37,6 +38,7 @@ import (
 	"github.com/plgd-dev/device/v2/schema"
 	coapNet "github.com/plgd-dev/go-coap/v3/net"
 	"github.com/plgd-dev/go-coap/v3/options"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
 	"github.com/plgd-dev/go-coap/v3/tcp"
 	tcpClient "github.com/plgd-dev/go-coap/v3/tcp/client"
 	"github.com/plgd-dev/go-coap/v3/udp"
@@ -69,6 +71,7 @@ type Service struct {
 	udp6Listener         *coapNet.UDPConn
 	done                 chan struct{}
 	authenticationClient AuthenticationClient
+	dialer               *dialer
 }
 
 var closeHandlerKey = "close-handler"
@@ -80,12 +83,17 @@ func errClosingConnection(debugf func(fmt string, a ...any), scheme schema.Schem
 // New creates new GRPC service
 func New(ctx context.Context, getConfig func() configDevice.Config, logger log.Logger) (*Service, error) {
 	config := getConfig()
+	dialer := &dialer{
+		logger:      logger,
+		connections: coapSync.NewMap[*coap.ClientCloseHandler, *statisticsConn](),
+	}
+	tlsParameters := newTLSParameters(getConfig)
 	var authenticationClient AuthenticationClient
 	switch config.COAP.TLS.Authentication {
 	case configDevice.AuthenticationPreSharedKey:
-		authenticationClient = newAuthenticationPreSharedKey(getConfig)
+		authenticationClient = newAuthenticationPreSharedKey(getConfig, dialer, tlsParameters)
 	case configDevice.AuthenticationX509:
-		authenticationClient = newAuthenticationX509(config)
+		authenticationClient = newAuthenticationX509(config, dialer, tlsParameters)
 	case configDevice.AuthenticationUninitialized:
 		return nil, errors.New("device is not initialized")
 	}
@@ -119,6 +127,15 @@ func New(ctx context.Context, getConfig func() configDevice.Config, logger log.L
 		return nil, fmt.Errorf("failed to create UDP6 listener: %w", err)
 	}
 
+	if config.COAP.Capture.FilePath != "" {
+		dialer.recorder, err = capture.NewFileRecorder(config.COAP.Capture.FilePath)
+		if err != nil {
+			_ = udp4Listener.Close()
+			_ = udp6Listener.Close()
+			return nil, err
+		}
+	}
+
 	udp4server := udp.NewServer(opts...)
 	udp6server := udp.NewServer(opts...)
 
@@ -131,6 +148,7 @@ func New(ctx context.Context, getConfig func() configDevice.Config, logger log.L
 		udp6Listener:         udp6Listener,
 		done:                 make(chan struct{}),
 		authenticationClient: authenticationClient,
+		dialer:               dialer,
 	}, nil
 }
 
@@ -165,7 +183,7 @@ func (s *Service) DialDTLS(ctx context.Context, addr string, dtlsCfg *dtls.Confi
 }
 
 func (s *Service) DialOwnership(ctx context.Context, addr string, dtlsCfg *dtls.Config, opts ...udp.Option) (*coap.ClientCloseHandler, error) {
-	return coap.DialUDPSecure(ctx, addr, dtlsCfg, append(s.getDialUDPOptions(true), opts...)...)
+	return s.dialer.DialUDPSecure(ctx, addr, dtlsCfg, append(s.getDialUDPOptions(true), opts...)...)
 }
 
 type UDPClientConn struct {
@@ -207,7 +225,7 @@ func (s *Service) DialUDP(_ context.Context, addr string, _ ...udp.Option) (*coa
 		_ = cc.Close()
 		return nil, errors.New("failed to create client connection: close handler is not *coap.OnCloseHandler")
 	}
-	return coap.NewClientCloseHandler(&UDPClientConn{Conn: cc}, h), nil
+	return s.dialer.newClientCloseHandler(&UDPClientConn{Conn: cc}, schema.UDPScheme, h), nil
 }
 
 func (s *Service) getDialTCPOptions(secure bool) []tcp.Option {
@@ -229,13 +247,57 @@ func (s *Service) getDialTCPOptions(secure bool) []tcp.Option {
 }
 
 func (s *Service) DialTCP(ctx context.Context, addr string, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
-	return coap.DialTCP(ctx, addr, append(s.getDialTCPOptions(false), opts...)...)
+	return s.dialer.DialTCP(ctx, addr, append(s.getDialTCPOptions(false), opts...)...)
 }
 
 func (s *Service) DialTLS(ctx context.Context, addr string, tlsCfg *tls.Config, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
 	return s.authenticationClient.DialTLS(ctx, addr, tlsCfg, append(s.getDialTCPOptions(true), opts...)...)
 }
 
+// ValidateEndpointScheme returns an error when the endpoints of the scheme cannot be dialed by the configured authentication.
+func (s *Service) ValidateEndpointScheme(scheme schema.Scheme) error {
+	authentication := s.getConfig().COAP.TLS.Authentication
+	if scheme == schema.TCPSecureScheme && authentication == configDevice.AuthenticationPreSharedKey {
+		return fmt.Errorf("scheme %v is not supported by %v authentication", scheme, authentication)
+	}
+	return nil
+}
+
+// DialEndpoint dials the device by the transport of the scheme. The secure schemes use the identity of the client application and
+// because the device ID is not known before the connection is established, the certificate of the device is verified only by the certificate authorities.
+func (s *Service) DialEndpoint(ctx context.Context, scheme schema.Scheme, addr string) (*coap.ClientCloseHandler, error) {
+	if scheme == schema.TCPScheme {
+		return s.DialTCP(ctx, addr)
+	}
+	if scheme != schema.UDPSecureScheme && scheme != schema.TCPSecureScheme {
+		return nil, fmt.Errorf("unsupported scheme %v", scheme)
+	}
+	if err := s.ValidateEndpointScheme(scheme); err != nil {
+		return nil, err
+	}
+	cas, err := s.authenticationClient.GetCertificateAuthorities()
+	if err != nil {
+		return nil, err
+	}
+	rootCAs := x509.NewCertPool()
+	for _, ca := range cas {
+		rootCAs.AddCert(ca)
+	}
+	verifyPeerCertificate := coap.NewVerifyPeerCertificate(rootCAs, func(*x509.Certificate) error {
+		return nil
+	})
+	if scheme == schema.TCPSecureScheme {
+		return s.DialTLS(ctx, addr, &tls.Config{
+			InsecureSkipVerify:    true, //nolint:gosec
+			VerifyPeerCertificate: verifyPeerCertificate,
+		})
+	}
+	return s.DialDTLS(ctx, addr, &dtls.Config{
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPeerCertificate,
+	})
+}
+
 func (s *Service) DeviceLogger() core.Logger {
 	return s.logger.DTLSLoggerFactory().NewLogger("client-application/device")
 }
@@ -279,6 +341,18 @@ func (s *Service) getManufacturerCertificateClient() *manufacturer.Client {
 	return manufacturer.NewClient(config.COAP.OwnershipTransfer.Manufacturer.TLS.GetCertificate(), config.COAP.OwnershipTransfer.Manufacturer.TLS.GetCAPool(), manufacturer.WithDialDTLS(s.DialOwnership))
 }
 
+func (s *Service) GetDiscoveryConfig() configDevice.DiscoveryConfig {
+	return s.getConfig().Discovery
+}
+
+func (s *Service) GetEndpointSelectionStrategy() configDevice.EndpointSelectionStrategy {
+	return s.getConfig().COAP.EndpointSelection.Strategy
+}
+
+func (s *Service) GetResourceLinksCacheTTL() time.Duration {
+	return s.getConfig().COAP.ResourceLinksCache.TTL
+}
+
 func (s *Service) GetOwnOptions() ([]core.OwnOption, error) {
 	return s.authenticationClient.GetOwnOptions()
 }
@@ -346,7 +420,7 @@ func (s *Service) serveWithHandlingSignal() error {
 func (s *Service) Close() error {
 	s.authenticationClient.Reset()
 	close(s.done)
-	return nil
+	return s.dialer.Close()
 }
 
 func (s *Service) GetIdentityCSR(id string) ([]byte, error) {
@@ -0,0 +1,176 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pion/dtls/v2"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/go-coap/v3/options"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+)
+
+// sessionStore keeps the DTLS sessions for the resumption, the oldest session is removed when the store is full.
+type sessionStore struct {
+	mutex       sync.Mutex
+	maxSessions int
+	sessions    map[string]dtls.Session
+	keys        []string
+}
+
+func newSessionStore(maxSessions int) *sessionStore {
+	return &sessionStore{
+		maxSessions: maxSessions,
+		sessions:    make(map[string]dtls.Session),
+	}
+}
+
+func (s *sessionStore) Set(key []byte, session dtls.Session) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k := string(key)
+	if _, ok := s.sessions[k]; !ok {
+		for len(s.sessions) >= s.maxSessions && len(s.keys) > 0 {
+			delete(s.sessions, s.keys[0])
+			s.keys = s.keys[1:]
+		}
+		s.keys = append(s.keys, k)
+	}
+	s.sessions[k] = session
+	return nil
+}
+
+func (s *sessionStore) Get(key []byte) (dtls.Session, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sessions[string(key)], nil
+}
+
+func (s *sessionStore) Del(key []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k := string(key)
+	if _, ok := s.sessions[k]; !ok {
+		return nil
+	}
+	delete(s.sessions, k)
+	for i, v := range s.keys {
+		if v == k {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// tlsParameters applies the configured DTLS and TLS parameters to the connections authenticated by the identity of the client application.
+// The configuration is read by each dial.
+type tlsParameters struct {
+	getConfig func() configDevice.Config
+
+	mutex       sync.Mutex
+	maxSessions int
+	sessions    *sessionStore
+	tlsSessions tls.ClientSessionCache
+}
+
+func newTLSParameters(getConfig func() configDevice.Config) *tlsParameters {
+	return &tlsParameters{
+		getConfig: getConfig,
+	}
+}
+
+func (p *tlsParameters) getTLSConfig() configDevice.TLSConfig {
+	return p.getConfig().COAP.TLS
+}
+
+// getSessionStores returns the stores of the sessions, they are created again when the max number of the sessions is changed.
+func (p *tlsParameters) getSessionStores(cfg configDevice.SessionResumptionConfig) (*sessionStore, tls.ClientSessionCache) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	maxSessions := cfg.GetMaxSessions()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.sessions == nil || p.maxSessions != maxSessions {
+		p.maxSessions = maxSessions
+		p.sessions = newSessionStore(maxSessions)
+		p.tlsSessions = tls.NewLRUClientSessionCache(maxSessions)
+	}
+	return p.sessions, p.tlsSessions
+}
+
+func (p *tlsParameters) setupDTLS(ctx context.Context, dtlsCfg *dtls.Config) {
+	cfg := p.getTLSConfig()
+	if cipherSuites := cfg.GetCipherSuites(); len(cipherSuites) > 0 {
+		dtlsCfg.CipherSuites = cipherSuites
+	}
+	if curves := cfg.GetCurves(); len(curves) > 0 {
+		dtlsCfg.EllipticCurves = curves
+	}
+	if cfg.ConnectionID.Enabled {
+		if cfg.ConnectionID.Length == 0 {
+			dtlsCfg.ConnectionIDGenerator = dtls.OnlySendCIDGenerator()
+		} else {
+			dtlsCfg.ConnectionIDGenerator = dtls.RandomCIDGenerator(cfg.ConnectionID.Length)
+		}
+	}
+	if sessions, _ := p.getSessionStores(cfg.SessionResumption); sessions != nil {
+		dtlsCfg.SessionStore = sessions
+	}
+	if cfg.HandshakeTimeout > 0 {
+		dtlsCfg.ConnectContextMaker = func() (context.Context, func()) {
+			return context.WithTimeout(ctx, cfg.HandshakeTimeout)
+		}
+	}
+}
+
+func (p *tlsParameters) setupTLS(tlsCfg *tls.Config) {
+	cfg := p.getTLSConfig()
+	if cipherSuites := cfg.GetTLSCipherSuites(); len(cipherSuites) > 0 {
+		tlsCfg.CipherSuites = cipherSuites
+		// the cipher suites of TLS 1.3 are not configurable, so TLS 1.3 would ignore them
+		if tlsCfg.MaxVersion == 0 || tlsCfg.MaxVersion > tls.VersionTLS12 {
+			tlsCfg.MaxVersion = tls.VersionTLS12
+		}
+	}
+	if curves := cfg.GetTLSCurves(); len(curves) > 0 {
+		tlsCfg.CurvePreferences = curves
+	}
+	if _, tlsSessions := p.getSessionStores(cfg.SessionResumption); tlsSessions != nil {
+		tlsCfg.ClientSessionCache = tlsSessions
+	}
+}
+
+// getDialTCPOptions limits the establishing of the TLS connection by the handshake timeout.
+func (p *tlsParameters) getDialTCPOptions(ctx context.Context) []tcp.Option {
+	timeout := p.getTLSConfig().HandshakeTimeout
+	if timeout <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	return []tcp.Option{options.WithDialer(&net.Dialer{
+		Timeout: timeout,
+	})}
+}
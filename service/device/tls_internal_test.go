@@ -0,0 +1,121 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package device
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/pion/dtls/v2"
+	dtlsElliptic "github.com/pion/dtls/v2/pkg/crypto/elliptic"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStore(t *testing.T) {
+	s := newSessionStore(2)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, s.Set([]byte(key), dtls.Session{ID: []byte(key)}))
+	}
+	// the oldest session is removed
+	v, err := s.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Empty(t, v.ID)
+	v, err = s.Get([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("c"), v.ID)
+
+	require.NoError(t, s.Del([]byte("b")))
+	v, err = s.Get([]byte("b"))
+	require.NoError(t, err)
+	require.Empty(t, v.ID)
+	require.NoError(t, s.Set([]byte("d"), dtls.Session{ID: []byte("d")}))
+	v, err = s.Get([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("c"), v.ID)
+}
+
+func newTLSConfigGetter(cfg *configDevice.TLSConfig) func() configDevice.Config {
+	return func() configDevice.Config {
+		c := configDevice.DefaultConfig()
+		c.COAP.TLS = *cfg
+		return c
+	}
+}
+
+func TestTLSParameters(t *testing.T) {
+	cfg := configDevice.TLSConfig{
+		Authentication:    configDevice.AuthenticationX509,
+		CipherSuites:      []string{"TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+		Curves:            []string{"P-256"},
+		HandshakeTimeout:  time.Second,
+		ConnectionID:      configDevice.ConnectionIDConfig{Enabled: true, Length: 8},
+		SessionResumption: configDevice.SessionResumptionConfig{Enabled: true},
+	}
+	require.NoError(t, cfg.Validate())
+	p := newTLSParameters(newTLSConfigGetter(&cfg))
+
+	dtlsCfg := &dtls.Config{}
+	p.setupDTLS(context.Background(), dtlsCfg)
+	require.Equal(t, []dtls.CipherSuiteID{dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8, dtls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, dtlsCfg.CipherSuites)
+	require.Equal(t, []dtlsElliptic.Curve{dtlsElliptic.P256}, dtlsCfg.EllipticCurves)
+	require.Len(t, dtlsCfg.ConnectionIDGenerator(), 8)
+	require.NotNil(t, dtlsCfg.SessionStore)
+	ctx, cancel := dtlsCfg.ConnectContextMaker()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	// the CCM cipher suites are not supported by crypto/tls
+	tlsCfg := &tls.Config{}
+	p.setupTLS(tlsCfg)
+	require.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, tlsCfg.CipherSuites)
+	require.Equal(t, []tls.CurveID{tls.CurveP256}, tlsCfg.CurvePreferences)
+	require.NotNil(t, tlsCfg.ClientSessionCache)
+	// TLS 1.3 ignores the configured cipher suites
+	require.Equal(t, uint16(tls.VersionTLS12), tlsCfg.MaxVersion)
+	require.Len(t, p.getDialTCPOptions(context.Background()), 1)
+
+	// the changes of the configuration are applied by the next dial
+	sessions := dtlsCfg.SessionStore
+	cfg.HandshakeTimeout = 0
+	cfg.SessionResumption.MaxSessions = 8
+	dtlsCfg = &dtls.Config{}
+	p.setupDTLS(context.Background(), dtlsCfg)
+	require.Nil(t, dtlsCfg.ConnectContextMaker)
+	require.NotSame(t, sessions, dtlsCfg.SessionStore)
+	require.Empty(t, p.getDialTCPOptions(context.Background()))
+
+	// the defaults keep the configuration of the dialers
+	cfg = configDevice.DefaultConfig().COAP.TLS
+	require.NoError(t, cfg.Validate())
+	p = newTLSParameters(newTLSConfigGetter(&cfg))
+	dtlsCfg = &dtls.Config{CipherSuites: []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256}}
+	p.setupDTLS(context.Background(), dtlsCfg)
+	require.Equal(t, []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256}, dtlsCfg.CipherSuites)
+	require.Nil(t, dtlsCfg.ConnectionIDGenerator)
+	require.Nil(t, dtlsCfg.SessionStore)
+	require.Nil(t, dtlsCfg.ConnectContextMaker)
+	require.Empty(t, p.getDialTCPOptions(context.Background()))
+	tlsCfg = &tls.Config{}
+	p.setupTLS(tlsCfg)
+	require.Zero(t, tlsCfg.MaxVersion)
+	require.Nil(t, tlsCfg.ClientSessionCache)
+}
@@ -0,0 +1,110 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/config/owners"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkPrimaryOwner checks that the request is sent by the owner of the client application. The token is verified by the authentication interceptor.
+func (s *ClientApplicationServer) checkPrimaryOwner(ctx context.Context) error {
+	if !s.HasJWTAuthorizationEnabled() {
+		return nil
+	}
+	token, err := pkgGrpc.TokenFromMD(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "cannot get token: %v", err)
+	}
+	if _, err = s.checkPrimaryOwnerToken(ctx, token); err != nil {
+		return status.Errorf(status.Code(err), "only the owner can manage additional owners: %v", status.Convert(err).Message())
+	}
+	return nil
+}
+
+func toAdditionalOwnerProto(subject owners.Subject) *pb.AdditionalOwner {
+	return &pb.AdditionalOwner{
+		Claim: subject.Claim,
+		Value: subject.Value,
+	}
+}
+
+func (s *ClientApplicationServer) GetAdditionalOwners(context.Context, *pb.GetAdditionalOwnersRequest) (*pb.GetAdditionalOwnersResponse, error) {
+	subjects := s.GetConfig().AdditionalOwners.Subjects
+	additionalOwners := make([]*pb.AdditionalOwner, 0, len(subjects))
+	for _, subject := range subjects {
+		additionalOwners = append(additionalOwners, toAdditionalOwnerProto(subject))
+	}
+	return &pb.GetAdditionalOwnersResponse{
+		AdditionalOwners: additionalOwners,
+	}, nil
+}
+
+func (s *ClientApplicationServer) updateAdditionalOwners(ctx context.Context, update func(cfg *owners.Config) error) error {
+	if err := s.checkPrimaryOwner(ctx); err != nil {
+		return err
+	}
+	s.additionalOwnersMutex.Lock()
+	defer s.additionalOwnersMutex.Unlock()
+	cfg := s.GetConfig()
+	if err := update(&cfg.AdditionalOwners); err != nil {
+		return err
+	}
+	return s.StoreConfig(&cfg)
+}
+
+func (s *ClientApplicationServer) AddAdditionalOwner(ctx context.Context, req *pb.AddAdditionalOwnerRequest) (*pb.AddAdditionalOwnerResponse, error) {
+	subject := owners.Subject{
+		Claim: req.GetClaim(),
+		Value: req.GetValue(),
+	}
+	if err := subject.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid additional owner: %v", err)
+	}
+	err := s.updateAdditionalOwners(ctx, func(cfg *owners.Config) error {
+		if !cfg.Add(subject) {
+			return status.Errorf(codes.AlreadyExists, "additional owner %v=%v already exists", subject.Claim, subject.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddAdditionalOwnerResponse{}, nil
+}
+
+func (s *ClientApplicationServer) RemoveAdditionalOwner(ctx context.Context, req *pb.RemoveAdditionalOwnerRequest) (*pb.RemoveAdditionalOwnerResponse, error) {
+	subject := owners.Subject{
+		Claim: req.GetClaim(),
+		Value: req.GetValue(),
+	}
+	err := s.updateAdditionalOwners(ctx, func(cfg *owners.Config) error {
+		if !cfg.Remove(subject) {
+			return status.Errorf(codes.NotFound, "additional owner %v=%v not found", subject.Claim, subject.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RemoveAdditionalOwnerResponse{}, nil
+}
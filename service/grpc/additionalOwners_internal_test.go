@@ -0,0 +1,104 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/hub/v2/identity-store/events"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	plgdJwt "github.com/plgd-dev/hub/v2/pkg/security/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAdditionalOwners(t *testing.T) {
+	a := newTestAuthority(t)
+	defer a.Close()
+	a.rotate(t, "kid1")
+
+	cfg := config.DefaultConfig(t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg.SetConfigPath(configPath)
+	require.NoError(t, cfg.Validate())
+	s := &ClientApplicationServer{
+		config: atomic.NewPointer(&cfg),
+		logger: log.NewLogger(log.MakeDefaultConfig()),
+	}
+	owner := uuid.MustParse(events.OwnerToUUID(testOwner))
+	s.jwksCache.Store(NewJSONWebKeyCache(owner, a.getKeys(t)))
+	ctx := context.Background()
+
+	exp := time.Now().Add(time.Hour).Unix()
+	operatorToken := a.token(t, "kid1", jwt.MapClaims{"sub": "operator", "groups": []string{"operators"}, "exp": exp})
+	userToken := a.token(t, "kid1", jwt.MapClaims{"sub": "user", "exp": exp})
+	require.NoError(t, s.ParseWithClaims(ctx, a.token(t, "kid1", jwt.MapClaims{"sub": testOwner, "exp": exp}), plgdJwt.NewScopeClaims()))
+	err := s.ParseWithClaims(ctx, operatorToken, plgdJwt.NewScopeClaims())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = s.AddAdditionalOwner(ctx, &pb.AddAdditionalOwnerRequest{Claim: "groups", Value: "operators"})
+	require.NoError(t, err)
+	_, err = s.AddAdditionalOwner(ctx, &pb.AddAdditionalOwnerRequest{Claim: "groups", Value: "operators"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = s.AddAdditionalOwner(ctx, &pb.AddAdditionalOwnerRequest{Claim: "groups"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	resp, err := s.GetAdditionalOwners(ctx, &pb.GetAdditionalOwnersRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAdditionalOwners(), 1)
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var stored config.Config
+	require.NoError(t, yaml.Unmarshal(data, &stored))
+	require.Len(t, stored.AdditionalOwners.Subjects, 1)
+
+	require.NoError(t, s.ParseWithClaims(ctx, operatorToken, plgdJwt.NewScopeClaims()))
+	err = s.ParseWithClaims(ctx, userToken, plgdJwt.NewScopeClaims())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the additional owner cannot replace the keys of the owner
+	data, err = json.Marshal(a.getKeys(t))
+	require.NoError(t, err)
+	var keys structpb.Struct
+	require.NoError(t, keys.UnmarshalJSON(data))
+	operatorCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "bearer "+operatorToken))
+	err = s.UpdateJSONWebKeys(operatorCtx, &keys)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	ownerCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "bearer "+a.token(t, "kid1", jwt.MapClaims{"sub": testOwner, "exp": exp})))
+	require.NoError(t, s.UpdateJSONWebKeys(ownerCtx, &keys))
+	require.Equal(t, owner, s.jwksCache.Load().owner)
+
+	_, err = s.RemoveAdditionalOwner(ctx, &pb.RemoveAdditionalOwnerRequest{Claim: "groups", Value: "operators"})
+	require.NoError(t, err)
+	_, err = s.RemoveAdditionalOwner(ctx, &pb.RemoveAdditionalOwnerRequest{Claim: "groups", Value: "operators"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	err = s.ParseWithClaims(ctx, operatorToken, plgdJwt.NewScopeClaims())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,501 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/go-coap/v3/message"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// manifestStep is a step of the plan with the function which applies it.
+type manifestStep struct {
+	*pb.ManifestStep
+	apply func(ctx context.Context) error
+}
+
+func newManifestStep(deviceID uuid.UUID, action pb.ManifestStep_Action, href, msg string, apply func(ctx context.Context) error) manifestStep {
+	return manifestStep{
+		ManifestStep: &pb.ManifestStep{
+			DeviceId: deviceID.String(),
+			Action:   action,
+			Href:     href,
+			Status:   pb.ManifestStep_PLANNED,
+			Message:  msg,
+		},
+		apply: apply,
+	}
+}
+
+func newFailedManifestStep(deviceID uuid.UUID, action pb.ManifestStep_Action, href string, err error) manifestStep {
+	step := newManifestStep(deviceID, action, href, err.Error(), nil)
+	step.Status = pb.ManifestStep_FAILED
+	return step
+}
+
+var aclPermissions = map[pb.AccessControlManifest_Permission]acl.Permission{
+	pb.AccessControlManifest_CREATE: acl.Permission_CREATE,
+	pb.AccessControlManifest_READ:   acl.Permission_READ,
+	pb.AccessControlManifest_WRITE:  acl.Permission_WRITE,
+	pb.AccessControlManifest_DELETE: acl.Permission_DELETE,
+	pb.AccessControlManifest_NOTIFY: acl.Permission_NOTIFY,
+}
+
+func validateAccessControlManifest(ac *pb.AccessControlManifest) error {
+	switch {
+	case ac.GetSubjectDeviceId() != "" && ac.GetSubjectConnectionType() != "":
+		return errors.New("only one of subjectDeviceId, subjectConnectionType can be set")
+	case ac.GetSubjectDeviceId() != "":
+		if _, err := uuid.Parse(ac.GetSubjectDeviceId()); err != nil {
+			return fmt.Errorf("invalid subjectDeviceId: %w", err)
+		}
+	case ac.GetSubjectConnectionType() != "":
+		switch acl.ConnectionType(ac.GetSubjectConnectionType()) {
+		case acl.ConnectionType_AUTH_CRYPT, acl.ConnectionType_ANON_CLEAR:
+		default:
+			return fmt.Errorf("invalid subjectConnectionType('%v'): supports only '%v,%v'", ac.GetSubjectConnectionType(), acl.ConnectionType_AUTH_CRYPT, acl.ConnectionType_ANON_CLEAR)
+		}
+	default:
+		return errors.New("subjectDeviceId or subjectConnectionType must be set")
+	}
+	if len(ac.GetHrefs()) == 0 {
+		return errors.New("hrefs are empty")
+	}
+	if len(ac.GetPermissions()) == 0 {
+		return errors.New("permissions are empty")
+	}
+	return nil
+}
+
+func validateOnboardManifest(o *pb.OnboardManifest) error {
+	switch {
+	case o.GetCoapGatewayAddress() == "":
+		return errors.New("invalid coapGatewayAddress")
+	case o.GetAuthorizationProviderName() == "":
+		return errors.New("invalid authorizationProviderName")
+	case o.GetAuthorizationCode() == "":
+		return errors.New("invalid authorizationCode")
+	case o.GetHubId() == "":
+		return errors.New("invalid hubId")
+	}
+	return nil
+}
+
+func validateDeviceManifest(m *pb.DeviceManifest) error {
+	if (m.GetDeviceId() == "") == (len(m.GetTypeFilter()) == 0) {
+		return errors.New("exactly one of deviceId, typeFilter must be set")
+	}
+	if m.GetDeviceId() != "" {
+		if _, err := uuid.Parse(m.GetDeviceId()); err != nil {
+			return fmt.Errorf("invalid deviceId: %w", err)
+		}
+	}
+	if !m.GetOwned() && (len(m.GetResources()) > 0 || len(m.GetAccessControls()) > 0 || m.GetOnboard() != nil) {
+		return errors.New("owned must be set when resources, accessControls or onboard are set")
+	}
+	for i, r := range m.GetResources() {
+		if r.GetHref() == "" {
+			return fmt.Errorf("resources[%v]: href is empty", i)
+		}
+		if r.GetContent() == nil {
+			return fmt.Errorf("resources[%v]: content is not set", i)
+		}
+	}
+	for i, ac := range m.GetAccessControls() {
+		if err := validateAccessControlManifest(ac); err != nil {
+			return fmt.Errorf("accessControls[%v]: %w", i, err)
+		}
+	}
+	if m.GetOnboard() != nil {
+		if err := validateOnboardManifest(m.GetOnboard()); err != nil {
+			return fmt.Errorf("onboard: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *ClientApplicationServer) selectManifestDevices(m *pb.DeviceManifest) ([]*device, error) {
+	if m.GetDeviceId() != "" {
+		devID, err := strDeviceID2UUID(m.GetDeviceId())
+		if err != nil {
+			return nil, err
+		}
+		dev, err := s.getDevice(devID)
+		if err != nil {
+			return nil, err
+		}
+		return []*device{dev}, nil
+	}
+	var devs devices
+	s.devices.Range(func(_ uuid.UUID, dev *device) bool {
+		if filterByType(dev.ToProto(), m.GetTypeFilter()) {
+			devs = append(devs, dev)
+		}
+		return true
+	})
+	devs.Sort()
+	return devs, nil
+}
+
+func decodeContent(content *commands.Content) (interface{}, error) {
+	if len(content.GetData()) == 0 {
+		return nil, nil
+	}
+	data, err := cbor.ToJSON(content.GetData())
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode content: %w", err)
+	}
+	var v interface{}
+	if err = json.Unmarshal([]byte(data), &v); err != nil {
+		return nil, fmt.Errorf("cannot decode content: %w", err)
+	}
+	return v, nil
+}
+
+// containsValue returns true when all properties of the desired value are equal in the live value.
+func containsValue(live, desired interface{}) bool {
+	d, ok := desired.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(live, desired)
+	}
+	l, ok := live.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, v := range d {
+		lv, ok := l[k]
+		if !ok || !containsValue(lv, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ClientApplicationServer) planResourceManifest(ctx context.Context, dev *device, r *pb.ResourceManifest) manifestStep {
+	resourceID := commands.NewResourceID(dev.ID.String(), r.GetHref())
+	res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId:        resourceID,
+		ResourceInterface: r.GetResourceInterface(),
+	})
+	if err != nil {
+		return newFailedManifestStep(dev.ID, pb.ManifestStep_UPDATE_RESOURCE, r.GetHref(), err)
+	}
+	live, err := decodeContent(res.GetData().GetContent())
+	if err != nil {
+		return newFailedManifestStep(dev.ID, pb.ManifestStep_UPDATE_RESOURCE, r.GetHref(), err)
+	}
+	if containsValue(live, r.GetContent().AsInterface()) {
+		return manifestStep{}
+	}
+	return newManifestStep(dev.ID, pb.ManifestStep_UPDATE_RESOURCE, r.GetHref(), "resource content differs", func(ctx context.Context) error {
+		data, err := protojson.Marshal(r.GetContent())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "cannot encode content: %v", err)
+		}
+		_, err = s.UpdateResource(ctx, &pb.UpdateResourceRequest{
+			ResourceId:        resourceID,
+			ResourceInterface: r.GetResourceInterface(),
+			Content: &grpcgwPb.Content{
+				ContentType: message.AppJSON.String(),
+				Data:        data,
+			},
+		})
+		return err
+	})
+}
+
+func toAccessControl(ac *pb.AccessControlManifest) acl.AccessControl {
+	var subject acl.Subject
+	if ac.GetSubjectDeviceId() != "" {
+		subject.Subject_Device = &acl.Subject_Device{DeviceID: ac.GetSubjectDeviceId()}
+	} else {
+		subject.Subject_Connection = &acl.Subject_Connection{Type: acl.ConnectionType(ac.GetSubjectConnectionType())}
+	}
+	var permission acl.Permission
+	for _, p := range ac.GetPermissions() {
+		permission |= aclPermissions[p]
+	}
+	resources := make([]acl.Resource, 0, len(ac.GetHrefs()))
+	for _, href := range ac.GetHrefs() {
+		switch wc := acl.ResourceWildcard(href); wc {
+		case acl.ResourceWildcard_NONCFG_ALL, acl.ResourceWildcard_NONCFG_SEC_ENDPOINT, acl.ResourceWildcard_NONCFG_NONSEC_ENDPOINT:
+			resources = append(resources, acl.Resource{Wildcard: wc, Interfaces: []string{"*"}})
+		default:
+			resources = append(resources, acl.Resource{Href: normalizeHref(href), Interfaces: []string{"*"}})
+		}
+	}
+	return acl.AccessControl{
+		Permission: permission,
+		Resources:  resources,
+		Subject:    subject,
+	}
+}
+
+func sameSubject(a, b acl.Subject) bool {
+	switch {
+	case a.Subject_Device != nil && b.Subject_Device != nil:
+		return a.Subject_Device.DeviceID == b.Subject_Device.DeviceID
+	case a.Subject_Connection != nil && b.Subject_Connection != nil:
+		return a.Subject_Connection.Type == b.Subject_Connection.Type
+	}
+	return false
+}
+
+func containsResource(resources []acl.Resource, r acl.Resource) bool {
+	for _, res := range resources {
+		if res.Href == r.Href && res.Wildcard == r.Wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAccessControl returns true when some live entry grants at least the desired permissions to the desired resources.
+func hasAccessControl(live []acl.AccessControl, desired acl.AccessControl) bool {
+	for _, l := range live {
+		if !sameSubject(l.Subject, desired.Subject) || l.Permission&desired.Permission != desired.Permission {
+			continue
+		}
+		covered := true
+		for _, r := range desired.Resources {
+			if !containsResource(l.Resources, r) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ClientApplicationServer) planAccessControlsManifest(ctx context.Context, dev *device, acs []*pb.AccessControlManifest) manifestStep {
+	res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId: commands.NewResourceID(dev.ID.String(), acl.ResourceURI),
+	})
+	if err != nil {
+		return newFailedManifestStep(dev.ID, pb.ManifestStep_UPDATE_ACCESS_CONTROLS, acl.ResourceURI, err)
+	}
+	var live acl.Response
+	if err = cbor.Decode(res.GetData().GetContent().GetData(), &live); err != nil {
+		return newFailedManifestStep(dev.ID, pb.ManifestStep_UPDATE_ACCESS_CONTROLS, acl.ResourceURI, fmt.Errorf("cannot decode access controls: %w", err))
+	}
+	missing := make([]acl.AccessControl, 0, len(acs))
+	for _, ac := range acs {
+		desired := toAccessControl(ac)
+		if !hasAccessControl(live.AccessControlList, desired) {
+			missing = append(missing, desired)
+		}
+	}
+	if len(missing) == 0 {
+		return manifestStep{}
+	}
+	return newManifestStep(dev.ID, pb.ManifestStep_UPDATE_ACCESS_CONTROLS, acl.ResourceURI, fmt.Sprintf("%v access controls are missing", len(missing)), func(ctx context.Context) error {
+		links, err := dev.getOrderedResourceLinks(ctx, false)
+		if err != nil {
+			return err
+		}
+		link, err := core.GetResourceLink(links, acl.ResourceURI)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "cannot find resource link %v for device %v", acl.ResourceURI, dev.ID)
+		}
+		err = dev.provision(ctx, links, func(ctx context.Context, pc *core.ProvisioningClient) error {
+			return pc.UpdateResource(ctx, link, acl.UpdateRequest{AccessControlList: missing}, nil)
+		})
+		if err != nil {
+			return convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot update access controls for device %v: %w", dev.ID, err), dev.newDeviceRequest(link.Href)).Err()
+		}
+		return nil
+	})
+}
+
+func (s *ClientApplicationServer) planOnboardManifest(ctx context.Context, dev *device, o *pb.OnboardManifest) []manifestStep {
+	live, err := s.getCloudConfiguration(ctx, dev.ID)
+	if err != nil {
+		return []manifestStep{newFailedManifestStep(dev.ID, pb.ManifestStep_ONBOARD, "", err)}
+	}
+	if live.URL == o.GetCoapGatewayAddress() && live.CloudID == o.GetHubId() && live.ProvisioningStatus != cloud.ProvisioningStatus_FAILED {
+		return nil
+	}
+	steps := make([]manifestStep, 0, 2)
+	if live.URL != "" {
+		steps = append(steps, newManifestStep(dev.ID, pb.ManifestStep_OFFBOARD, "", fmt.Sprintf("device is onboarded to %v with provisioning status %v", live.URL, live.ProvisioningStatus), func(ctx context.Context) error {
+			_, err := s.OffboardDevice(ctx, &pb.OffboardDeviceRequest{DeviceId: dev.ID.String()})
+			return err
+		}))
+	}
+	return append(steps, newManifestStep(dev.ID, pb.ManifestStep_ONBOARD, "", "device is not onboarded to "+o.GetCoapGatewayAddress(), func(ctx context.Context) error {
+		_, err := s.OnboardDevice(ctx, &pb.OnboardDeviceRequest{
+			DeviceId:                  dev.ID.String(),
+			CoapGatewayAddress:        o.GetCoapGatewayAddress(),
+			AuthorizationCode:         o.GetAuthorizationCode(),
+			AuthorizationProviderName: o.GetAuthorizationProviderName(),
+			HubId:                     o.GetHubId(),
+			CertificateAuthorities:    o.GetCertificateAuthorities(),
+		})
+		return err
+	}))
+}
+
+// planOwnedDevice compares the live state of the owned device with the manifest.
+func (s *ClientApplicationServer) planOwnedDevice(ctx context.Context, dev *device, m *pb.DeviceManifest) []manifestStep {
+	steps := make([]manifestStep, 0, len(m.GetResources())+3)
+	for _, r := range m.GetResources() {
+		if step := s.planResourceManifest(ctx, dev, r); step.ManifestStep != nil {
+			steps = append(steps, step)
+		}
+	}
+	if len(m.GetAccessControls()) > 0 {
+		if step := s.planAccessControlsManifest(ctx, dev, m.GetAccessControls()); step.ManifestStep != nil {
+			steps = append(steps, step)
+		}
+	}
+	if m.GetOnboard() != nil {
+		steps = append(steps, s.planOnboardManifest(ctx, dev, m.GetOnboard())...)
+	}
+	return steps
+}
+
+// planUnownedDevice returns steps which are applied after the device is owned. The live state is read again after the device is owned.
+func planUnownedDevice(dev *device, m *pb.DeviceManifest) []manifestStep {
+	const msg = "device is not owned"
+	steps := make([]manifestStep, 0, len(m.GetResources())+2)
+	for _, r := range m.GetResources() {
+		steps = append(steps, newManifestStep(dev.ID, pb.ManifestStep_UPDATE_RESOURCE, r.GetHref(), msg, nil))
+	}
+	if len(m.GetAccessControls()) > 0 {
+		steps = append(steps, newManifestStep(dev.ID, pb.ManifestStep_UPDATE_ACCESS_CONTROLS, acl.ResourceURI, msg, nil))
+	}
+	if m.GetOnboard() != nil {
+		steps = append(steps, newManifestStep(dev.ID, pb.ManifestStep_ONBOARD, "", msg, nil))
+	}
+	return steps
+}
+
+func (s *ClientApplicationServer) planDeviceManifest(ctx context.Context, dev *device, m *pb.DeviceManifest) []manifestStep {
+	_, err := dev.getResourceLinksAndRefreshCache(ctx)
+	if err != nil {
+		action := pb.ManifestStep_OWN
+		if !m.GetOwned() {
+			action = pb.ManifestStep_DISOWN
+		}
+		return []manifestStep{newFailedManifestStep(dev.ID, action, "", err)}
+	}
+	ownershipStatus := dev.ToProto().GetOwnershipStatus()
+	switch {
+	case ownershipStatus == grpcgwPb.Device_UNSUPPORTED:
+		if !m.GetOwned() {
+			return nil
+		}
+		return []manifestStep{newFailedManifestStep(dev.ID, pb.ManifestStep_OWN, "", errors.New("device doesn't support ownership"))}
+	case !m.GetOwned() && ownershipStatus == grpcgwPb.Device_OWNED:
+		return []manifestStep{newManifestStep(dev.ID, pb.ManifestStep_DISOWN, "", "device is owned", func(ctx context.Context) error {
+			_, err := s.DisownDevice(ctx, &pb.DisownDeviceRequest{DeviceId: dev.ID.String()})
+			return err
+		})}
+	case !m.GetOwned():
+		return nil
+	case ownershipStatus == grpcgwPb.Device_OWNED:
+		return s.planOwnedDevice(ctx, dev, m)
+	}
+	if s.signIdentityCertificateRemotely() {
+		return []manifestStep{newFailedManifestStep(dev.ID, pb.ManifestStep_OWN, "", errors.New("own device mediated by user agent is not supported by manifest"))}
+	}
+	own := newManifestStep(dev.ID, pb.ManifestStep_OWN, "", "device is not owned", func(ctx context.Context) error {
+		_, err := s.OwnDevice(ctx, &pb.OwnDeviceRequest{DeviceId: dev.ID.String()})
+		return err
+	})
+	return append([]manifestStep{own}, planUnownedDevice(dev, m)...)
+}
+
+func skipManifestSteps(steps []manifestStep) {
+	for _, step := range steps {
+		step.Status = pb.ManifestStep_SKIPPED
+	}
+}
+
+func (s *ClientApplicationServer) applyDeviceManifest(ctx context.Context, dev *device, m *pb.DeviceManifest, dryRun bool) []*pb.ManifestStep {
+	steps := s.planDeviceManifest(ctx, dev, m)
+	result := make([]*pb.ManifestStep, 0, len(steps))
+	apply := !dryRun
+	for i := 0; i < len(steps); i++ {
+		step := steps[i]
+		result = append(result, step.ManifestStep)
+		if !apply {
+			continue
+		}
+		if step.Status != pb.ManifestStep_FAILED {
+			start := time.Now()
+			err := step.apply(ctx)
+			// the steps call the handlers directly, so they are not recorded by the audit interceptor
+			s.writeAuditRecord(ctx, "ApplyManifest/"+step.Action.String(), step.DeviceId, step.Href, start, err)
+			if err != nil {
+				step.Status = pb.ManifestStep_FAILED
+				step.Message = err.Error()
+			}
+		}
+		if step.Status == pb.ManifestStep_FAILED {
+			skipManifestSteps(steps[i+1:])
+			apply = false
+			continue
+		}
+		step.Status = pb.ManifestStep_APPLIED
+		if step.Action == pb.ManifestStep_OWN {
+			// the live state of the owned device is available now
+			steps = append(steps[:i+1], s.planOwnedDevice(ctx, dev, m)...)
+		}
+	}
+	return result
+}
+
+func (s *ClientApplicationServer) ApplyManifest(ctx context.Context, req *pb.ApplyManifestRequest) (*pb.ApplyManifestResponse, error) {
+	selected := make([]devices, 0, len(req.GetDevices()))
+	for i, m := range req.GetDevices() {
+		if err := validateDeviceManifest(m); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid devices[%v]: %v", i, err)
+		}
+		devs, err := s.selectManifestDevices(m)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, devs)
+	}
+	resp := &pb.ApplyManifestResponse{}
+	for i, m := range req.GetDevices() {
+		for _, dev := range selected[i] {
+			resp.Steps = append(resp.Steps, s.applyDeviceManifest(ctx, dev, m, req.GetDryRun())...)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,173 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestContainsValue(t *testing.T) {
+	live := map[string]interface{}{
+		"state": true,
+		"power": float64(42),
+		"nested": map[string]interface{}{
+			"a": "b",
+			"c": []interface{}{float64(1), float64(2)},
+		},
+	}
+	require.True(t, containsValue(live, map[string]interface{}{"power": float64(42)}))
+	require.True(t, containsValue(live, map[string]interface{}{"nested": map[string]interface{}{"a": "b"}}))
+	require.True(t, containsValue(live, map[string]interface{}{"nested": map[string]interface{}{"c": []interface{}{float64(1), float64(2)}}}))
+	require.False(t, containsValue(live, map[string]interface{}{"power": float64(1)}))
+	require.False(t, containsValue(live, map[string]interface{}{"unknown": true}))
+	require.False(t, containsValue(live, map[string]interface{}{"nested": map[string]interface{}{"c": []interface{}{float64(1)}}}))
+	require.False(t, containsValue(nil, map[string]interface{}{"power": float64(42)}))
+}
+
+func TestHasAccessControl(t *testing.T) {
+	live := []acl.AccessControl{
+		{
+			Permission: acl.Permission_READ | acl.Permission_WRITE,
+			Subject:    acl.TLSConnection,
+			Resources:  []acl.Resource{{Href: "/light/1"}, {Href: "/light/2"}},
+		},
+		{
+			Permission: acl.AllPermissions,
+			Subject:    acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: "00000000-0000-0000-0000-000000000001"}},
+			Resources:  acl.AllResources,
+		},
+	}
+	tests := []struct {
+		name string
+		ac   *pb.AccessControlManifest
+		want bool
+	}{
+		{
+			name: "covered",
+			ac: &pb.AccessControlManifest{
+				SubjectConnectionType: string(acl.ConnectionType_AUTH_CRYPT),
+				Hrefs:                 []string{"light/1"},
+				Permissions:           []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ},
+			},
+			want: true,
+		},
+		{
+			name: "missing permission",
+			ac: &pb.AccessControlManifest{
+				SubjectConnectionType: string(acl.ConnectionType_AUTH_CRYPT),
+				Hrefs:                 []string{"/light/1"},
+				Permissions:           []pb.AccessControlManifest_Permission{pb.AccessControlManifest_DELETE},
+			},
+		},
+		{
+			name: "missing href",
+			ac: &pb.AccessControlManifest{
+				SubjectConnectionType: string(acl.ConnectionType_AUTH_CRYPT),
+				Hrefs:                 []string{"/light/1", "/light/3"},
+				Permissions:           []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ},
+			},
+		},
+		{
+			name: "wildcard",
+			ac: &pb.AccessControlManifest{
+				SubjectDeviceId: "00000000-0000-0000-0000-000000000001",
+				Hrefs:           []string{"*"},
+				Permissions:     []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ, pb.AccessControlManifest_NOTIFY},
+			},
+			want: true,
+		},
+		{
+			name: "other subject",
+			ac: &pb.AccessControlManifest{
+				SubjectConnectionType: string(acl.ConnectionType_ANON_CLEAR),
+				Hrefs:                 []string{"/light/1"},
+				Permissions:           []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, validateAccessControlManifest(tt.ac))
+			require.Equal(t, tt.want, hasAccessControl(live, toAccessControl(tt.ac)))
+		})
+	}
+}
+
+func TestValidateDeviceManifest(t *testing.T) {
+	const deviceID = "00000000-0000-0000-0000-000000000001"
+	content, err := structpb.NewValue(map[string]interface{}{"power": 1})
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		m       *pb.DeviceManifest
+		wantErr bool
+	}{
+		{
+			name: "owned",
+			m:    &pb.DeviceManifest{DeviceId: deviceID, Owned: true, Resources: []*pb.ResourceManifest{{Href: "/light/1", Content: content}}},
+		},
+		{
+			name: "type filter",
+			m:    &pb.DeviceManifest{TypeFilter: []string{"oic.d.light"}},
+		},
+		{
+			name:    "no selector",
+			m:       &pb.DeviceManifest{Owned: true},
+			wantErr: true,
+		},
+		{
+			name:    "both selectors",
+			m:       &pb.DeviceManifest{DeviceId: deviceID, TypeFilter: []string{"oic.d.light"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid deviceId",
+			m:       &pb.DeviceManifest{DeviceId: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "resources without owned",
+			m:       &pb.DeviceManifest{DeviceId: deviceID, Resources: []*pb.ResourceManifest{{Href: "/light/1", Content: content}}},
+			wantErr: true,
+		},
+		{
+			name:    "resource without content",
+			m:       &pb.DeviceManifest{DeviceId: deviceID, Owned: true, Resources: []*pb.ResourceManifest{{Href: "/light/1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid onboard",
+			m:       &pb.DeviceManifest{DeviceId: deviceID, Owned: true, Onboard: &pb.OnboardManifest{CoapGatewayAddress: "coaps+tcp://localhost:5684"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDeviceManifest(tt.m)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
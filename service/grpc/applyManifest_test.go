@@ -0,0 +1,92 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func getManifestStepActions(steps []*pb.ManifestStep, wantStatus pb.ManifestStep_Status) []pb.ManifestStep_Action {
+	actions := make([]pb.ManifestStep_Action, 0, len(steps))
+	for _, step := range steps {
+		if step.GetStatus() == wantStatus {
+			actions = append(actions, step.GetAction())
+		}
+	}
+	return actions
+}
+
+func TestClientApplicationServerApplyManifest(t *testing.T) {
+	dev := test.MustFindDeviceByName(test.DevsimName, []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV4})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*16)
+	defer cancel()
+
+	s, teardown, err := test.NewClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	err = s.GetDevices(&pb.GetDevicesRequest{}, test.NewClientApplicationGetDevicesServer(ctx))
+	require.NoError(t, err)
+
+	_, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{
+		Devices: []*pb.DeviceManifest{{DeviceId: dev.GetId(), TypeFilter: []string{"oic.wk.d"}}},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	content, err := structpb.NewValue(map[string]interface{}{"power": 42})
+	require.NoError(t, err)
+	manifest := &pb.DeviceManifest{
+		DeviceId: dev.GetId(),
+		Owned:    true,
+		Resources: []*pb.ResourceManifest{
+			{Href: "/light/1", Content: content},
+		},
+		AccessControls: []*pb.AccessControlManifest{
+			{
+				SubjectConnectionType: "anon-clear",
+				Hrefs:                 []string{"/light/1"},
+				Permissions:           []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ},
+			},
+		},
+	}
+
+	resp, err := s.ApplyManifest(ctx, &pb.ApplyManifestRequest{Devices: []*pb.DeviceManifest{manifest}, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, []pb.ManifestStep_Action{pb.ManifestStep_OWN, pb.ManifestStep_UPDATE_RESOURCE, pb.ManifestStep_UPDATE_ACCESS_CONTROLS}, getManifestStepActions(resp.GetSteps(), pb.ManifestStep_PLANNED))
+
+	resp, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{Devices: []*pb.DeviceManifest{manifest}})
+	require.NoError(t, err)
+	require.Equal(t, []pb.ManifestStep_Action{pb.ManifestStep_OWN, pb.ManifestStep_UPDATE_RESOURCE, pb.ManifestStep_UPDATE_ACCESS_CONTROLS}, getManifestStepActions(resp.GetSteps(), pb.ManifestStep_APPLIED))
+
+	// the state is applied, so nothing is planned
+	resp, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{Devices: []*pb.DeviceManifest{manifest}, DryRun: true})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetSteps())
+
+	resp, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{Devices: []*pb.DeviceManifest{{DeviceId: dev.GetId()}}})
+	require.NoError(t, err)
+	require.Equal(t, []pb.ManifestStep_Action{pb.ManifestStep_DISOWN}, getManifestStepActions(resp.GetSteps(), pb.ManifestStep_APPLIED))
+}
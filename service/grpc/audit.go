@@ -0,0 +1,205 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/audit"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// auditedMethods are the state-changing RPCs which are recorded to the audit log.
+var auditedMethods = map[string]struct{}{
+	"OwnDevice":             {},
+	"FinishOwnDevice":       {},
+	"DisownDevice":          {},
+	"OnboardDevice":         {},
+	"OffboardDevice":        {},
+	"UpdateResource":        {},
+	"CreateResource":        {},
+	"DeleteResource":        {},
+	"Initialize":            {},
+	"Reset":                 {},
+	"ClearCache":            {},
+	"CloseConnections":      {},
+	"AddAdditionalOwner":    {},
+	"RemoveAdditionalOwner": {},
+	"FinishInitialize":      {},
+	"ApplyManifest":         {},
+	"ImportInventory":       {},
+	"SetDeviceMetadata":     {},
+	// UpdateJSONWebKeys is not an RPC, it writes its own record.
+}
+
+const (
+	// forwardedForKey is set by the grpc-gateway, it appends the address of the HTTP client to the value sent by the client.
+	forwardedForKey = "x-forwarded-for"
+	// gatewayNetwork is the network of the peer of the in-process channel used by the grpc-gateway.
+	gatewayNetwork = "inproc"
+)
+
+func (s *ClientApplicationServer) getAuditOwner(ctx context.Context, ownerClaim string) string {
+	if s.HasAPIKeyAuthenticationEnabled() {
+		if key, ok := s.getAPIKey(ctx); ok {
+			return "apiKey:" + key.Name
+		}
+		return ""
+	}
+	if owner, err := pkgGrpc.OwnerFromTokenMD(ctx, ownerClaim); err == nil && owner != "" {
+		return owner
+	}
+	if subject, err := pkgGrpc.SubjectFromTokenMD(ctx); err == nil {
+		return subject
+	}
+	return ""
+}
+
+func isGatewayPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	return ok && p.Addr != nil && p.Addr.Network() == gatewayNetwork
+}
+
+// getAuditForwardedFor returns the last hop of the x-forwarded-for metadata of the requests of the grpc-gateway, the previous
+// hops are set by the HTTP client and cannot be trusted. The metadata of the direct gRPC requests are set by the client, so they are ignored.
+func getAuditForwardedFor(ctx context.Context) string {
+	if !isGatewayPeer(ctx) {
+		return ""
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	v := md.Get(forwardedForKey)
+	if len(v) == 0 {
+		return ""
+	}
+	hops := strings.Split(v[len(v)-1], ",")
+	return strings.TrimSpace(hops[len(hops)-1])
+}
+
+func getAuditRemoteAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func getAuditTarget(req interface{}) (string, string) {
+	if r, ok := req.(interface{ GetResourceId() *commands.ResourceId }); ok {
+		return r.GetResourceId().GetDeviceId(), r.GetResourceId().GetHref()
+	}
+	if r, ok := req.(interface{ GetDeviceId() string }); ok {
+		return r.GetDeviceId(), ""
+	}
+	return "", ""
+}
+
+// writeAuditRecord records the operation to the audit log, it does nothing when the audit log is disabled.
+func (s *ClientApplicationServer) writeAuditRecord(ctx context.Context, method, deviceID, href string, start time.Time, err error) {
+	if s.auditLog == nil {
+		return
+	}
+	r := audit.Record{
+		Time:          start,
+		Method:        method,
+		Owner:         s.getAuditOwner(ctx, s.GetConfig().RemoteProvisioning.GetJwtOwnerClaim()),
+		RemoteAddress: getAuditRemoteAddress(ctx),
+		ForwardedFor:  getAuditForwardedFor(ctx),
+		DeviceID:      deviceID,
+		Href:          href,
+		Outcome:       status.Code(err).String(),
+		Duration:      time.Since(start),
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if errW := s.auditLog.Write(r); errW != nil {
+		s.logger.Errorf("cannot write audit record of %v: %v", method, errW)
+	}
+}
+
+// AuditUnaryInterceptor records the state-changing RPCs to the audit log.
+func (s *ClientApplicationServer) AuditUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if s.auditLog == nil {
+			return handler(ctx, req)
+		}
+		method := path.Base(info.FullMethod)
+		if _, ok := auditedMethods[method]; !ok {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		deviceID, href := getAuditTarget(req)
+		s.writeAuditRecord(ctx, method, deviceID, href, start, err)
+		return resp, err
+	}
+}
+
+func toAuditRecordProto(r audit.Record) *pb.AuditRecord {
+	return &pb.AuditRecord{
+		Time:          r.Time.UnixNano(),
+		Method:        r.Method,
+		Owner:         r.Owner,
+		RemoteAddress: r.RemoteAddress,
+		ForwardedFor:  r.ForwardedFor,
+		DeviceId:      r.DeviceID,
+		Href:          r.Href,
+		Outcome:       r.Outcome,
+		Error:         r.Error,
+		Duration:      r.Duration.Nanoseconds(),
+	}
+}
+
+func (s *ClientApplicationServer) GetAuditLog(_ context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	if s.auditLog == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "audit log is disabled")
+	}
+	filter := audit.Filter{
+		DeviceIDs: req.GetDeviceIdFilter(),
+		Methods:   req.GetMethodFilter(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.GetSince() > 0 {
+		filter.Since = time.Unix(0, req.GetSince())
+	}
+	if req.GetUntil() > 0 {
+		filter.Until = time.Unix(0, req.GetUntil())
+	}
+	records, err := s.auditLog.Get(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get audit log: %v", err)
+	}
+	resp := &pb.GetAuditLogResponse{
+		Records: make([]*pb.AuditRecord, 0, len(records)),
+	}
+	for _, r := range records {
+		resp.Records = append(resp.Records, toAuditRecordProto(r))
+	}
+	return resp, nil
+}
@@ -0,0 +1,182 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/audit"
+	"github.com/plgd-dev/client-application/service/config"
+	configAudit "github.com/plgd-dev/client-application/service/config/audit"
+	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
+	serviceGrpc "github.com/plgd-dev/client-application/service/grpc"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const auditDeviceID = "00000000-0000-0000-0000-000000000001"
+
+func newAuditServer(t *testing.T) *serviceGrpc.ClientApplicationServer {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig(dir)
+	cfg.Audit.FilePath = filepath.Join(dir, "audit.jsonl")
+	auditLog, err := audit.New(cfg.Audit)
+	require.NoError(t, err)
+	s := serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), nil, nil, auditLog, &configGrpc.ServiceInformation{}, log.NewLogger(log.MakeDefaultConfig()))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func invokeAudited(ctx context.Context, t *testing.T, s *serviceGrpc.ClientApplicationServer, method string, req interface{}, err error) {
+	_, errI := s.AuditUnaryInterceptor()(ctx, req, &grpc.UnaryServerInfo{
+		FullMethod: "/" + pb.ClientApplication_ServiceDesc.ServiceName + "/" + method,
+	}, func(context.Context, interface{}) (interface{}, error) {
+		return nil, err
+	})
+	require.Equal(t, err, errI)
+}
+
+func TestClientApplicationServerAuditLog(t *testing.T) {
+	s := newAuditServer(t)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "user"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer "+token, "x-forwarded-for", "10.0.0.1, 192.168.1.2"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}})
+
+	invokeAudited(ctx, t, s, "OwnDevice", &pb.OwnDeviceRequest{DeviceId: auditDeviceID}, nil)
+	invokeAudited(ctx, t, s, "UpdateResource", &pb.UpdateResourceRequest{ResourceId: commands.NewResourceID(auditDeviceID, "/light/1")}, status.Error(codes.NotFound, "not found"))
+	// not audited
+	invokeAudited(ctx, t, s, "GetDevice", &pb.GetDeviceRequest{DeviceId: auditDeviceID}, nil)
+	invokeAudited(context.Background(), t, s, "Reset", &pb.ResetRequest{}, nil)
+
+	resp, err := s.GetAuditLog(ctx, &pb.GetAuditLogRequest{})
+	require.NoError(t, err)
+	records := resp.GetRecords()
+	require.Len(t, records, 3)
+	require.Equal(t, "OwnDevice", records[0].GetMethod())
+	require.Equal(t, "user", records[0].GetOwner())
+	require.Equal(t, "127.0.0.1:1234", records[0].GetRemoteAddress())
+	// the x-forwarded-for of the direct gRPC request is set by the client
+	require.Empty(t, records[0].GetForwardedFor())
+	require.Equal(t, auditDeviceID, records[0].GetDeviceId())
+	require.Equal(t, codes.OK.String(), records[0].GetOutcome())
+	require.NotZero(t, records[0].GetTime())
+
+	require.Equal(t, "/light/1", records[1].GetHref())
+	require.Equal(t, codes.NotFound.String(), records[1].GetOutcome())
+	require.NotEmpty(t, records[1].GetError())
+
+	require.Equal(t, "Reset", records[2].GetMethod())
+	require.Empty(t, records[2].GetOwner())
+
+	resp, err = s.GetAuditLog(ctx, &pb.GetAuditLogRequest{MethodFilter: []string{"UpdateResource"}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRecords(), 1)
+	resp, err = s.GetAuditLog(ctx, &pb.GetAuditLogRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRecords(), 1)
+	require.Equal(t, "Reset", resp.GetRecords()[0].GetMethod())
+}
+
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return "inproc" }
+func (gatewayAddr) String() string  { return "0" }
+
+func TestClientApplicationServerAuditLogForwardedFor(t *testing.T) {
+	s := newAuditServer(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "10.0.0.1, 192.168.1.2"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: gatewayAddr{}})
+	invokeAudited(ctx, t, s, "OwnDevice", &pb.OwnDeviceRequest{DeviceId: auditDeviceID}, nil)
+
+	resp, err := s.GetAuditLog(ctx, &pb.GetAuditLogRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRecords(), 1)
+	// only the last hop appended by the grpc-gateway is recorded
+	require.Equal(t, "192.168.1.2", resp.GetRecords()[0].GetForwardedFor())
+}
+
+func TestClientApplicationServerAuditLogApplyManifest(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.Audit.FilePath = filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := audit.New(cfg.Audit)
+	require.NoError(t, err)
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx, test.WithAuditLog(auditLog))
+	require.NoError(t, err)
+	defer teardown()
+	dev, err := test.GetSimulatedDevice(ctx, s, sim.Devices()[0])
+	require.NoError(t, err)
+
+	power, err := structpb.NewValue(map[string]interface{}{"power": 42})
+	require.NoError(t, err)
+	_, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{
+		Devices: []*pb.DeviceManifest{{
+			DeviceId:  dev.GetId(),
+			Owned:     true,
+			Resources: []*pb.ResourceManifest{{Href: "/light/1", Content: power}},
+		}},
+	})
+	require.NoError(t, err)
+	_, err = s.ApplyManifest(ctx, &pb.ApplyManifestRequest{
+		Devices: []*pb.DeviceManifest{{DeviceId: dev.GetId()}},
+	})
+	require.NoError(t, err)
+
+	resp, err := s.GetAuditLog(ctx, &pb.GetAuditLogRequest{DeviceIdFilter: []string{dev.GetId()}})
+	require.NoError(t, err)
+	methods := make([]string, 0, len(resp.GetRecords()))
+	for _, r := range resp.GetRecords() {
+		require.Equal(t, codes.OK.String(), r.GetOutcome())
+		methods = append(methods, r.GetMethod())
+	}
+	require.Equal(t, []string{"ApplyManifest/OWN", "ApplyManifest/UPDATE_RESOURCE", "ApplyManifest/DISOWN"}, methods)
+	require.Equal(t, "/light/1", resp.GetRecords()[1].GetHref())
+}
+
+func TestClientApplicationServerAuditLogDisabled(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig(dir)
+	cfg.Audit = configAudit.Config{}
+	s := serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), nil, nil, nil, &configGrpc.ServiceInformation{}, log.NewLogger(log.MakeDefaultConfig()))
+	defer s.Close()
+	invokeAudited(context.Background(), t, s, "Reset", &pb.ResetRequest{}, nil)
+	_, err := s.GetAuditLog(context.Background(), &pb.GetAuditLogRequest{})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
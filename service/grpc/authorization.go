@@ -0,0 +1,125 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/plgd-dev/client-application/service/config/authorization"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
+	pkgJwt "github.com/plgd-dev/hub/v2/pkg/security/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getTokenScopes returns the scopes of the verified token. The whitelisted methods are not verified by the authentication interceptor,
+// so the token is verified again and the scopes of an unverified token are never trusted.
+func (s *ClientApplicationServer) getTokenScopes(ctx context.Context) ([]string, error) {
+	token, err := pkgGrpc.TokenFromMD(ctx)
+	if err != nil {
+		return nil, errors.New("missing token")
+	}
+	claims := pkgJwt.NewScopeClaims()
+	if _, err = s.verifyToken(ctx, token, claims); err != nil {
+		return nil, fmt.Errorf("cannot verify token: %v", status.Convert(err).Message())
+	}
+	return pkgJwt.Claims(*claims).GetScope()
+}
+
+// checkScopes checks that the scopes returned by getScopes allow the method. The method is denied when the scopes cannot be got.
+func checkScopes(ctx context.Context, cfg authorization.Config, fullMethod string, getScopes func(ctx context.Context) ([]string, error)) error {
+	if authorization.IsPublicMethod(fullMethod) {
+		return nil
+	}
+	method := path.Base(fullMethod)
+	required := cfg.GetScopes(method)
+	if len(required) == 0 {
+		return status.Errorf(codes.PermissionDenied, "method %v is not allowed by any scope", method)
+	}
+	scopes, err := getScopes(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "cannot get scopes of %v: %v", method, err)
+	}
+	for _, scope := range scopes {
+		if slices.Contains(required, scope) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "method %v requires one of the scopes %v", method, required)
+}
+
+func (s *ClientApplicationServer) authorize(ctx context.Context, fullMethod string) error {
+	cfg := s.GetConfig()
+	if !cfg.Authorization.Enabled {
+		return nil
+	}
+	if s.HasJWTAuthorizationEnabled() {
+		if authorization.IsBootstrapMethod(fullMethod) {
+			// the token of the initialization is verified by the method
+			return nil
+		}
+		return checkScopes(ctx, cfg.Authorization, fullMethod, s.getTokenScopes)
+	}
+	if s.HasAPIKeyAuthenticationEnabled() {
+		return checkScopes(ctx, cfg.Authorization, fullMethod, s.getAPIKeyScopes)
+	}
+	return nil
+}
+
+func (s *ClientApplicationServer) getAPIKeyScopes(ctx context.Context) ([]string, error) {
+	key, ok := s.getAPIKey(ctx)
+	if !ok {
+		return nil, errors.New("missing API key")
+	}
+	return key.Scopes, nil
+}
+
+// AuthorizationUnaryInterceptor enforces the scope rules of the configuration.
+func (s *ClientApplicationServer) AuthorizationUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := s.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizationStreamInterceptor enforces the scope rules of the configuration.
+func (s *ClientApplicationServer) AuthorizationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := s.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// UnaryInterceptor records the state-changing operations to the audit log and enforces the scope rules.
+func (s *ClientApplicationServer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	audit := s.AuditUnaryInterceptor()
+	authorize := s.AuthorizationUnaryInterceptor()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return audit(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return authorize(ctx, req, info, handler)
+		})
+	}
+}
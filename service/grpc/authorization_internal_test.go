@@ -0,0 +1,136 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	"github.com/plgd-dev/client-application/service/config/authorization"
+	"github.com/plgd-dev/hub/v2/identity-store/events"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTokenContext(t *testing.T, a *testAuthority, scope string) context.Context {
+	claims := a.claims()
+	claims["scope"] = scope
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer "+a.token(t, "kid1", claims)))
+}
+
+func fullMethod(method string) string {
+	return "/" + pb.ClientApplication_ServiceDesc.ServiceName + "/" + method
+}
+
+func TestCheckScopes(t *testing.T) {
+	a := newTestAuthority(t)
+	defer a.Close()
+	a.rotate(t, "kid1")
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.Authorization.Enabled = true
+	require.NoError(t, cfg.Validate())
+	s := &ClientApplicationServer{
+		config: atomic.NewPointer(&cfg),
+		logger: log.NewLogger(log.MakeDefaultConfig()),
+	}
+	s.jwksCache.Store(NewJSONWebKeyCache(uuid.MustParse(events.OwnerToUUID(testOwner)), a.getKeys(t)))
+
+	unsignedToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": testOwner, "scope": "admin"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	unsignedCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer "+unsignedToken))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		method  string
+		wantErr bool
+	}{
+		{name: "read", ctx: newTokenContext(t, a, "devices:read"), method: "GetDevices"},
+		{name: "read denied", ctx: newTokenContext(t, a, "devices:read"), method: "UpdateResource", wantErr: true},
+		{name: "multiple scopes", ctx: newTokenContext(t, a, "openid devices:read resources:write"), method: "UpdateResource"},
+		{name: "ownership", ctx: newTokenContext(t, a, "ownership:manage"), method: "DisownDevice"},
+		{name: "reset requires admin", ctx: newTokenContext(t, a, "devices:read resources:write ownership:manage"), method: "Reset", wantErr: true},
+		{name: "admin", ctx: newTokenContext(t, a, "admin"), method: "Reset"},
+		{name: "public", ctx: context.Background(), method: "GetConfiguration"},
+		{name: "missing token", ctx: context.Background(), method: "GetDevices", wantErr: true},
+		{name: "unverified token", ctx: unsignedCtx, method: "GetDevices", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScopes(tt.ctx, cfg.Authorization, fullMethod(tt.method), s.getTokenScopes)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	cfg.Authorization.Rules = []authorization.Rule{{Scope: "devices:read", Methods: []string{"GetDevices"}}}
+	err = checkScopes(newTokenContext(t, a, "admin"), cfg.Authorization, fullMethod("Reset"), s.getTokenScopes)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func newAPIKeyContext(header, key string) context.Context {
+	return ctxWithAPIKey(metadata.NewIncomingContext(context.Background(), metadata.Pairs(header, key)))
+}
+
+func TestAuthorizeAPIKey(t *testing.T) {
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.Authorization.Enabled = true
+	cfg.APIs.APIKeys.Keys = []apikey.Key{
+		{Name: "reader", Hash: apikey.Hash("reader-key"), Scopes: []string{"devices:read"}},
+		{Name: "admin", Hash: apikey.Hash("admin-key"), Scopes: []string{"admin"}},
+	}
+	require.NoError(t, cfg.Validate())
+	s := &ClientApplicationServer{config: atomic.NewPointer(&cfg)}
+	require.True(t, s.HasAPIKeyAuthenticationEnabled())
+
+	readerCtx := newAPIKeyContext(APIKeyMetadataKey, "reader-key")
+	require.NoError(t, s.ParseWithClaims(readerCtx, "reader-key", nil))
+	require.NoError(t, s.authorize(readerCtx, fullMethod("GetDevices")))
+	err := s.authorize(readerCtx, fullMethod("Reset"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the initialization is not public with the API keys
+	err = s.authorize(readerCtx, fullMethod("Initialize"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = s.authorize(readerCtx, fullMethod("FinishInitialize"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	adminCtx := newAPIKeyContext("authorization", "bearer admin-key")
+	require.NoError(t, s.authorize(adminCtx, fullMethod("Reset")))
+	require.NoError(t, s.authorize(adminCtx, fullMethod("Initialize")))
+	require.Equal(t, "apiKey:admin", s.getAuditOwner(adminCtx, ""))
+
+	err = s.ParseWithClaims(context.Background(), "invalid-key", nil)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	err = s.ParseWithClaims(context.Background(), "", nil)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	err = s.authorize(newAPIKeyContext(APIKeyMetadataKey, "invalid-key"), fullMethod("GetDevices"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,54 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/plgd-dev/client-application/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ClientApplicationServer) CloseConnections(_ context.Context, req *pb.CloseConnectionsRequest) (*pb.CloseConnectionsResponse, error) {
+	var deviceIDFilter []string
+	if req.GetDeviceId() != "" {
+		deviceIDFilter = []string{req.GetDeviceId()}
+	}
+	devs, err := s.getCachedDevices(deviceIDFilter)
+	if err != nil {
+		return nil, err
+	}
+	var closed uint32
+	var errs []error
+	for _, dev := range devs {
+		n, err := dev.closeConnections()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot close device %v connections: %w", dev.ID, err))
+			continue
+		}
+		closed += uint32(n)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.CloseConnectionsResponse{
+		ClosedConnections: closed,
+	}, nil
+}
@@ -0,0 +1,103 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+)
+
+// deviceConnection is the connection established by the dialers of the device.
+type deviceConnection struct {
+	conn        *coap.ClientCloseHandler
+	scheme      schema.Scheme
+	established time.Time
+}
+
+func (c deviceConnection) isSecure() bool {
+	return c.scheme == schema.UDPSecureScheme || c.scheme == schema.TCPSecureScheme
+}
+
+// connectionsState tracks the open connections of the device until they are closed.
+type connectionsState struct {
+	mutex       sync.Mutex
+	connections map[*coap.ClientCloseHandler]deviceConnection
+}
+
+func newConnectionsState() *connectionsState {
+	return &connectionsState{
+		connections: make(map[*coap.ClientCloseHandler]deviceConnection),
+	}
+}
+
+func (s *connectionsState) remove(conn *coap.ClientCloseHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.connections, conn)
+}
+
+func (s *connectionsState) track(conn *coap.ClientCloseHandler, scheme schema.Scheme, err error) (*coap.ClientCloseHandler, error) {
+	if err != nil {
+		return conn, err
+	}
+	s.mutex.Lock()
+	s.connections[conn] = deviceConnection{
+		conn:        conn,
+		scheme:      scheme,
+		established: time.Now(),
+	}
+	s.mutex.Unlock()
+	conn.RegisterCloseHandler(func(error) {
+		s.remove(conn)
+	})
+	if conn.Context().Err() != nil {
+		// the connection was closed before the close handler was registered
+		s.remove(conn)
+	}
+	return conn, nil
+}
+
+// list returns the open connections ordered by the time of establishing.
+func (s *connectionsState) list() []deviceConnection {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	conns := make([]deviceConnection, 0, len(s.connections))
+	for _, c := range s.connections {
+		conns = append(conns, c)
+	}
+	s.mutex.Unlock()
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].established.Before(conns[j].established)
+	})
+	return conns
+}
+
+// closeConnections closes the connections and the observations of the device, the device stays in the cache.
+// It returns the number of the closed connections.
+func (d *device) closeConnections() (int, error) {
+	n := len(d.connections.list())
+	if err := closeDevice(d); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,195 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v2"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/plgd-dev/go-coap/v3/udp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testConn struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	addr   net.Addr
+}
+
+func (c *testConn) Post(context.Context, string, message.MediaType, io.ReadSeeker, ...message.Option) (*pool.Message, error) {
+	return nil, io.EOF
+}
+
+func (c *testConn) Get(context.Context, string, ...message.Option) (*pool.Message, error) {
+	return nil, io.EOF
+}
+
+func (c *testConn) Delete(context.Context, string, ...message.Option) (*pool.Message, error) {
+	return nil, io.EOF
+}
+
+func (c *testConn) Observe(context.Context, string, func(*pool.Message), ...message.Option) (coap.Observation, error) {
+	return nil, io.EOF
+}
+
+func (c *testConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+func (c *testConn) Close() error {
+	c.cancel()
+	return nil
+}
+
+func (c *testConn) Context() context.Context {
+	return c.ctx
+}
+
+func (c *testConn) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+type testDialer struct {
+	onClose map[string]*coap.OnCloseHandler
+	conns   map[string]*testConn
+}
+
+func (d *testDialer) dial(addr string) (*coap.ClientCloseHandler, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &testConn{ctx: ctx, cancel: cancel, addr: udpAddr}
+	h := coap.NewOnCloseHandler()
+	d.conns[addr] = c
+	d.onClose[addr] = h
+	return coap.NewClientCloseHandler(c, h), nil
+}
+
+func (d *testDialer) close(addr string) {
+	_ = d.conns[addr].Close()
+	d.onClose[addr].OnClose(nil)
+}
+
+func newTestDeviceConfiguration(d *testDialer) core.DeviceConfiguration {
+	return core.DeviceConfiguration{
+		DialDTLS: func(_ context.Context, addr string, _ *dtls.Config, _ ...udp.Option) (*coap.ClientCloseHandler, error) {
+			return d.dial(addr)
+		},
+		DialUDP: func(_ context.Context, addr string, _ ...udp.Option) (*coap.ClientCloseHandler, error) {
+			return d.dial(addr)
+		},
+		DialTCP: func(_ context.Context, addr string, _ ...tcp.Option) (*coap.ClientCloseHandler, error) {
+			return d.dial(addr)
+		},
+	}
+}
+
+func TestConnectionsState(t *testing.T) {
+	d := &testDialer{onClose: map[string]*coap.OnCloseHandler{}, conns: map[string]*testConn{}}
+	dev := &device{
+		endpoints:   newEndpointsState(),
+		connections: newConnectionsState(),
+	}
+	s := dev.connections
+	cfg := dev.wrapDialers(newTestDeviceConfiguration(d))
+
+	_, err := cfg.DialDTLS(context.Background(), "127.0.0.1:5684", &dtls.Config{})
+	require.NoError(t, err)
+	_, err = cfg.DialUDP(context.Background(), "127.0.0.1:5683")
+	require.NoError(t, err)
+	_, err = cfg.DialTCP(context.Background(), "invalid")
+	require.Error(t, err)
+
+	conns := s.list()
+	require.Len(t, conns, 2)
+	require.Equal(t, schema.UDPSecureScheme, conns[0].scheme)
+	require.True(t, conns[0].isSecure())
+	require.Equal(t, "127.0.0.1:5684", conns[0].conn.RemoteAddr().String())
+	require.Equal(t, schema.UDPScheme, conns[1].scheme)
+	require.False(t, conns[1].isSecure())
+	// the same dial hook reports the dials to the endpoint selection
+	active, failed := dev.endpoints.get()
+	require.Equal(t, "coap://127.0.0.1:5683", active)
+	require.Equal(t, []string{"coap+tcp://invalid"}, failed)
+
+	d.close("127.0.0.1:5684")
+	conns = s.list()
+	require.Len(t, conns, 1)
+	require.Equal(t, schema.UDPScheme, conns[0].scheme)
+
+	// the device without the tracked connections
+	var empty *connectionsState
+	require.Empty(t, empty.list())
+}
+
+func TestGetAndCloseConnections(t *testing.T) {
+	d := &testDialer{onClose: map[string]*coap.OnCloseHandler{}, conns: map[string]*testConn{}}
+	dev := &device{
+		ID:          uuid.New(),
+		endpoints:   newEndpointsState(),
+		connections: newConnectionsState(),
+	}
+	cfg := dev.wrapDialers(newTestDeviceConfiguration(d))
+	dev.Device = core.NewDevice(cfg, dev.ID.String(), nil, dev.GetEndpoints)
+	_, err := cfg.DialDTLS(context.Background(), "127.0.0.1:5684", &dtls.Config{})
+	require.NoError(t, err)
+
+	s := &ClientApplicationServer{
+		devices: coapSync.NewMap[uuid.UUID, *device](),
+	}
+	s.devices.Store(dev.ID, dev)
+
+	resp, err := s.GetConnections(context.Background(), &pb.GetConnectionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetConnections(), 1)
+	conn := resp.GetConnections()[0]
+	require.Equal(t, dev.ID.String(), conn.GetDeviceId())
+	require.Equal(t, string(schema.UDPSecureScheme), conn.GetScheme())
+	require.Equal(t, "127.0.0.1:5684", conn.GetRemoteAddress())
+	require.True(t, conn.GetSecure())
+	require.GreaterOrEqual(t, conn.GetAge(), int64(0))
+	require.NotZero(t, conn.GetLastActivity())
+
+	_, err = s.GetConnections(context.Background(), &pb.GetConnectionsRequest{DeviceIdFilter: []string{uuid.NewString()}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.CloseConnections(context.Background(), &pb.CloseConnectionsRequest{DeviceId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	closeResp, err := s.CloseConnections(context.Background(), &pb.CloseConnectionsRequest{DeviceId: dev.ID.String()})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), closeResp.GetClosedConnections())
+	// the device stays in the cache
+	_, ok := s.devices.Load(dev.ID)
+	require.True(t, ok)
+}
@@ -54,17 +54,22 @@ func (s *ClientApplicationServer) CreateResource(ctx context.Context, req *pb.Cr
 	if err != nil {
 		return nil, err
 	}
-	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), "")
+	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), "", req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
 	var response []byte
 	options := make([]func(message.Options) message.Options, 0, 2)
 	options = append(options, coap.WithDeviceID(dev.DeviceID()), coap.WithInterface(interfaces.OC_IF_CREATE))
-	err = dev.UpdateResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), createData, &response, options...)
+	err = dev.withRetry(ctx, req.GetRetry(), func(ctx context.Context) error {
+		return dev.UpdateResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), createData, &response, options...)
+	})
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot create resource %v for device %v: %w", link.Href, dev.ID, err)).Err()
+		dev.invalidateResourceLinksOnNotFound(err)
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot create resource %v for device %v: %w", link.Href, dev.ID, err), dev.newDeviceRequest(link.Href)).Err()
 	}
+	// the created resource changes the links of the device
+	dev.invalidateResourceLinks()
 	return &grpcgwPb.CreateResourceResponse{
 		Data: &events.ResourceCreated{
 			Content: responseToData(response),
@@ -39,17 +39,22 @@ func (s *ClientApplicationServer) DeleteResource(ctx context.Context, req *pb.De
 	if err != nil {
 		return nil, err
 	}
-	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), "")
+	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), "", req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
 	var response []byte
 	options := make([]func(message.Options) message.Options, 0, 2)
 	options = append(options, pkgCoap.WithDeviceID(dev.DeviceID()))
-	err = dev.DeleteResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), &response, options...)
+	err = dev.withRetry(ctx, req.GetRetry(), func(ctx context.Context) error {
+		return dev.DeleteResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), &response, options...)
+	})
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot delete resource %v for device %v: %w", link.Href, dev.ID, err)).Err()
+		dev.invalidateResourceLinksOnNotFound(err)
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot delete resource %v for device %v: %w", link.Href, dev.ID, err), dev.newDeviceRequest(link.Href)).Err()
 	}
+	// the deleted resource changes the links of the device
+	dev.invalidateResourceLinks()
 	return &grpcgwPb.DeleteResourceResponse{
 		Data: &events.ResourceDeleted{
 			Content: responseToData(response),
@@ -18,6 +18,7 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"slices"
 	"sort"
@@ -25,6 +26,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pion/dtls/v2"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
 	serviceDevice "github.com/plgd-dev/client-application/service/device"
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
@@ -33,6 +36,8 @@ import (
 	"github.com/plgd-dev/device/v2/schema/doxm"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
 	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/plgd-dev/go-coap/v3/udp"
 	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/v2/pkg/log"
 	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
@@ -52,6 +57,15 @@ func (d devices) Sort() {
 type device struct {
 	ID     uuid.UUID
 	logger log.Logger
+	// retry calls the function according to the retry policy of the device service
+	retry func(ctx context.Context, fn func(ctx context.Context) error) error
+	// getEndpointSelectionStrategy returns the strategy used to order the endpoints of the device
+	getEndpointSelectionStrategy func() configDevice.EndpointSelectionStrategy
+	endpoints                    *endpointsState
+	// getResourceLinksCacheTTL returns how long the resource links are cached
+	getResourceLinksCacheTTL func() time.Duration
+	linksCache               *resourceLinksCache
+	connections              *connectionsState
 
 	private struct {
 		mutex              sync.RWMutex
@@ -70,12 +84,74 @@ func newDevice(deviceID uuid.UUID, serviceDevice *serviceDevice.Service, logger
 	d := device{
 		ID:     deviceID,
 		logger: logger.With(log.DeviceIDKey, deviceID),
+		retry:  serviceDevice.Retry,
+
+		getEndpointSelectionStrategy: serviceDevice.GetEndpointSelectionStrategy,
+		endpoints:                    newEndpointsState(),
+		getResourceLinksCacheTTL:     serviceDevice.GetResourceLinksCacheTTL,
+		connections:                  newConnectionsState(),
 	}
+	d.linksCache = newResourceLinksCache(func(observationID string) {
+		// the observation is stopped by the request to the device, so it must not block the caller
+		go d.stopResourceLinksObservation(observationID)
+	})
 	coreDeviceCfg.Logger = serviceDevice.DeviceLogger()
+	coreDeviceCfg = d.wrapDialers(coreDeviceCfg)
 	d.Device = core.NewDevice(coreDeviceCfg, deviceID.String(), []string{}, d.GetEndpoints)
 	return &d
 }
 
+// onDial reports the result and the duration of establishing the connection to the endpoint selection and tracks the established connection.
+func (d *device) onDial(ctx context.Context, scheme schema.Scheme, addr string, rtt time.Duration, conn *coap.ClientCloseHandler, err error) (*coap.ClientCloseHandler, error) {
+	d.endpoints.onDial(ctx, string(scheme)+"://"+addr, rtt, err)
+	return d.connections.track(conn, scheme, err)
+}
+
+// wrapDialers hooks the dialers of the device by onDial.
+func (d *device) wrapDialers(cfg core.DeviceConfiguration) core.DeviceConfiguration {
+	dialDTLS := cfg.DialDTLS
+	cfg.DialDTLS = func(ctx context.Context, addr string, dtlsCfg *dtls.Config, opts ...udp.Option) (*coap.ClientCloseHandler, error) {
+		start := time.Now()
+		c, err := dialDTLS(ctx, addr, dtlsCfg, opts...)
+		return d.onDial(ctx, schema.UDPSecureScheme, addr, time.Since(start), c, err)
+	}
+	dialUDP := cfg.DialUDP
+	cfg.DialUDP = func(ctx context.Context, addr string, opts ...udp.Option) (*coap.ClientCloseHandler, error) {
+		c, err := dialUDP(ctx, addr, opts...)
+		// the connection is created without any exchange with the device, so the RTT is not measured
+		return d.onDial(ctx, schema.UDPScheme, addr, 0, c, err)
+	}
+	dialTCP := cfg.DialTCP
+	cfg.DialTCP = func(ctx context.Context, addr string, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
+		start := time.Now()
+		c, err := dialTCP(ctx, addr, opts...)
+		return d.onDial(ctx, schema.TCPScheme, addr, time.Since(start), c, err)
+	}
+	dialTLS := cfg.DialTLS
+	cfg.DialTLS = func(ctx context.Context, addr string, tlsCfg *tls.Config, opts ...tcp.Option) (*coap.ClientCloseHandler, error) {
+		start := time.Now()
+		c, err := dialTLS(ctx, addr, tlsCfg, opts...)
+		return d.onDial(ctx, schema.TCPSecureScheme, addr, time.Since(start), c, err)
+	}
+	return cfg
+}
+
+// Close stops the observation of the resource links and closes the connections of the device.
+func (d *device) Close(ctx context.Context) error {
+	if d.linksCache != nil {
+		d.linksCache.invalidate()
+	}
+	return d.Device.Close(ctx)
+}
+
+// withRetry calls fn, when retry is set then the failed fn is retried according to the retry policy.
+func (d *device) withRetry(ctx context.Context, retry bool, fn func(ctx context.Context) error) error {
+	if !retry || d.retry == nil {
+		return fn(ctx)
+	}
+	return d.retry(ctx, fn)
+}
+
 func (d *device) ErrorFunc(err error) {
 	d.logger.Debug(err)
 }
@@ -119,6 +195,47 @@ func (d *device) GetEndpoints() schema.Endpoints {
 	return endpoints
 }
 
+// orderEndpoints returns the endpoints in the order in which they are used for the requests to the device.
+func (d *device) orderEndpoints(endpoints schema.Endpoints) schema.Endpoints {
+	if d.endpoints == nil {
+		return endpoints
+	}
+	strategy := configDevice.EndpointSelectionDefault
+	if d.getEndpointSelectionStrategy != nil {
+		strategy = d.getEndpointSelectionStrategy()
+	}
+	return d.endpoints.order(endpoints, strategy)
+}
+
+// orderResourceLinksEndpoints returns the links with the endpoints in the order in which they are used for the requests to the device.
+func (d *device) orderResourceLinksEndpoints(links schema.ResourceLinks) schema.ResourceLinks {
+	ordered := make(schema.ResourceLinks, 0, len(links))
+	for _, link := range links {
+		link.Endpoints = d.orderEndpoints(link.Endpoints)
+		ordered = append(ordered, link)
+	}
+	return ordered
+}
+
+// getEndpointsStatus returns the endpoint of the last established connection and the failed endpoints.
+func (d *device) getEndpointsStatus() (string, []string) {
+	if d.endpoints == nil {
+		return "", nil
+	}
+	return d.endpoints.get()
+}
+
+func (d *device) newDeviceRequest(href string) deviceRequest {
+	req := deviceRequest{
+		deviceID: d.ID.String(),
+		href:     href,
+	}
+	if d.endpoints != nil {
+		req.endpoint = d.endpoints.getDialed()
+	}
+	return req
+}
+
 func normalizeHref(href string) string {
 	if href == "" {
 		return ""
@@ -148,27 +265,48 @@ func (d *device) getResourceLinksAndRefreshCache(ctx context.Context) (schema.Re
 	if d.Device == nil {
 		return nil, status.Error(codes.Internal, "device is not initialized")
 	}
-	links, err := d.GetResourceLinks(ctx, d.GetEndpoints(), coap.WithDeviceID(d.DeviceID()))
+	var links schema.ResourceLinks
+	err := d.withRetry(ctx, true, func(ctx context.Context) error {
+		var errG error
+		links, errG = d.GetResourceLinks(ctx, d.orderEndpoints(d.GetEndpoints()), coap.WithDeviceID(d.DeviceID()))
+		return errG
+	})
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot get resource links for device %v: %w", d.ID, err)).Err()
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot get resource links for device %v: %w", d.ID, err), d.newDeviceRequest("")).Err()
 	}
 	d.updateOwnershipStatus(getOwnershipStatusLinks(links))
 	devLinks := links.GetResourceLinks(plgdDevice.ResourceType)
 	if len(devLinks) > 0 {
 		d.updateResourceTypes(devLinks[0].ResourceTypes)
 	}
+	d.storeResourceLinks(links)
 	return links, nil
 }
 
-func (d *device) getResourceLink(ctx context.Context, resourceID *commands.ResourceId) (schema.ResourceLink, error) {
-	links, err := d.getResourceLinksAndRefreshCache(ctx)
+// getOrderedResourceLinks returns the links with the endpoints in the order in which they are used for the requests to the device.
+// The cached links are used unless refresh is set.
+func (d *device) getOrderedResourceLinks(ctx context.Context, refresh bool) (schema.ResourceLinks, error) {
+	links, _, err := d.getCachedResourceLinks(ctx, refresh)
+	if err != nil {
+		return nil, err
+	}
+	return d.orderResourceLinksEndpoints(links), nil
+}
+
+func (d *device) getResourceLink(ctx context.Context, resourceID *commands.ResourceId, refresh bool) (schema.ResourceLink, error) {
+	links, cached, err := d.getCachedResourceLinks(ctx, refresh)
 	if err != nil {
 		return schema.ResourceLink{}, err
 	}
 	link, ok := links.GetResourceLink(normalizeHref(resourceID.GetHref()))
+	if !ok && cached {
+		// the resource could be added after the links were cached
+		return d.getResourceLink(ctx, resourceID, true)
+	}
 	if !ok {
 		return schema.ResourceLink{}, status.Errorf(codes.NotFound, "cannot find resource link %v for device %v", resourceID.GetHref(), d.ID)
 	}
+	link.Endpoints = d.orderEndpoints(link.Endpoints)
 	return link, nil
 }
 
@@ -179,8 +317,8 @@ func (d *device) checkAccess(link schema.ResourceLink) error {
 	return nil
 }
 
-func (d *device) getResourceLinkAndCheckAccess(ctx context.Context, resourceID *commands.ResourceId, resInterface string) (schema.ResourceLink, error) {
-	link, err := d.getResourceLink(ctx, resourceID)
+func (d *device) getResourceLinkAndCheckAccess(ctx context.Context, resourceID *commands.ResourceId, resInterface string, refresh bool) (schema.ResourceLink, error) {
+	link, err := d.getResourceLink(ctx, resourceID, refresh)
 	if err != nil {
 		return link, err
 	}
@@ -193,6 +331,7 @@ func (d *device) getResourceLinkAndCheckAccess(ctx context.Context, resourceID *
 func (d *device) updateDeviceMetadata(resourceTypes []string, endpoints schema.Endpoints, ownershipStatus grpcgwPb.Device_OwnershipStatus) {
 	d.private.mutex.Lock()
 	defer d.private.mutex.Unlock()
+	d.invalidateResourceLinksOnOwnershipChangeLocked(ownershipStatus)
 	d.private.ResourceTypes = resourceTypes
 	d.private.OwnershipStatus = ownershipStatus
 	d.updateEndpointsLocked(endpoints)
@@ -207,9 +346,18 @@ func (d *device) updateResourceTypes(resourceTypes []string) {
 func (d *device) updateOwnershipStatus(ownershipStatus grpcgwPb.Device_OwnershipStatus) {
 	d.private.mutex.Lock()
 	defer d.private.mutex.Unlock()
+	d.invalidateResourceLinksOnOwnershipChangeLocked(ownershipStatus)
 	d.private.OwnershipStatus = ownershipStatus
 }
 
+// invalidateResourceLinksOnOwnershipChangeLocked invalidates the cached links when the ownership status changes, because the device can expose
+// different resources and endpoints to the owner.
+func (d *device) invalidateResourceLinksOnOwnershipChangeLocked(ownershipStatus grpcgwPb.Device_OwnershipStatus) {
+	if d.linksCache != nil && d.private.OwnershipStatus != ownershipStatus {
+		d.linksCache.invalidate()
+	}
+}
+
 func (d *device) updateDeviceResourceBody(body *commands.Content) {
 	d.private.mutex.Lock()
 	defer d.private.mutex.Unlock()
@@ -223,6 +371,7 @@ func (d *device) update(data *device) {
 	defer d.private.mutex.Unlock()
 	d.private.DeviceResourceBody = data.private.DeviceResourceBody
 	d.private.ResourceTypes = data.private.ResourceTypes
+	d.invalidateResourceLinksOnOwnershipChangeLocked(data.private.OwnershipStatus)
 	d.private.OwnershipStatus = data.private.OwnershipStatus
 	d.updateEndpointsLocked(data.private.Endpoints)
 }
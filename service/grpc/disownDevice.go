@@ -36,7 +36,7 @@ func (s *ClientApplicationServer) DisownDevice(ctx context.Context, req *pb.Diso
 	if err != nil {
 		return nil, err
 	}
-	links, err := dev.getResourceLinksAndRefreshCache(ctx)
+	links, err := dev.getOrderedResourceLinks(ctx, req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +45,9 @@ func (s *ClientApplicationServer) DisownDevice(ctx context.Context, req *pb.Diso
 	}
 
 	err = dev.Disown(ctx, links)
+	dev.invalidateResourceLinks()
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot disown device %v: %w", dev.ID, err)).Err()
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot disown device %v: %w", dev.ID, err), dev.newDeviceRequest("")).Err()
 	}
 	err = s.deleteDevice(ctx, devID)
 	if err != nil {
@@ -0,0 +1,72 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/plgd-dev/client-application/pkg/dnssd"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+)
+
+func getDNSSDServiceScheme(serviceType string) schema.Scheme {
+	if strings.HasSuffix(serviceType, "._tcp") {
+		return schema.TCPScheme
+	}
+	return schema.UDPScheme
+}
+
+// getDevicesByDNSSD resolves the DNS-SD services to the endpoints and probes them the same way as the endpoints of use_endpoints.
+func getDevicesByDNSSD(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, cfg configDevice.DNSSDConfig, devices *foundDevices) {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	probed := make(map[string]struct{})
+	err := dnssd.Browse(ctx, cfg.Addresses, cfg.Services, func(service dnssd.Service) {
+		scheme := getDNSSDServiceScheme(service.Type)
+		address := service.AddrPort.String()
+		mutex.Lock()
+		_, ok := probed[string(scheme)+address]
+		probed[string(scheme)+address] = struct{}{}
+		mutex.Unlock()
+		if ok {
+			return
+		}
+		logger.Debugf("probing DNS-SD service %v at %v://%v", service.Instance, scheme, address)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if scheme == schema.UDPScheme {
+				err = getDeviceByUnicastAddress(ctx, serviceDevice, logger, address, devices)
+			} else {
+				err = getDeviceByEndpoint(ctx, serviceDevice, logger, scheme, address, devices)
+			}
+			if err != nil {
+				logger.Debugf("cannot get device by DNS-SD service %v at %v://%v: %v", service.Instance, scheme, address, err)
+			}
+		}()
+	})
+	wg.Wait()
+	if err != nil {
+		logger.Errorf("failed to browse DNS-SD services: %v", err)
+	}
+}
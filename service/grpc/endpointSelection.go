@@ -0,0 +1,169 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/device/v2/schema"
+)
+
+// endpointsState tracks the connections established to the endpoints of the device.
+type endpointsState struct {
+	mutex  sync.Mutex
+	active string
+	dialed string
+	failed map[string]bool
+	rtt    map[string]time.Duration
+}
+
+func newEndpointsState() *endpointsState {
+	return &endpointsState{
+		failed: make(map[string]bool),
+		rtt:    make(map[string]time.Duration),
+	}
+}
+
+func endpointKey(endpoint schema.Endpoint) string {
+	addr, err := endpoint.GetAddr()
+	if err != nil {
+		return endpoint.URI
+	}
+	return addr.URL()
+}
+
+func (s *endpointsState) onDial(ctx context.Context, key string, rtt time.Duration, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dialed = key
+	if err != nil {
+		if ctx.Err() != nil {
+			// the request was canceled or timed out, the endpoint is not marked as failed
+			return
+		}
+		s.failed[key] = true
+		if s.active == key {
+			s.active = ""
+		}
+		return
+	}
+	delete(s.failed, key)
+	s.active = key
+	if rtt > 0 {
+		s.rtt[key] = rtt
+	}
+}
+
+// get returns the endpoint of the last established connection and the failed endpoints.
+func (s *endpointsState) get() (string, []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	failed := make([]string, 0, len(s.failed))
+	for key := range s.failed {
+		failed = append(failed, key)
+	}
+	sort.Strings(failed)
+	return s.active, failed
+}
+
+// getDialed returns the endpoint of the last dial, it is used by the connection of the device or it failed.
+func (s *endpointsState) getDialed() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.dialed
+}
+
+type endpointInfo struct {
+	endpoint schema.Endpoint
+	rank     int
+	tcp      bool
+	ipv6     bool
+	rtt      time.Duration
+}
+
+func (s *endpointsState) newEndpointInfo(endpoint schema.Endpoint) endpointInfo {
+	key := endpointKey(endpoint)
+	info := endpointInfo{
+		endpoint: endpoint,
+		rank:     1,
+	}
+	switch {
+	case key == s.active:
+		info.rank = 0
+	case s.failed[key]:
+		info.rank = 2
+	}
+	if addr, err := endpoint.GetAddr(); err == nil {
+		scheme := schema.Scheme(addr.GetScheme())
+		info.tcp = scheme == schema.TCPScheme || scheme == schema.TCPSecureScheme
+		info.ipv6 = strings.ContainsAny(addr.GetHostname(), ":")
+	}
+	if rtt, ok := s.rtt[key]; ok {
+		info.rtt = rtt
+	}
+	return info
+}
+
+func lessByStrategy(strategy configDevice.EndpointSelectionStrategy, a, b endpointInfo) bool {
+	switch strategy {
+	case configDevice.EndpointSelectionPreferTCP:
+		return a.tcp && !b.tcp
+	case configDevice.EndpointSelectionPreferIPv6:
+		return a.ipv6 && !b.ipv6
+	case configDevice.EndpointSelectionLowestRTT:
+		if a.rtt == 0 || b.rtt == 0 {
+			// measured endpoints are preferred
+			return a.rtt != 0 && b.rtt == 0
+		}
+		return a.rtt < b.rtt
+	case configDevice.EndpointSelectionDefault:
+	}
+	return false
+}
+
+// order returns the endpoints in the order in which they are tried: the endpoint of the last established connection,
+// the endpoints ordered by the strategy and the priority set by the device, and the failed endpoints.
+// The priorities of the returned endpoints are set according to the order.
+func (s *endpointsState) order(endpoints schema.Endpoints, strategy configDevice.EndpointSelectionStrategy) schema.Endpoints {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+	s.mutex.Lock()
+	infos := make([]endpointInfo, 0, len(endpoints))
+	for _, endpoint := range endpoints.Sort() {
+		infos = append(infos, s.newEndpointInfo(endpoint))
+	}
+	s.mutex.Unlock()
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].rank != infos[j].rank {
+			return infos[i].rank < infos[j].rank
+		}
+		return lessByStrategy(strategy, infos[i], infos[j])
+	})
+	ordered := make(schema.Endpoints, 0, len(infos))
+	for i, info := range infos {
+		endpoint := info.endpoint
+		endpoint.Priority = uint64(i)
+		ordered = append(ordered, endpoint)
+	}
+	return ordered
+}
@@ -0,0 +1,90 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func endpointURIs(endpoints schema.Endpoints) []string {
+	uris := make([]string, 0, len(endpoints))
+	for i, ep := range endpoints {
+		if ep.Priority != uint64(i) {
+			return nil
+		}
+		uris = append(uris, ep.URI)
+	}
+	return uris
+}
+
+func TestEndpointsStateOrder(t *testing.T) {
+	const (
+		udp4 = "coaps://127.0.0.1:5684"
+		udp6 = "coaps://[::1]:5684"
+		tcp4 = "coaps+tcp://127.0.0.1:5685"
+		tcp6 = "coaps+tcp://[::1]:5685"
+	)
+	endpoints := schema.Endpoints{
+		{URI: udp4, Priority: 1},
+		{URI: udp6, Priority: 2},
+		{URI: tcp4, Priority: 3},
+		{URI: tcp6, Priority: 4},
+	}
+	s := newEndpointsState()
+	require.Equal(t, []string{udp4, udp6, tcp4, tcp6}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionDefault)))
+	require.Equal(t, []string{tcp4, tcp6, udp4, udp6}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionPreferTCP)))
+	require.Equal(t, []string{udp6, tcp6, udp4, tcp4}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionPreferIPv6)))
+
+	ctx := context.Background()
+	s.onDial(ctx, tcp6, time.Millisecond*10, nil)
+	s.onDial(ctx, udp6, time.Millisecond*20, nil)
+	// the endpoint of the last established connection is the first one
+	require.Equal(t, []string{udp6, tcp6, udp4, tcp4}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionLowestRTT)))
+
+	// the failed endpoint is the last one
+	s.onDial(ctx, udp6, 0, errors.New("handshake failed"))
+	require.Equal(t, []string{tcp6, udp4, tcp4, udp6}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionLowestRTT)))
+	require.Equal(t, []string{udp4, tcp4, tcp6, udp6}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionDefault)))
+	active, failed := s.get()
+	require.Empty(t, active)
+	require.Equal(t, []string{udp6}, failed)
+	// the failed endpoint is reported by the errors
+	require.Equal(t, udp6, s.getDialed())
+
+	// the failure caused by the canceled request is ignored
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	s.onDial(canceledCtx, tcp4, 0, context.Canceled)
+	s.onDial(ctx, udp4, 0, nil)
+	active, failed = s.get()
+	require.Equal(t, udp4, active)
+	require.Equal(t, []string{udp6}, failed)
+
+	// the endpoint is not failed after the connection is established
+	s.onDial(ctx, udp6, time.Millisecond, nil)
+	active, failed = s.get()
+	require.Equal(t, udp6, active)
+	require.Empty(t, failed)
+	require.Equal(t, []string{udp6, tcp6, udp4, tcp4}, endpointURIs(s.order(endpoints, configDevice.EndpointSelectionLowestRTT)))
+}
@@ -17,18 +17,143 @@
 package grpc
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"io"
+	"net"
+	"strconv"
+	"syscall"
 
+	"github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
 	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
 	"github.com/plgd-dev/hub/v2/coap-gateway/coapconv"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcCodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func convErrToGrpcStatus(defaultCode codes.Code, err error) *status.Status { //nolint:unparam
-	var coapStatus coapStatus.Status
-	if errors.As(err, &coapStatus) {
-		return status.New(coapconv.ToGrpcCode(coapStatus.Code(), defaultCode), err.Error())
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of the errors.
+const ErrorDomain = "client-application.plgd.dev"
+
+// Reasons of the google.rpc.ErrorInfo details of the device errors.
+const (
+	ErrorReasonTimeout         = "TIMEOUT"
+	ErrorReasonHandshakeFailed = "HANDSHAKE_FAILED"
+	ErrorReasonAccessDenied    = "ACCESS_DENIED"
+	ErrorReasonDeviceError     = "DEVICE_ERROR"
+	ErrorReasonUnavailable     = "UNAVAILABLE"
+	ErrorReasonCanceled        = "CANCELED"
+	// ErrorReasonRequestFailed is set when the request failed without the network error or the response of the device, e.g. by the invalid configuration.
+	ErrorReasonRequestFailed = "REQUEST_FAILED"
+)
+
+// Metadata keys of the google.rpc.ErrorInfo details of the device errors.
+const (
+	ErrorMetadataDeviceID  = "deviceId"
+	ErrorMetadataHref      = "href"
+	ErrorMetadataEndpoint  = "endpoint"
+	ErrorMetadataCoapCode  = "coapCode"
+	ErrorMetadataRetryable = "retryable"
+)
+
+// deviceRequest describes the request to the device which failed.
+type deviceRequest struct {
+	deviceID string
+	href     string
+	// endpoint is the last dialed endpoint of the device.
+	endpoint string
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	var errno syscall.Errno
+	return errors.As(err, &netErr) || errors.As(err, &errno) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isHandshakeFailed(err error) bool {
+	var dtlsErr *dtls.HandshakeError
+	var alertErr tls.AlertError
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var certificateInvalidErr x509.CertificateInvalidError
+	return errors.As(err, &dtlsErr) || errors.As(err, &alertErr) || errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthorityErr) || errors.As(err, &certificateInvalidErr)
+}
+
+// getErrorReason classifies the error of the device, it returns the reason, the CoAP code of the response or nil and whether the request can be retried.
+func getErrorReason(err error) (string, *codes.Code, bool) {
+	var coapErr coapStatus.Status
+	if errors.As(err, &coapErr) {
+		code := coapErr.Code()
+		switch code {
+		case codes.Unauthorized, codes.Forbidden:
+			return ErrorReasonAccessDenied, &code, false
+		case codes.ServiceUnavailable, codes.GatewayTimeout:
+			return ErrorReasonDeviceError, &code, true
+		}
+		return ErrorReasonDeviceError, &code, false
+	}
+	if isTimeout(err) {
+		return ErrorReasonTimeout, nil, true
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorReasonCanceled, nil, false
+	}
+	if isHandshakeFailed(err) {
+		return ErrorReasonHandshakeFailed, nil, false
+	}
+	if isNetworkError(err) {
+		return ErrorReasonUnavailable, nil, true
+	}
+	return ErrorReasonRequestFailed, nil, false
+}
+
+func newErrorInfo(err error, req deviceRequest) *errdetails.ErrorInfo {
+	reason, coapCode, retryable := getErrorReason(err)
+	metadata := map[string]string{
+		ErrorMetadataRetryable: strconv.FormatBool(retryable),
+	}
+	if req.deviceID != "" {
+		metadata[ErrorMetadataDeviceID] = req.deviceID
+	}
+	if req.href != "" {
+		metadata[ErrorMetadataHref] = req.href
+	}
+	if req.endpoint != "" {
+		metadata[ErrorMetadataEndpoint] = req.endpoint
+	}
+	if coapCode != nil {
+		metadata[ErrorMetadataCoapCode] = coapCode.String()
+	}
+	return &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}
+}
+
+// convErrToGrpcStatus converts the error of the request to the device to the status with the google.rpc.ErrorInfo details.
+func convErrToGrpcStatus(defaultCode grpcCodes.Code, err error, req deviceRequest) *status.Status {
+	code := defaultCode
+	var coapErr coapStatus.Status
+	if errors.As(err, &coapErr) {
+		code = coapconv.ToGrpcCode(coapErr.Code(), defaultCode)
+	}
+	s := status.New(code, err.Error())
+	if withDetails, errD := s.WithDetails(newErrorInfo(err, req)); errD == nil {
+		return withDetails
 	}
-	return status.New(defaultCode, err.Error())
+	return s
 }
@@ -0,0 +1,127 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcCodes "google.golang.org/grpc/codes"
+)
+
+func newCoapError(code codes.Code) error {
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(code)
+	return fmt.Errorf("cannot get resource: %w", coapStatus.Errorf(msg, "unexpected code %v", code))
+}
+
+func TestConvErrToGrpcStatus(t *testing.T) {
+	req := deviceRequest{
+		deviceID: "00000000-0000-0000-0000-000000000001",
+		href:     "/light/1",
+		endpoint: "coaps+tcp://127.0.0.1:5685",
+	}
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  grpcCodes.Code
+		reason    string
+		coapCode  string
+		retryable string
+	}{
+		{
+			name:      "accessDenied",
+			err:       newCoapError(codes.Forbidden),
+			wantCode:  grpcCodes.PermissionDenied,
+			reason:    ErrorReasonAccessDenied,
+			coapCode:  codes.Forbidden.String(),
+			retryable: "false",
+		},
+		{
+			name:      "serviceUnavailable",
+			err:       newCoapError(codes.ServiceUnavailable),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonDeviceError,
+			coapCode:  codes.ServiceUnavailable.String(),
+			retryable: "true",
+		},
+		{
+			name:      "timeout",
+			err:       fmt.Errorf("cannot get resource: %w", context.DeadlineExceeded),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonTimeout,
+			retryable: "true",
+		},
+		{
+			name:      "handshake",
+			err:       fmt.Errorf("cannot get resource: %w", &dtls.HandshakeError{Err: errors.New("bad certificate")}),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonHandshakeFailed,
+			retryable: "false",
+		},
+		{
+			name:      "unavailable",
+			err:       fmt.Errorf("cannot dial: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonUnavailable,
+			retryable: "true",
+		},
+		{
+			name:      "canceled",
+			err:       fmt.Errorf("cannot get resource: %w", context.Canceled),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonCanceled,
+			retryable: "false",
+		},
+		{
+			name:      "requestFailed",
+			err:       errors.New("invalid ownership transfer option"),
+			wantCode:  grpcCodes.Unavailable,
+			reason:    ErrorReasonRequestFailed,
+			retryable: "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := convErrToGrpcStatus(grpcCodes.Unavailable, tt.err, req)
+			require.Equal(t, tt.wantCode, s.Code())
+			require.Equal(t, tt.err.Error(), s.Message())
+			details := s.Details()
+			require.Len(t, details, 1)
+			info, ok := details[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, ErrorDomain, info.GetDomain())
+			require.Equal(t, tt.reason, info.GetReason())
+			metadata := info.GetMetadata()
+			require.Equal(t, req.deviceID, metadata[ErrorMetadataDeviceID])
+			require.Equal(t, req.href, metadata[ErrorMetadataHref])
+			require.Equal(t, req.endpoint, metadata[ErrorMetadataEndpoint])
+			require.Equal(t, tt.coapCode, metadata[ErrorMetadataCoapCode])
+			require.Equal(t, tt.retryable, metadata[ErrorMetadataRetryable])
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	serviceDevice "github.com/plgd-dev/client-application/service/device"
+)
+
+func (d *device) connectionsToProto(devService *serviceDevice.Service, now time.Time) []*pb.Connection {
+	conns := d.connections.list()
+	res := make([]*pb.Connection, 0, len(conns))
+	for _, c := range conns {
+		if c.conn.Context().Err() != nil {
+			continue
+		}
+		conn := &pb.Connection{
+			DeviceId:      d.ID.String(),
+			Scheme:        string(c.scheme),
+			RemoteAddress: c.conn.RemoteAddr().String(),
+			Secure:        c.isSecure(),
+			Age:           now.Sub(c.established).Nanoseconds(),
+			LastActivity:  c.established.UnixNano(),
+		}
+		if devService != nil {
+			if stats, ok := devService.GetConnectionStatistics(c.conn); ok {
+				conn.LastActivity = stats.LastActivity.UnixNano()
+				conn.BytesIn = stats.BytesIn
+				conn.BytesOut = stats.BytesOut
+			}
+		}
+		res = append(res, conn)
+	}
+	return res
+}
+
+func (s *ClientApplicationServer) GetConnections(_ context.Context, req *pb.GetConnectionsRequest) (*pb.GetConnectionsResponse, error) {
+	devs, err := s.getCachedDevices(req.GetDeviceIdFilter())
+	if err != nil {
+		return nil, err
+	}
+	devService := s.serviceDevice.Load()
+	now := time.Now()
+	conns := make([]*pb.Connection, 0, len(devs))
+	for _, dev := range devs {
+		conns = append(conns, dev.connectionsToProto(devService, now)...)
+	}
+	return &pb.GetConnectionsResponse{
+		Connections: conns,
+	}, nil
+}
@@ -0,0 +1,42 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/metadata"
+)
+
+func toDeviceMetadataProto(deviceID uuid.UUID, md metadata.Metadata) *pb.DeviceMetadata {
+	return &pb.DeviceMetadata{
+		DeviceId: deviceID.String(),
+		Alias:    md.Alias,
+		Labels:   md.Labels,
+		Groups:   md.Groups,
+	}
+}
+
+func (s *ClientApplicationServer) GetDeviceMetadata(_ context.Context, req *pb.GetDeviceMetadataRequest) (*pb.DeviceMetadata, error) {
+	devID, err := strDeviceID2UUID(req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	return toDeviceMetadataProto(devID, s.metadata.Get(devID)), nil
+}
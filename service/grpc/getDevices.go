@@ -23,6 +23,7 @@ import (
 	"io"
 	"net"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/plgd-dev/client-application/pb"
 	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	"github.com/plgd-dev/client-application/service/metadata"
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
 	"github.com/plgd-dev/device/v2/schema"
@@ -50,11 +52,15 @@ import (
 	pkgNet "github.com/plgd-dev/kit/v2/net"
 	kitStrings "github.com/plgd-dev/kit/v2/strings"
 	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	DefaultTimeout = 2 * time.Second
 	MulticastPort  = 5683
+	// maxConcurrentFilters limits the devices which are filtered at once.
+	maxConcurrentFilters = 16
 )
 
 func filterEndpoints(endpoints schema.Endpoints, ipv4TCPEndpoint schema.Endpoint, ipv4UDPEndpoint schema.Endpoint, ipv6TCPEndpoint schema.Endpoint, ipv6UDPEndpoint schema.Endpoint, ipv4secureTCPEndpoint schema.Endpoint, ipv4secureUDPEndpoint schema.Endpoint, ipv6secureTCPEndpoint schema.Endpoint, ipv6secureUDPEndpoint schema.Endpoint) (schema.Endpoint, schema.Endpoint, schema.Endpoint, schema.Endpoint, schema.Endpoint, schema.Endpoint, schema.Endpoint, schema.Endpoint) {
@@ -159,7 +165,7 @@ func getDeviceInfoFromLinks(links schema.ResourceLinks) map[string]*deviceInfo {
 	return devices
 }
 
-func processDiscoveryResourceResponse(serviceDevice *serviceDevice.Service, logger log.Logger, remoteAddr net.Addr, resp *pool.Message) (map[uuid.UUID]*device, error) {
+func processDiscoveryResourceResponse(serviceDevice *serviceDevice.Service, logger log.Logger, remoteAddr net.Addr, scheme schema.Scheme, resp *pool.Message) (map[uuid.UUID]*device, error) {
 	if resp.Code() != coapCodes.Content {
 		return nil, fmt.Errorf("unexpected response code: %d", resp.Code())
 	}
@@ -175,11 +181,11 @@ func processDiscoveryResourceResponse(serviceDevice *serviceDevice.Service, logg
 		return nil, errors.New("no links in response")
 	}
 
-	addr, err := pkgNet.ParseString(string(schema.UDPScheme), remoteAddr.String())
+	addr, err := pkgNet.ParseString(string(scheme), remoteAddr.String())
 	if err != nil {
 		return nil, err
 	}
-	links = links.PatchEndpoint(addr, schema.Endpoints{})
+	links = setLinkLocalZone(links, getRemoteZone(remoteAddr)).PatchEndpoint(addr, schema.Endpoints{})
 	deviceInfos := getDeviceInfoFromLinks(links)
 	devices := make(map[uuid.UUID]*device, len(deviceInfos))
 	for _, d := range deviceInfos {
@@ -198,8 +204,9 @@ func processDiscoveryResourceResponse(serviceDevice *serviceDevice.Service, logg
 	return devices, nil
 }
 
-func onDiscoveryResourceResponse(ctx context.Context, conn *client.Conn, serviceDevice *serviceDevice.Service, logger log.Logger, resp *pool.Message, devices *coapSync.Map[uuid.UUID, *device]) error {
-	discoveredDevices, err := processDiscoveryResourceResponse(serviceDevice, logger, conn.RemoteAddr(), resp)
+func onDiscoveryResourceResponse(ctx context.Context, conn *client.Conn, serviceDevice *serviceDevice.Service, logger log.Logger, resp *pool.Message, devices *foundDevices) error {
+	serviceDevice.CaptureMulticastResponse(conn.RemoteAddr(), resources.ResourceURI, []string{"rt=" + plgdDevice.ResourceType, "rt=" + doxm.ResourceType}, resp)
+	discoveredDevices, err := processDiscoveryResourceResponse(serviceDevice, logger, conn.RemoteAddr(), schema.UDPScheme, resp)
 	if err != nil {
 		return err
 	}
@@ -212,6 +219,7 @@ func onDiscoveryResourceResponse(ctx context.Context, conn *client.Conn, service
 		if err != nil {
 			d.ErrorFunc(fmt.Errorf("cannot get device resource content: %w", err))
 		}
+		devices.notify(d)
 	}
 	return nil
 }
@@ -277,29 +285,38 @@ func processDeviceResourceResponse(serviceDevice *serviceDevice.Service, logger
 	return device, nil
 }
 
-func toDiscoveryConfiguration(ipVersionFilter ipVersionFilter) core.DiscoveryConfiguration {
-	discoveryCfg := core.DefaultDiscoveryConfiguration()
-	if ipVersionFilter&ipv4 == 0 {
-		discoveryCfg.MulticastAddressUDP4 = nil
-	}
-	if ipVersionFilter&ipv6 == 0 {
-		discoveryCfg.MulticastAddressUDP6 = nil
-	}
-	return discoveryCfg
-}
-
-func getDevicesByMulticast(ctx context.Context, discoveryCfg core.DiscoveryConfiguration, onDiscoveryResourceResponse func(conn *client.Conn, resp *pool.Message)) {
-	err := discoverDiscoveryResources(ctx, discoveryCfg, onDiscoveryResourceResponse)
+func getDevicesByMulticast(ctx context.Context, discoveryCfg core.DiscoveryConfiguration, interfaces []string, onDiscoveryResourceResponse func(conn *client.Conn, resp *pool.Message)) {
+	err := discoverDiscoveryResourcesOnInterfaces(ctx, discoveryCfg, interfaces, onDiscoveryResourceResponse)
 	if err != nil {
 		log.Errorf("failed to discover device resources: %w", err)
 	}
 }
 
+var defaultEndpointPorts = map[schema.Scheme]int{
+	schema.UDPScheme:       MulticastPort,
+	schema.UDPSecureScheme: 5684,
+	schema.TCPScheme:       MulticastPort,
+	schema.TCPSecureScheme: 5684,
+}
+
 func normalizeEndpoint(endpoint string) (pkgNet.Addr, error) {
+	scheme := schema.UDPScheme
 	addressPort := endpoint
-	addr, err := pkgNet.ParseString(string(schema.UDPScheme), addressPort)
+	if s, a, ok := strings.Cut(endpoint, "://"); ok {
+		scheme = schema.Scheme(s)
+		addressPort = strings.TrimSuffix(a, "/")
+	}
+	port, ok := defaultEndpointPorts[scheme]
+	if !ok {
+		return pkgNet.Addr{}, fmt.Errorf("invalid endpoint: %s: unsupported scheme %v", endpoint, scheme)
+	}
+	if ip, _ := pkgNet.ParseIPZone(addressPort); ip != nil {
+		// IPv6 address, optionally with the zone, without brackets and port
+		addressPort = net.JoinHostPort(addressPort, strconv.Itoa(port))
+	}
+	addr, err := pkgNet.ParseString(string(scheme), addressPort)
 	if err != nil && strings.Contains(err.Error(), "missing port in address") {
-		addr, err = pkgNet.ParseString(string(schema.UDPScheme), fmt.Sprintf("%v:%v", addressPort, MulticastPort))
+		addr, err = pkgNet.ParseString(string(scheme), fmt.Sprintf("%v:%v", addressPort, port))
 	}
 	if err != nil {
 		return pkgNet.Addr{}, fmt.Errorf("invalid endpoint: %s", endpoint)
@@ -307,7 +324,13 @@ func normalizeEndpoint(endpoint string) (pkgNet.Addr, error) {
 	return addr, nil
 }
 
-func getDeviceByMulticastAddress(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, addr pkgNet.Addr, devices *coapSync.Map[uuid.UUID, *device]) error {
+func getDeviceByMulticastAddress(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, addr pkgNet.Addr, devices *foundDevices) error {
+	// the zone of the multicast address selects the interface
+	addr, zone := splitMulticastZone(addr)
+	interfaces := serviceDevice.GetDiscoveryConfig().MulticastInterfaces
+	if zone != "" {
+		interfaces = []string{zone}
+	}
 	hostname := addr.GetHostname()
 	if strings.Contains(hostname, ":") {
 		hostname = "[" + hostname + "]"
@@ -325,7 +348,7 @@ func getDeviceByMulticastAddress(ctx context.Context, serviceDevice *serviceDevi
 	discoveryResource := atomic.NewBool(false)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	getDevicesByMulticast(ctx, discoveryConfiguration, func(conn *client.Conn, resp *pool.Message) {
+	getDevicesByMulticast(ctx, discoveryConfiguration, interfaces, func(conn *client.Conn, resp *pool.Message) {
 		defer func() {
 			_ = conn.Close()
 		}()
@@ -363,9 +386,14 @@ func getDeviceResourceContent(ctx context.Context, uri string, serviceDevice *se
 		return nil
 	}
 	var response *pool.Message
+	endpoints := d.GetEndpoints().FilterUnsecureEndpoints()
+	if len(endpoints) == 0 {
+		// the device is reachable only by the secure endpoints
+		endpoints = d.GetEndpoints()
+	}
 	err := d.GetResourceWithCodec(ctx, schema.ResourceLink{
 		Href:      uri,
-		Endpoints: d.GetEndpoints().FilterUnsecureEndpoints(),
+		Endpoints: endpoints,
 	}, deviceResponseCodec{}, &response, coap.WithDeviceID(d.ID.String()))
 	if err != nil {
 		return err
@@ -378,8 +406,9 @@ func getDeviceResourceContent(ctx context.Context, uri string, serviceDevice *se
 	return nil
 }
 
-func getDeviceByAddress(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, addr pkgNet.Addr, devices *coapSync.Map[uuid.UUID, *device]) error {
-	if addr.GetPort() == MulticastPort {
+func getDeviceByAddress(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, addr pkgNet.Addr, devices *foundDevices) error {
+	scheme := schema.Scheme(addr.GetScheme())
+	if scheme == schema.UDPScheme && addr.GetPort() == MulticastPort {
 		return getDeviceByMulticastAddress(ctx, serviceDevice, logger, addr, devices)
 	}
 	hostname := addr.GetHostname()
@@ -387,6 +416,13 @@ func getDeviceByAddress(ctx context.Context, serviceDevice *serviceDevice.Servic
 		hostname = "[" + hostname + "]"
 	}
 	address := fmt.Sprintf("%s:%d", hostname, addr.GetPort())
+	if scheme == schema.UDPScheme {
+		return getDeviceByUnicastAddress(ctx, serviceDevice, logger, address, devices)
+	}
+	return getDeviceByEndpoint(ctx, serviceDevice, logger, scheme, address, devices)
+}
+
+func getDeviceByUnicastAddress(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, address string, devices *foundDevices) error {
 	client, err := udp.Dial(address, options.WithContext(ctx))
 	if err != nil {
 		return err
@@ -397,11 +433,32 @@ func getDeviceByAddress(ctx context.Context, serviceDevice *serviceDevice.Servic
 	opts := make(message.Options, 0, 2)
 	coap.WithResourceType(plgdDevice.ResourceType)(opts)
 	coap.WithResourceType(doxm.ResourceType)(opts)
-	resp, err := client.Get(ctx, resources.ResourceURI, opts...)
+	resp, err := serviceDevice.WrapConnection(client, schema.UDPScheme).Get(ctx, resources.ResourceURI, opts...)
 	if err != nil {
 		return err
 	}
-	discoveryRes, err := processDiscoveryResourceResponse(serviceDevice, logger, client.RemoteAddr(), resp)
+	return processDevicesOfDiscoveryResource(ctx, serviceDevice, logger, client.RemoteAddr(), schema.UDPScheme, resp, devices)
+}
+
+// getDeviceByEndpoint gets the discovery resource over TCP or over the secure connection established by the identity of the client application.
+func getDeviceByEndpoint(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, scheme schema.Scheme, address string, devices *foundDevices) error {
+	client, err := serviceDevice.DialEndpoint(ctx, scheme, address)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	var resp *pool.Message
+	err = client.GetResourceWithCodec(ctx, resources.ResourceURI, deviceResponseCodec{}, &resp, coap.WithResourceType(plgdDevice.ResourceType), coap.WithResourceType(doxm.ResourceType))
+	if err != nil {
+		return err
+	}
+	return processDevicesOfDiscoveryResource(ctx, serviceDevice, logger, client.RemoteAddr(), scheme, resp, devices)
+}
+
+func processDevicesOfDiscoveryResource(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, remoteAddr net.Addr, scheme schema.Scheme, resp *pool.Message, devices *foundDevices) error {
+	discoveryRes, err := processDiscoveryResourceResponse(serviceDevice, logger, remoteAddr, scheme, resp)
 	if err != nil {
 		return err
 	}
@@ -413,11 +470,26 @@ func getDeviceByAddress(ctx context.Context, serviceDevice *serviceDevice.Servic
 		if err != nil {
 			d.ErrorFunc(fmt.Errorf("cannot get device resource content: %w", err))
 		}
+		devices.notify(d)
 	}
 	return nil
 }
 
-func getDevicesByEndpoints(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, endpoints []string, devices *coapSync.Map[uuid.UUID, *device]) {
+// validateUseEndpoints rejects the endpoints which cannot be dialed by the configured authentication, the invalid endpoints are skipped by the discovery.
+func validateUseEndpoints(serviceDevice *serviceDevice.Service, endpoints []string) error {
+	for _, endpoint := range endpoints {
+		addr, err := normalizeEndpoint(endpoint)
+		if err != nil {
+			continue
+		}
+		if err := serviceDevice.ValidateEndpointScheme(schema.Scheme(addr.GetScheme())); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid useEndpoints('%v'): %v", endpoint, err)
+		}
+	}
+	return nil
+}
+
+func getDevicesByEndpoints(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, endpoints []string, devices *foundDevices) {
 	addresses := make([]pkgNet.Addr, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		addr, err := normalizeEndpoint(endpoint)
@@ -497,18 +569,18 @@ func filterByOwnershipStatus(device *grpcgwPb.Device, filteredOwnershipStatus []
 	return false
 }
 
-// If use_cache, use_multicast, use_endpoints are not set, then it will set use_multicast with [IPV4,IPV6].
+// If use_cache, use_multicast, use_endpoints, use_subnets, use_dns_sd are not set, then it will set use_multicast with [IPV4,IPV6].
 func tryToSetDefaultRequest(req *pb.GetDevicesRequest) *pb.GetDevicesRequest {
 	if req == nil {
 		req = &pb.GetDevicesRequest{}
 	}
-	if !req.GetUseCache() && len(req.GetUseMulticast()) == 0 && len(req.GetUseEndpoints()) == 0 {
+	if !req.GetUseCache() && len(req.GetUseMulticast()) == 0 && len(req.GetUseEndpoints()) == 0 && len(req.GetUseSubnets()) == 0 && !req.GetUseDnsSd() {
 		req.UseMulticast = []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV4, pb.GetDevicesRequest_IPV6}
 	}
 	return req
 }
 
-func (s *ClientApplicationServer) processDiscoverdDevices(discoveredDevices, cachedDevices *coapSync.Map[uuid.UUID, *device]) devices {
+func (s *ClientApplicationServer) processDiscoverdDevices(discoveredDevices *foundDevices, cachedDevices *coapSync.Map[uuid.UUID, *device]) devices {
 	devs := make(devices, 0, 128)
 	discoveredDevices.Range(func(key uuid.UUID, d *device) bool {
 		if len(d.GetEndpoints()) == 0 {
@@ -516,10 +588,7 @@ func (s *ClientApplicationServer) processDiscoverdDevices(discoveredDevices, cac
 			return true
 		}
 
-		updDevice, loaded := s.devices.LoadOrStore(key, d)
-		if loaded {
-			updDevice.update(d)
-		}
+		s.cacheDevice(d)
 		devs = append(devs, d)
 		cachedDevices.Delete(key)
 		return true
@@ -527,20 +596,79 @@ func (s *ClientApplicationServer) processDiscoverdDevices(discoveredDevices, cac
 	return devs
 }
 
-func sendDevices(req *pb.GetDevicesRequest, devs devices, send func(*grpcgwPb.Device) error) error {
-	devs.Sort()
-	for _, device := range devs {
-		d := device.ToProto()
-		if d.GetData().GetContent() == nil {
-			continue
-		}
-		if !filterByType(d, req.GetTypeFilter()) {
-			continue
+func filterByMetadata(md metadata.Metadata, labelSelectors []metadata.LabelSelector, groupFilter []string) bool {
+	if !metadata.MatchLabelSelectors(labelSelectors, md.Labels) {
+		return false
+	}
+	if len(groupFilter) == 0 {
+		return true
+	}
+	return md.HasOneOfGroups(groupFilter)
+}
+
+func toDeviceWithMetadata(d *grpcgwPb.Device, md metadata.Metadata) *pb.Device {
+	dev := pb.NewDevice(d)
+	dev.Alias = md.Alias
+	dev.Labels = md.Labels
+	dev.Groups = md.Groups
+	return dev
+}
+
+// filterDevice returns the device to send or nil when the device doesn't match the filter.
+func (s *ClientApplicationServer) filterDevice(ctx context.Context, filter *devicesFilter, device *device) *pb.Device {
+	d := device.ToProto()
+	if d.GetData().GetContent() == nil {
+		return nil
+	}
+	md, ok := s.match(ctx, filter, device, d)
+	if !ok {
+		return nil
+	}
+	dev := toDeviceWithMetadata(d, md)
+	dev.ActiveEndpoint, dev.FailedEndpoints = device.getEndpointsStatus()
+	return dev
+}
+
+func (s *ClientApplicationServer) sendDevice(ctx context.Context, filter *devicesFilter, device *device, send func(*pb.Device) error) error {
+	dev := s.filterDevice(ctx, filter, device)
+	if dev == nil {
+		return nil
+	}
+	return send(dev)
+}
+
+// filterDevices filters the devices concurrently, because the onboarding status filter requires a round trip to each device.
+// The result preserves the order of devs and contains nil for the filtered out devices.
+func (s *ClientApplicationServer) filterDevices(ctx context.Context, filter *devicesFilter, devs devices) []*pb.Device {
+	filtered := make([]*pb.Device, len(devs))
+	semaphore := make(chan struct{}, maxConcurrentFilters)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for i, dev := range devs {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return filtered
 		}
-		if !filterByOwnershipStatus(d, req.GetOwnershipStatusFilter()) {
+		wg.Add(1)
+		go func(i int, dev *device) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			filtered[i] = s.filterDevice(ctx, filter, dev)
+		}(i, dev)
+	}
+	return filtered
+}
+
+func (s *ClientApplicationServer) sendDevices(ctx context.Context, filter *devicesFilter, devs devices, send func(*pb.Device) error) error {
+	devs.Sort()
+	for _, dev := range s.filterDevices(ctx, filter, devs) {
+		if dev == nil {
 			continue
 		}
-		if err := send(d); err != nil {
+		if err := send(dev); err != nil {
 			return err
 		}
 	}
@@ -548,17 +676,40 @@ func sendDevices(req *pb.GetDevicesRequest, devs devices, send func(*grpcgwPb.De
 	return nil
 }
 
+// getDiscoveryTimeout returns the timeout of the discovery requested by req.
+func getDiscoveryTimeout(req *pb.GetDevicesRequest) time.Duration {
+	if req.GetTimeout() > 0 {
+		return time.Duration(req.GetTimeout())
+	}
+	return DefaultTimeout
+}
+
 func (s *ClientApplicationServer) GetDevices(req *pb.GetDevicesRequest, srv pb.ClientApplication_GetDevicesServer) error {
 	req = tryToSetDefaultRequest(req)
+	filter, err := newDevicesFilter(req)
+	if err != nil {
+		return err
+	}
 	ctx := srv.Context()
+	start := time.Now()
+	sender := newDevicesSender(srv.Send, req.GetStreamIncrementally())
+	onDevice := func(d *device) {
+		if len(d.GetEndpoints()) == 0 {
+			return
+		}
+		s.cacheDevice(d)
+		if err := s.sendDevice(ctx, filter, d, sender.Send); err != nil {
+			s.logger.Debugf("cannot send device %v: %v", d.ID, err)
+		}
+	}
+	var onDiscoveredDevice func(d *device)
+	if req.GetStreamIncrementally() {
+		onDiscoveredDevice = onDevice
+	}
 	var toCall []func()
-	discoveredDevices := coapSync.NewMap[uuid.UUID, *device]()
+	discoveredDevices := newFoundDevices(onDiscoveredDevice)
 	cachedDevices := coapSync.NewMap[uuid.UUID, *device]()
-	timeout := DefaultTimeout
-	if req.GetTimeout() > 0 {
-		timeout = time.Duration(req.GetTimeout())
-	}
-	discoveryCtx, cancel := context.WithTimeout(ctx, timeout)
+	discoveryCtx, cancel := context.WithTimeout(ctx, getDiscoveryTimeout(req))
 	defer cancel()
 	if req.GetUseCache() {
 		s.devices.Range(func(key uuid.UUID, value *device) bool {
@@ -572,8 +723,9 @@ func (s *ClientApplicationServer) GetDevices(req *pb.GetDevicesRequest, srv pb.C
 		if devService == nil {
 			return errors.New("cannot get devices: device service is not initialized")
 		}
+		interfaces, scopes := getMulticastConfig(req, devService.GetDiscoveryConfig())
 		toCall = append(toCall, func() {
-			getDevicesByMulticast(discoveryCtx, toDiscoveryConfiguration(toUseMulticastFilter(req.GetUseMulticast())), func(conn *client.Conn, resp *pool.Message) {
+			getDevicesByMulticast(discoveryCtx, toDiscoveryConfiguration(toUseMulticastFilter(req.GetUseMulticast()), scopes), interfaces, func(conn *client.Conn, resp *pool.Message) {
 				defer func() {
 					_ = conn.Close()
 				}()
@@ -587,10 +739,36 @@ func (s *ClientApplicationServer) GetDevices(req *pb.GetDevicesRequest, srv pb.C
 		if devService == nil {
 			return errors.New("cannot get devices: device service is not initialized")
 		}
+		if err := validateUseEndpoints(devService, req.GetUseEndpoints()); err != nil {
+			return err
+		}
 		toCall = append(toCall, func() {
 			getDevicesByEndpoints(discoveryCtx, devService, s.logger, req.GetUseEndpoints(), discoveredDevices)
 		})
 	}
+	if len(req.GetUseSubnets()) > 0 {
+		devService := s.serviceDevice.Load()
+		if devService == nil {
+			return errors.New("cannot get devices: device service is not initialized")
+		}
+		cfg := devService.GetDiscoveryConfig().SubnetSweep
+		hosts, err := getSubnetHosts(req.GetUseSubnets(), cfg.MaxHosts)
+		if err != nil {
+			return err
+		}
+		toCall = append(toCall, func() {
+			getDevicesBySubnetSweep(discoveryCtx, devService, s.logger, hosts, cfg, discoveredDevices)
+		})
+	}
+	if req.GetUseDnsSd() {
+		devService := s.serviceDevice.Load()
+		if devService == nil {
+			return errors.New("cannot get devices: device service is not initialized")
+		}
+		toCall = append(toCall, func() {
+			getDevicesByDNSSD(discoveryCtx, devService, s.logger, devService.GetDiscoveryConfig().DNSSD, discoveredDevices)
+		})
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(len(toCall))
@@ -603,9 +781,20 @@ func (s *ClientApplicationServer) GetDevices(req *pb.GetDevicesRequest, srv pb.C
 	wg.Wait()
 
 	devs := s.processDiscoverdDevices(discoveredDevices, cachedDevices)
+	statistics := &pb.DiscoveryStatistics{
+		DiscoveredDevices: uint32(len(devs)),
+	}
 	cachedDevices.Range(func(_ uuid.UUID, d *device) bool {
 		devs = append(devs, d)
+		statistics.CachedDevices++
 		return true
 	})
-	return sendDevices(req, devs, srv.Send)
+	if err := s.sendDevices(ctx, filter, devs, sender.Send); err != nil {
+		return err
+	}
+	if !req.GetStreamIncrementally() {
+		return nil
+	}
+	statistics.Duration = time.Since(start).Nanoseconds()
+	return sender.SendEndOfDiscovery(statistics)
 }
@@ -0,0 +1,226 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/metadata"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	plgdDevice "github.com/plgd-dev/device/v2/schema/device"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var supportedSchemes = []string{string(schema.UDPScheme), string(schema.UDPSecureScheme), string(schema.TCPScheme), string(schema.TCPSecureScheme)}
+
+// devicesFilter contains the validated filters of GetDevicesRequest.
+type devicesFilter struct {
+	typeFilter             []string
+	ownershipStatusFilter  []pb.GetDevicesRequest_OwnershipStatusFilter
+	labelSelectors         []metadata.LabelSelector
+	groupFilter            []string
+	nameFilter             string
+	nameRegexFilter        *regexp.Regexp
+	deviceIDPrefixFilter   []string
+	subnetFilter           []netip.Prefix
+	schemeFilter           []string
+	onboardingStatusFilter []pb.GetDevicesRequest_OnboardingStatusFilter
+	// onboardingStatusTimeout bounds the lookup of the onboarding status of one device.
+	onboardingStatusTimeout time.Duration
+}
+
+func newDevicesFilter(req *pb.GetDevicesRequest) (*devicesFilter, error) {
+	f := devicesFilter{
+		typeFilter:              req.GetTypeFilter(),
+		ownershipStatusFilter:   req.GetOwnershipStatusFilter(),
+		groupFilter:             req.GetGroupFilter(),
+		nameFilter:              strings.ToLower(req.GetNameFilter()),
+		onboardingStatusFilter:  req.GetOnboardingStatusFilter(),
+		onboardingStatusTimeout: getDiscoveryTimeout(req),
+	}
+	var err error
+	f.labelSelectors, err = metadata.ParseLabelSelectors(req.GetLabelSelector())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid labelSelector: %v", err)
+	}
+	if req.GetNameRegexFilter() != "" {
+		f.nameRegexFilter, err = regexp.Compile(req.GetNameRegexFilter())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid nameRegexFilter('%v'): %v", req.GetNameRegexFilter(), err)
+		}
+	}
+	for _, prefix := range req.GetDeviceIdPrefixFilter() {
+		f.deviceIDPrefixFilter = append(f.deviceIDPrefixFilter, strings.ToLower(prefix))
+	}
+	for _, subnet := range req.GetSubnetFilter() {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid subnetFilter('%v'): %v", subnet, err)
+		}
+		f.subnetFilter = append(f.subnetFilter, prefix.Masked())
+	}
+	for _, scheme := range req.GetSchemeFilter() {
+		scheme = strings.ToLower(scheme)
+		if !slices.Contains(supportedSchemes, scheme) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid schemeFilter('%v'): supported values are %v", scheme, supportedSchemes)
+		}
+		f.schemeFilter = append(f.schemeFilter, scheme)
+	}
+	return &f, nil
+}
+
+func getDeviceName(d *grpcgwPb.Device) string {
+	var dev plgdDevice.Device
+	if err := cbor.Decode(d.GetData().GetContent().GetData(), &dev); err != nil {
+		return ""
+	}
+	return dev.Name
+}
+
+func filterByName(device *grpcgwPb.Device, nameFilter string, nameRegexFilter *regexp.Regexp) bool {
+	if nameFilter == "" && nameRegexFilter == nil {
+		return true
+	}
+	name := getDeviceName(device)
+	if nameFilter != "" && !strings.Contains(strings.ToLower(name), nameFilter) {
+		return false
+	}
+	if nameRegexFilter != nil && !nameRegexFilter.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+func filterByDeviceIDPrefix(device *grpcgwPb.Device, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	id := strings.ToLower(device.GetId())
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func getEndpointAddr(endpoint string) (string, netip.Addr, bool) {
+	addr, err := schema.Endpoint{URI: endpoint}.GetAddr()
+	if err != nil {
+		return "", netip.Addr{}, false
+	}
+	ip, err := netip.ParseAddr(addr.GetHostname())
+	if err != nil {
+		return addr.GetScheme(), netip.Addr{}, true
+	}
+	return addr.GetScheme(), ip.WithZone("").Unmap(), true
+}
+
+func filterBySubnet(device *grpcgwPb.Device, subnets []netip.Prefix) bool {
+	if len(subnets) == 0 {
+		return true
+	}
+	for _, endpoint := range device.GetEndpoints() {
+		_, ip, ok := getEndpointAddr(endpoint)
+		if !ok || !ip.IsValid() {
+			continue
+		}
+		for _, subnet := range subnets {
+			if subnet.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func filterByScheme(device *grpcgwPb.Device, schemes []string) bool {
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, endpoint := range device.GetEndpoints() {
+		scheme, _, ok := getEndpointAddr(endpoint)
+		if ok && slices.Contains(schemes, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func toOnboardingStatusFilter(provisioningStatus cloud.ProvisioningStatus) (pb.GetDevicesRequest_OnboardingStatusFilter, bool) {
+	switch provisioningStatus {
+	case cloud.ProvisioningStatus_UNINITIALIZED, cloud.ProvisioningStatus_READY_TO_REGISTER, "":
+		return pb.GetDevicesRequest_OFFBOARDED, true
+	case cloud.ProvisioningStatus_REGISTERING:
+		return pb.GetDevicesRequest_ONBOARDING, true
+	case cloud.ProvisioningStatus_REGISTERED:
+		return pb.GetDevicesRequest_ONBOARDED, true
+	case cloud.ProvisioningStatus_FAILED:
+		return pb.GetDevicesRequest_ONBOARDING_FAILED, true
+	}
+	return pb.GetDevicesRequest_OFFBOARDED, false
+}
+
+func (s *ClientApplicationServer) filterByOnboardingStatus(ctx context.Context, dev *device, filteredOnboardingStatus []pb.GetDevicesRequest_OnboardingStatusFilter, timeout time.Duration) bool {
+	if len(filteredOnboardingStatus) == 0 {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cfg, err := s.getCloudConfiguration(ctx, dev.ID)
+	if err != nil {
+		dev.ErrorFunc(fmt.Errorf("cannot get onboarding status: %w", err))
+		return false
+	}
+	onboardingStatus, ok := toOnboardingStatusFilter(cfg.ProvisioningStatus)
+	if !ok {
+		return false
+	}
+	return slices.Contains(filteredOnboardingStatus, onboardingStatus)
+}
+
+// match returns true when the device satisfies all filters. The metadata of the device are returned to avoid reading them again.
+func (s *ClientApplicationServer) match(ctx context.Context, f *devicesFilter, dev *device, d *grpcgwPb.Device) (metadata.Metadata, bool) {
+	if !filterByType(d, f.typeFilter) ||
+		!filterByOwnershipStatus(d, f.ownershipStatusFilter) ||
+		!filterByDeviceIDPrefix(d, f.deviceIDPrefixFilter) ||
+		!filterByScheme(d, f.schemeFilter) ||
+		!filterBySubnet(d, f.subnetFilter) ||
+		!filterByName(d, f.nameFilter, f.nameRegexFilter) {
+		return metadata.Metadata{}, false
+	}
+	md := s.metadata.Get(dev.ID)
+	if !filterByMetadata(md, f.labelSelectors, f.groupFilter) {
+		return metadata.Metadata{}, false
+	}
+	// the onboarding status requires communication with the device so it is checked as the last one
+	if !s.filterByOnboardingStatus(ctx, dev, f.onboardingStatusFilter, f.onboardingStatusTimeout) {
+		return metadata.Metadata{}, false
+	}
+	return md, true
+}
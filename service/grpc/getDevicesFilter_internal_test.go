@@ -0,0 +1,158 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	plgdDevice "github.com/plgd-dev/device/v2/schema/device"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/events"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDevice(t *testing.T, name string, endpoints []string) *grpcgwPb.Device {
+	data, err := cbor.Encode(plgdDevice.Device{ID: "9c4a3d5b-1f2e-4a6b-8c7d-0e1f2a3b4c5d", Name: name})
+	require.NoError(t, err)
+	return &grpcgwPb.Device{
+		Id:        "9c4a3d5b-1f2e-4a6b-8c7d-0e1f2a3b4c5d",
+		Endpoints: endpoints,
+		Data: &events.ResourceChanged{
+			Content: &commands.Content{Data: data},
+		},
+	}
+}
+
+func TestNewDevicesFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *pb.GetDevicesRequest
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			req: &pb.GetDevicesRequest{
+				NameFilter:           "light",
+				NameRegexFilter:      "^light-[0-9]+$",
+				DeviceIdPrefixFilter: []string{"9C4A"},
+				SubnetFilter:         []string{"192.168.1.0/24", "fd00::/8"},
+				SchemeFilter:         []string{"coaps+tcp", "COAP"},
+				LabelSelector:        []string{"room=kitchen"},
+			},
+		},
+		{
+			name:    "invalid regex",
+			req:     &pb.GetDevicesRequest{NameRegexFilter: "(light"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid subnet",
+			req:     &pb.GetDevicesRequest{SubnetFilter: []string{"192.168.1.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			req:     &pb.GetDevicesRequest{SchemeFilter: []string{"http"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid label selector",
+			req:     &pb.GetDevicesRequest{LabelSelector: []string{"=a"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newDevicesFilter(tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewDevicesFilterOnboardingStatusTimeout(t *testing.T) {
+	f, err := newDevicesFilter(&pb.GetDevicesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultTimeout, f.onboardingStatusTimeout)
+
+	f, err = newDevicesFilter(&pb.GetDevicesRequest{Timeout: int64(5 * time.Second)})
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, f.onboardingStatusTimeout)
+}
+
+func TestDevicesFilters(t *testing.T) {
+	dev := newTestDevice(t, "Kitchen Light-1", []string{"coap://192.168.1.10:5683", "coaps+tcp://[fe80::1%25eth0]:5684"})
+	f, err := newDevicesFilter(&pb.GetDevicesRequest{
+		NameFilter:           "LIGHT",
+		NameRegexFilter:      "-[0-9]$",
+		DeviceIdPrefixFilter: []string{"0000", "9C4A"},
+		SubnetFilter:         []string{"192.168.1.0/24"},
+		SchemeFilter:         []string{"coaps+tcp"},
+	})
+	require.NoError(t, err)
+	require.True(t, filterByName(dev, f.nameFilter, f.nameRegexFilter))
+	require.True(t, filterByDeviceIDPrefix(dev, f.deviceIDPrefixFilter))
+	require.True(t, filterBySubnet(dev, f.subnetFilter))
+	require.True(t, filterByScheme(dev, f.schemeFilter))
+
+	f, err = newDevicesFilter(&pb.GetDevicesRequest{
+		NameFilter:           "sensor",
+		DeviceIdPrefixFilter: []string{"0000"},
+		SubnetFilter:         []string{"10.0.0.0/8", "fe80::/10"},
+		SchemeFilter:         []string{"coaps"},
+	})
+	require.NoError(t, err)
+	require.False(t, filterByName(dev, f.nameFilter, f.nameRegexFilter))
+	require.False(t, filterByDeviceIDPrefix(dev, f.deviceIDPrefixFilter))
+	require.True(t, filterBySubnet(dev, f.subnetFilter))
+	require.False(t, filterByScheme(dev, f.schemeFilter))
+
+	f, err = newDevicesFilter(&pb.GetDevicesRequest{SubnetFilter: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+	require.False(t, filterBySubnet(dev, f.subnetFilter))
+}
+
+func TestToOnboardingStatusFilter(t *testing.T) {
+	tests := []struct {
+		status cloud.ProvisioningStatus
+		want   pb.GetDevicesRequest_OnboardingStatusFilter
+		ok     bool
+	}{
+		{status: "", want: pb.GetDevicesRequest_OFFBOARDED, ok: true},
+		{status: cloud.ProvisioningStatus_UNINITIALIZED, want: pb.GetDevicesRequest_OFFBOARDED, ok: true},
+		{status: cloud.ProvisioningStatus_READY_TO_REGISTER, want: pb.GetDevicesRequest_OFFBOARDED, ok: true},
+		{status: cloud.ProvisioningStatus_REGISTERING, want: pb.GetDevicesRequest_ONBOARDING, ok: true},
+		{status: cloud.ProvisioningStatus_REGISTERED, want: pb.GetDevicesRequest_ONBOARDED, ok: true},
+		{status: cloud.ProvisioningStatus_FAILED, want: pb.GetDevicesRequest_ONBOARDING_FAILED, ok: true},
+		{status: "unknown"},
+	}
+	for _, tt := range tests {
+		got, ok := toOnboardingStatusFilter(tt.status)
+		require.Equal(t, tt.ok, ok)
+		if ok {
+			require.Equal(t, tt.want, got)
+		}
+	}
+}
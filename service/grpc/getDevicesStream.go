@@ -0,0 +1,104 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+// foundDevices collects the devices found by the discovery and notifies about the devices after their device resource is fetched.
+type foundDevices struct {
+	*coapSync.Map[uuid.UUID, *device]
+	onDevice func(d *device)
+}
+
+func newFoundDevices(onDevice func(d *device)) *foundDevices {
+	return &foundDevices{
+		Map:      coapSync.NewMap[uuid.UUID, *device](),
+		onDevice: onDevice,
+	}
+}
+
+func (f *foundDevices) notify(d *device) {
+	if f.onDevice != nil {
+		f.onDevice(d)
+	}
+}
+
+// cacheDevice stores the device to the cache, so it can be used before the end of the discovery.
+func (s *ClientApplicationServer) cacheDevice(d *device) {
+	cached, loaded := s.devices.LoadOrStore(d.ID, d)
+	if loaded && cached != d {
+		cached.update(d)
+	}
+}
+
+// devicesSender serializes sending of the devices to the stream. Each device is sent only once, in the incremental mode
+// the changed device is sent again with the event UPDATED.
+type devicesSender struct {
+	mutex       sync.Mutex
+	send        func(*pb.Device) error
+	incremental bool
+	sent        map[string]*pb.Device
+	updates     uint32
+}
+
+func newDevicesSender(send func(*pb.Device) error, incremental bool) *devicesSender {
+	return &devicesSender{
+		send:        send,
+		incremental: incremental,
+		sent:        make(map[string]*pb.Device),
+	}
+}
+
+func (s *devicesSender) Send(dev *pb.Device) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	prev, ok := s.sent[dev.GetId()]
+	switch {
+	case ok && !s.incremental:
+		return nil
+	case ok:
+		dev.Event = prev.GetEvent()
+		if proto.Equal(prev, dev) {
+			return nil
+		}
+		dev.Event = pb.Device_UPDATED
+		s.updates++
+	case s.incremental:
+		dev.Event = pb.Device_DISCOVERED
+	}
+	s.sent[dev.GetId()] = dev
+	return s.send(dev)
+}
+
+// SendEndOfDiscovery sends the last message of the incremental mode with the statistics.
+func (s *devicesSender) SendEndOfDiscovery(statistics *pb.DiscoveryStatistics) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	statistics.SentDevices = uint32(len(s.sent))
+	statistics.Updates = s.updates
+	return s.send(&pb.Device{
+		Event:      pb.Device_END_OF_DISCOVERY,
+		Statistics: statistics,
+	})
+}
@@ -0,0 +1,57 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevicesSender(t *testing.T) {
+	var sent []*pb.Device
+	send := func(d *pb.Device) error {
+		sent = append(sent, d)
+		return nil
+	}
+
+	sender := newDevicesSender(send, false)
+	require.NoError(t, sender.Send(&pb.Device{Id: "a", Name: "a"}))
+	require.NoError(t, sender.Send(&pb.Device{Id: "a", Name: "b"}))
+	require.Len(t, sent, 1)
+	require.Equal(t, pb.Device_NONE, sent[0].GetEvent())
+
+	sent = nil
+	sender = newDevicesSender(send, true)
+	require.NoError(t, sender.Send(&pb.Device{Id: "a", Name: "a"}))
+	require.NoError(t, sender.Send(&pb.Device{Id: "a", Name: "a"}))
+	require.NoError(t, sender.Send(&pb.Device{Id: "b", Name: "b"}))
+	require.NoError(t, sender.Send(&pb.Device{Id: "a", Name: "a", Endpoints: []string{"coap://127.0.0.1:5683"}}))
+	require.NoError(t, sender.SendEndOfDiscovery(&pb.DiscoveryStatistics{DiscoveredDevices: 2}))
+	require.Len(t, sent, 4)
+	require.Equal(t, pb.Device_DISCOVERED, sent[0].GetEvent())
+	require.Equal(t, pb.Device_DISCOVERED, sent[1].GetEvent())
+	require.Equal(t, "a", sent[2].GetId())
+	require.Equal(t, pb.Device_UPDATED, sent[2].GetEvent())
+	require.Equal(t, pb.Device_END_OF_DISCOVERY, sent[3].GetEvent())
+	require.Equal(t, &pb.DiscoveryStatistics{
+		DiscoveredDevices: 2,
+		SentDevices:       2,
+		Updates:           1,
+	}, sent[3].GetStatistics())
+}
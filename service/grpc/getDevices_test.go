@@ -20,16 +20,21 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/simulator"
 	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/device/v2/schema"
 	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestClientApplicationServerCheckForClosingInactivityConnection(t *testing.T) {
@@ -87,7 +92,7 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 		name    string
 		args    args
 		wantErr bool
-		want    []*grpcgwPb.Device
+		want    []*pb.Device
 	}{
 		{
 			name: "by multicast",
@@ -97,8 +102,8 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 				},
 				srv: test.NewClientApplicationGetDevicesServer(ctx),
 			},
-			want: []*grpcgwPb.Device{
-				device,
+			want: []*pb.Device{
+				pb.NewDevice(device),
 			},
 		},
 		{
@@ -109,8 +114,8 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 				},
 				srv: test.NewClientApplicationGetDevicesServer(ctx),
 			},
-			want: []*grpcgwPb.Device{
-				device,
+			want: []*pb.Device{
+				pb.NewDevice(device),
 			},
 		},
 		{
@@ -121,10 +126,38 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 				},
 				srv: test.NewClientApplicationGetDevicesServer(ctx),
 			},
-			want: []*grpcgwPb.Device{
-				device,
+			want: []*pb.Device{
+				pb.NewDevice(device),
 			},
 		},
+		{
+			name: "by ip with filters",
+			args: args{
+				req: &pb.GetDevicesRequest{
+					UseEndpoints:         []string{u.Hostname()},
+					NameFilter:           strings.ToUpper(test.DevsimName),
+					NameRegexFilter:      "^" + regexp.QuoteMeta(test.DevsimName) + "$",
+					DeviceIdPrefixFilter: []string{device.GetId()[:8]},
+					SubnetFilter:         []string{u.Hostname() + "/32"},
+					SchemeFilter:         []string{"coaps+tcp"},
+				},
+				srv: test.NewClientApplicationGetDevicesServer(ctx),
+			},
+			want: []*pb.Device{
+				pb.NewDevice(device),
+			},
+		},
+		{
+			name: "invalid filter",
+			args: args{
+				req: &pb.GetDevicesRequest{
+					UseEndpoints: []string{u.Hostname()},
+					SubnetFilter: []string{u.Hostname()},
+				},
+				srv: test.NewClientApplicationGetDevicesServer(ctx),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -146,7 +179,74 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 			require.True(t, strings.Contains(got[0].GetEndpoints()[1], "coap+tcp://"))
 			require.True(t, strings.Contains(got[0].GetEndpoints()[2], "coaps://"))
 			require.True(t, strings.Contains(got[0].GetEndpoints()[3], "coaps+tcp://"))
+			require.Empty(t, got[0].GetFailedEndpoints())
+			for _, d := range got {
+				// the active endpoint depends on the connection used during the discovery
+				d.ActiveEndpoint = ""
+			}
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func getSimulatorEndpoint(t *testing.T, dev *simulator.Device, scheme schema.Scheme) string {
+	for _, ep := range dev.Endpoints() {
+		if addr, err := ep.GetAddr(); err == nil && addr.GetScheme() == string(scheme) {
+			return ep.URI
+		}
+	}
+	require.FailNowf(t, "endpoint not found", "device %v has no %v endpoint", dev.Name(), scheme)
+	return ""
+}
+
+func TestClientApplicationServerGetDevicesByEndpointOnSimulator(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+	simDev := sim.Devices()[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+
+	getDevices := func(endpoint string) ([]*pb.Device, error) {
+		srv := test.NewClientApplicationGetDevicesServer(ctx)
+		err := s.GetDevices(&pb.GetDevicesRequest{
+			UseEndpoints: []string{endpoint},
+			Timeout:      time.Second.Nanoseconds(),
+		}, srv)
+		return srv.Devices, err
+	}
+
+	tcpEndpoint := getSimulatorEndpoint(t, simDev, schema.TCPScheme)
+	devs, err := getDevices(tcpEndpoint)
+	require.NoError(t, err)
+	require.Len(t, devs, 1)
+	require.Equal(t, simDev.ID().String(), devs[0].GetId())
+
+	// the pre-shared key authentication cannot establish the TLS connection, so the endpoint is rejected before it is dialed
+	_, err = getDevices(strings.Replace(tcpEndpoint, string(schema.TCPScheme), string(schema.TCPSecureScheme), 1))
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.OwnDevice(ctx, &pb.OwnDeviceRequest{
+		DeviceId: simDev.ID().String(),
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err = s.DisownDevice(ctx, &pb.DisownDeviceRequest{
+			DeviceId: simDev.ID().String(),
+		})
+		require.NoError(t, err)
+	}()
+
+	devs, err = getDevices(getSimulatorEndpoint(t, simDev, schema.UDPSecureScheme))
+	require.NoError(t, err)
+	require.Len(t, devs, 1)
+	require.Equal(t, simDev.ID().String(), devs[0].GetId())
+	require.Equal(t, grpcgwPb.Device_OWNED, devs[0].GetOwnershipStatus())
+}
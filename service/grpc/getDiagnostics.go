@@ -0,0 +1,212 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/logbuffer"
+	"github.com/plgd-dev/client-application/pkg/tar"
+	"github.com/plgd-dev/hub/v2/pkg/config/property/urischeme"
+	pkgX509 "github.com/plgd-dev/hub/v2/pkg/security/x509"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Names of the files in the diagnostics archive.
+const (
+	DiagnosticsConfigFile       = "config.yaml"
+	DiagnosticsBuildInfoFile    = "buildInfo.json"
+	DiagnosticsDevicesFile      = "devices.json"
+	DiagnosticsLogsFile         = "logs.txt"
+	DiagnosticsGoroutineFile    = "goroutine.pprof"
+	DiagnosticsHeapFile         = "heap.pprof"
+	DiagnosticsCertificatesFile = "certificates.json"
+)
+
+type certificateSummary struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// certificatesSummary describes the certificate chain used by the identity or the listener.
+type certificatesSummary struct {
+	Name         string               `json:"name"`
+	Certificates []certificateSummary `json:"certificates,omitempty"`
+	Error        string               `json:"error,omitempty"`
+}
+
+func makeCertificatesSummary(name string, certs []*x509.Certificate, err error) certificatesSummary {
+	if err != nil {
+		return certificatesSummary{
+			Name:  name,
+			Error: err.Error(),
+		}
+	}
+	summaries := make([]certificateSummary, 0, len(certs))
+	for _, c := range certs {
+		summaries = append(summaries, certificateSummary{
+			Subject:   c.Subject.String(),
+			Issuer:    c.Issuer.String(),
+			NotBefore: c.NotBefore,
+			NotAfter:  c.NotAfter,
+		})
+	}
+	return certificatesSummary{
+		Name:         name,
+		Certificates: summaries,
+	}
+}
+
+func parseTLSCertificate(crt tls.Certificate) ([]*x509.Certificate, error) {
+	certs := make([]*x509.Certificate, 0, len(crt.Certificate))
+	for _, der := range crt.Certificate {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, nil
+}
+
+func readCertificates(certFile urischeme.URIScheme) ([]*x509.Certificate, error) {
+	data, err := certFile.Read()
+	if err != nil {
+		return nil, err
+	}
+	return pkgX509.ParseX509(data)
+}
+
+func (s *ClientApplicationServer) getCertificatesSummary() []certificatesSummary {
+	summaries := make([]certificatesSummary, 0, 3)
+	if devService := s.serviceDevice.Load(); devService != nil {
+		crt, err := devService.GetIdentityCertificate()
+		var certs []*x509.Certificate
+		if err == nil {
+			certs, err = parseTLSCertificate(crt)
+		}
+		summaries = append(summaries, makeCertificatesSummary("identity", certs, err))
+	}
+	cfg := s.GetConfig()
+	if cfg.APIs.HTTP.Enabled && cfg.APIs.HTTP.TLS.Enabled {
+		certs, err := readCertificates(cfg.APIs.HTTP.TLS.CertFile)
+		summaries = append(summaries, makeCertificatesSummary("apis.http", certs, err))
+	}
+	if cfg.APIs.GRPC.Enabled && cfg.APIs.GRPC.TLS.Enabled {
+		certs, err := readCertificates(cfg.APIs.GRPC.TLS.CertFile)
+		summaries = append(summaries, makeCertificatesSummary("apis.grpc", certs, err))
+	}
+	return summaries
+}
+
+func (s *ClientApplicationServer) getDevicesDiagnostics() ([]byte, error) {
+	devs := make(devices, 0, 32)
+	s.devices.Range(func(_ uuid.UUID, dev *device) bool {
+		devs = append(devs, dev)
+		return true
+	})
+	devs.Sort()
+	data := make([]json.RawMessage, 0, len(devs))
+	for _, dev := range devs {
+		d, err := protojson.Marshal(dev.ToProto())
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal device %v: %w", dev.ID, err)
+		}
+		data = append(data, d)
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func getProfile(name string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup(name).WriteTo(&buf, 0); err != nil {
+		return nil, fmt.Errorf("cannot write %v profile: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *ClientApplicationServer) getLogLines() []byte {
+	l, ok := s.logger.(*logbuffer.Logger)
+	if !ok {
+		return nil
+	}
+	lines := l.Buffer().Lines()
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func (s *ClientApplicationServer) getDiagnosticsFiles() ([]tar.File, error) {
+	buildInfo, err := protojson.MarshalOptions{Multiline: true}.Marshal(s.info.GetBuildInfo())
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal build info: %w", err)
+	}
+	devs, err := s.getDevicesDiagnostics()
+	if err != nil {
+		return nil, err
+	}
+	goroutine, err := getProfile("goroutine")
+	if err != nil {
+		return nil, err
+	}
+	heap, err := getProfile("heap")
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := json.MarshalIndent(s.getCertificatesSummary(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal certificates: %w", err)
+	}
+	return []tar.File{
+		{Name: DiagnosticsConfigFile, Data: []byte(s.GetConfig().Redacted().String())},
+		{Name: DiagnosticsBuildInfoFile, Data: buildInfo},
+		{Name: DiagnosticsDevicesFile, Data: devs},
+		{Name: DiagnosticsLogsFile, Data: s.getLogLines()},
+		{Name: DiagnosticsGoroutineFile, Data: goroutine},
+		{Name: DiagnosticsHeapFile, Data: heap},
+		{Name: DiagnosticsCertificatesFile, Data: certificates},
+	}, nil
+}
+
+func (s *ClientApplicationServer) GetDiagnostics(context.Context, *pb.GetDiagnosticsRequest) (*pb.GetDiagnosticsResponse, error) {
+	files, err := s.getDiagnosticsFiles()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get diagnostics: %v", err)
+	}
+	var buf bytes.Buffer
+	if err = tar.TarFiles(files, &buf); err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get diagnostics: %v", err)
+	}
+	return &pb.GetDiagnosticsResponse{
+		Data: buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,118 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/tar"
+	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
+	"github.com/plgd-dev/client-application/service/config/owners"
+	serviceGrpc "github.com/plgd-dev/client-application/service/grpc"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestClientApplicationServerGetDiagnostics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	err = s.GetDevices(&pb.GetDevicesRequest{}, test.NewClientApplicationGetDevicesServer(ctx))
+	require.NoError(t, err)
+
+	resp, err := s.GetDiagnostics(ctx, &pb.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	err = tar.Untar(dir, bytes.NewReader(resp.GetData()))
+	require.NoError(t, err)
+
+	for _, f := range []string{
+		serviceGrpc.DiagnosticsConfigFile,
+		serviceGrpc.DiagnosticsBuildInfoFile,
+		serviceGrpc.DiagnosticsDevicesFile,
+		serviceGrpc.DiagnosticsLogsFile,
+		serviceGrpc.DiagnosticsGoroutineFile,
+		serviceGrpc.DiagnosticsHeapFile,
+		serviceGrpc.DiagnosticsCertificatesFile,
+	} {
+		_, err = os.Stat(filepath.Join(dir, f))
+		require.NoError(t, err, f)
+	}
+
+	cfg, err := os.ReadFile(filepath.Join(dir, serviceGrpc.DiagnosticsConfigFile))
+	require.NoError(t, err)
+	if key := s.GetConfig().Clients.Device.COAP.TLS.PreSharedKey.Key; key != "" {
+		require.NotContains(t, string(cfg), key)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, serviceGrpc.DiagnosticsDevicesFile))
+	require.NoError(t, err)
+	var devices []json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &devices))
+
+	data, err = os.ReadFile(filepath.Join(dir, serviceGrpc.DiagnosticsCertificatesFile))
+	require.NoError(t, err)
+	var certificates []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &certificates))
+	require.NotEmpty(t, certificates)
+}
+
+func TestGetDiagnosticsWithoutSecrets(t *testing.T) {
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.Clients.Device.COAP.TLS.PreSharedKey.Key = "secret-pre-shared-key"
+	cfg.APIs.APIKeys.Keys = []apikey.Key{{Name: "ci", Hash: apikey.Hash("secret-api-key"), Scopes: []string{"admin"}}}
+	cfg.AdditionalOwners.Subjects = []owners.Subject{{Claim: "groups", Value: "secret-group"}}
+	cfg.APIs.GRPC.TLS.KeyFile = "data:;base64,c2VjcmV0LWtleQ=="
+	secrets := []string{
+		cfg.Clients.Device.COAP.TLS.PreSharedKey.Key,
+		cfg.APIs.APIKeys.Keys[0].Hash,
+		cfg.AdditionalOwners.Subjects[0].Value,
+		string(cfg.APIs.GRPC.TLS.KeyFile),
+	}
+	s := serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), nil, nil, nil, &configGrpc.ServiceInformation{}, log.NewLogger(log.MakeDefaultConfig()))
+	defer s.Close()
+
+	resp, err := s.GetDiagnostics(context.Background(), &pb.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	err = tar.Untar(dir, bytes.NewReader(resp.GetData()))
+	require.NoError(t, err)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, err)
+		for _, secret := range secrets {
+			require.NotContains(t, string(data), secret, f.Name())
+		}
+	}
+}
@@ -41,9 +41,12 @@ func getResourceAndRefreshCache(ctx context.Context, dev *device, link schema.Re
 		options = append(options, pkgCoap.WithInterface(resourceInterface))
 	}
 
-	err := dev.GetResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), &response, options...)
+	err := dev.withRetry(ctx, true, func(ctx context.Context) error {
+		return dev.GetResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), &response, options...)
+	})
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot get resource %v for device %v: %w", link.Href, dev.ID, err)).Err()
+		dev.invalidateResourceLinksOnNotFound(err)
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot get resource %v for device %v: %w", link.Href, dev.ID, err), dev.newDeviceRequest(link.Href)).Err()
 	}
 	content := responseToData(response)
 	// we update device resource body only for device resource
@@ -73,7 +76,7 @@ func (s *ClientApplicationServer) GetResource(ctx context.Context, req *pb.GetRe
 	if err != nil {
 		return nil, err
 	}
-	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), req.GetResourceInterface())
+	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), req.GetResourceInterface(), req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
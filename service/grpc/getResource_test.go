@@ -26,6 +26,7 @@ import (
 	"github.com/plgd-dev/client-application/test"
 	"github.com/plgd-dev/device/v2/schema/configuration"
 	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/pstat"
 	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
 	"github.com/stretchr/testify/require"
@@ -122,3 +123,98 @@ func TestClientApplicationServerGetResource(t *testing.T) {
 		})
 	}
 }
+
+func TestClientApplicationServerGetResourceOnSimulator(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	dev, err := test.GetSimulatedDevice(ctx, s, sim.Devices()[0])
+	require.NoError(t, err)
+
+	type args struct {
+		req *pb.GetResourceRequest
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        *grpcgwPb.Resource
+		wantErr     bool
+		wantErrCode codes.Code
+	}{
+		{
+			name: "device resource",
+			args: args{
+				req: &pb.GetResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     device.ResourceURI,
+					},
+				},
+			},
+			want: &grpcgwPb.Resource{
+				Data:  dev.GetData(),
+				Types: []string{"oic.wk.d", "oic.d.cloudDevice"},
+			},
+		},
+		{
+			name: "unknown device",
+			args: args{
+				req: &pb.GetResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: uuid.NewString(),
+						Href:     device.ResourceURI,
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.NotFound,
+		},
+		{
+			name: "unknown href",
+			args: args{
+				req: &pb.GetResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     "/unknown",
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.NotFound,
+		},
+		{
+			name: "permissionDenied - cannot establish TLS connection",
+			args: args{
+				req: &pb.GetResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     pstat.ResourceURI,
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetResource(ctx, tt.args.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrCode.String(), status.Code(err).String())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/metadata"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
+	kitJson "github.com/plgd-dev/kit/v2/codec/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var inventoryCSVHeader = []string{"id", "endpoints", "types", "ownershipStatus", "deviceResourceBody", "labels", "resources"}
+
+func (d *device) toInventory(labels map[string]string) *pb.InventoryDevice {
+	dev := d.ToProto()
+	var body *commands.Content
+	if len(dev.GetData().GetContent().GetData()) > 0 {
+		body = dev.GetData().GetContent()
+	}
+	return &pb.InventoryDevice{
+		Id:                 dev.GetId(),
+		Endpoints:          dev.GetEndpoints(),
+		Types:              dev.GetTypes(),
+		OwnershipStatus:    dev.GetOwnershipStatus(),
+		DeviceResourceBody: body,
+		Labels:             labels,
+	}
+}
+
+func contentToJSON(content *commands.Content) (string, error) {
+	if len(content.GetData()) == 0 {
+		return "", nil
+	}
+	switch content.GetContentType() {
+	case message.AppJSON.String():
+		return string(content.GetData()), nil
+	case message.AppCBOR.String(), message.AppOcfCbor.String():
+		return cbor.ToJSON(content.GetData())
+	}
+	return "", fmt.Errorf("unsupported content type '%v'", content.GetContentType())
+}
+
+func jsonToContent(data string) (*commands.Content, error) {
+	if data == "" {
+		return nil, nil
+	}
+	cborData, err := kitJson.ToCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	return &commands.Content{
+		ContentType:       message.AppOcfCbor.String(),
+		CoapContentFormat: int32(message.AppOcfCbor),
+		Data:              cborData,
+	}, nil
+}
+
+func inventoryDeviceToCSV(d *pb.InventoryDevice) ([]string, error) {
+	body, err := contentToJSON(d.GetDeviceResourceBody())
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode device resource body: %w", err)
+	}
+	labels, err := json.Marshal(d.GetLabels())
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode labels: %w", err)
+	}
+	resources := make(map[string]json.RawMessage, len(d.GetResources()))
+	for _, r := range d.GetResources() {
+		content, err := contentToJSON(r.GetContent())
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode resource %v: %w", r.GetHref(), err)
+		}
+		if content == "" {
+			content = "null"
+		}
+		resources[r.GetHref()] = json.RawMessage(content)
+	}
+	resourcesData, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode resources: %w", err)
+	}
+	return []string{
+		d.GetId(),
+		strings.Join(d.GetEndpoints(), " "),
+		strings.Join(d.GetTypes(), " "),
+		d.GetOwnershipStatus().String(),
+		body,
+		string(labels),
+		string(resourcesData),
+	}, nil
+}
+
+func encodeInventoryCSV(inv *pb.Inventory) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(inventoryCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, d := range inv.GetDevices() {
+		record, err := inventoryDeviceToCSV(d)
+		if err != nil {
+			return nil, fmt.Errorf("device %v: %w", d.GetId(), err)
+		}
+		if err = w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeInventory(format pb.InventoryFormat, inv *pb.Inventory) ([]byte, error) {
+	switch format {
+	case pb.InventoryFormat_JSON:
+		return protojson.Marshal(inv)
+	case pb.InventoryFormat_CSV:
+		return encodeInventoryCSV(inv)
+	}
+	return nil, fmt.Errorf("unsupported format %v", format)
+}
+
+func splitFields(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Fields(v)
+}
+
+func csvToInventoryDevice(record []string) (*pb.InventoryDevice, error) {
+	if len(record) != len(inventoryCSVHeader) {
+		return nil, fmt.Errorf("invalid number of columns %v, expected %v", len(record), len(inventoryCSVHeader))
+	}
+	ownershipStatus, ok := grpcgwPb.Device_OwnershipStatus_value[record[3]]
+	if !ok {
+		return nil, fmt.Errorf("invalid ownershipStatus('%v')", record[3])
+	}
+	body, err := jsonToContent(record[4])
+	if err != nil {
+		return nil, fmt.Errorf("invalid deviceResourceBody: %w", err)
+	}
+	var labels map[string]string
+	if record[5] != "" {
+		if err = json.Unmarshal([]byte(record[5]), &labels); err != nil {
+			return nil, fmt.Errorf("invalid labels: %w", err)
+		}
+	}
+	var resources map[string]json.RawMessage
+	if record[6] != "" {
+		if err = json.Unmarshal([]byte(record[6]), &resources); err != nil {
+			return nil, fmt.Errorf("invalid resources: %w", err)
+		}
+	}
+	d := &pb.InventoryDevice{
+		Id:                 record[0],
+		Endpoints:          splitFields(record[1]),
+		Types:              splitFields(record[2]),
+		OwnershipStatus:    grpcgwPb.Device_OwnershipStatus(ownershipStatus),
+		DeviceResourceBody: body,
+		Labels:             labels,
+	}
+	hrefs := make([]string, 0, len(resources))
+	for href := range resources {
+		hrefs = append(hrefs, href)
+	}
+	sort.Strings(hrefs)
+	for _, href := range hrefs {
+		content, err := jsonToContent(string(resources[href]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource %v: %w", href, err)
+		}
+		d.Resources = append(d.Resources, &pb.InventoryResource{Href: href, Content: content})
+	}
+	return d, nil
+}
+
+func decodeInventoryCSV(data []byte) (*pb.Inventory, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", err)
+	}
+	if strings.Join(header, ",") != strings.Join(inventoryCSVHeader, ",") {
+		return nil, fmt.Errorf("invalid header('%v'), expected '%v'", strings.Join(header, ","), strings.Join(inventoryCSVHeader, ","))
+	}
+	var inv pb.Inventory
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		d, err := csvToInventoryDevice(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		inv.Devices = append(inv.Devices, d)
+	}
+	return &inv, nil
+}
+
+func decodeInventory(format pb.InventoryFormat, data []byte) (*pb.Inventory, error) {
+	switch format {
+	case pb.InventoryFormat_JSON:
+		var inv pb.Inventory
+		if err := protojson.Unmarshal(data, &inv); err != nil {
+			return nil, err
+		}
+		return &inv, nil
+	case pb.InventoryFormat_CSV:
+		return decodeInventoryCSV(data)
+	}
+	return nil, fmt.Errorf("unsupported format %v", format)
+}
+
+func (s *ClientApplicationServer) getCachedDevices(deviceIDFilter []string) (devices, error) {
+	if len(deviceIDFilter) == 0 {
+		devs := make(devices, 0, 32)
+		s.devices.Range(func(_ uuid.UUID, dev *device) bool {
+			devs = append(devs, dev)
+			return true
+		})
+		devs.Sort()
+		return devs, nil
+	}
+	devs := make(devices, 0, len(deviceIDFilter))
+	for _, id := range deviceIDFilter {
+		devID, err := strDeviceID2UUID(id)
+		if err != nil {
+			return nil, err
+		}
+		dev, err := s.getDevice(devID)
+		if err != nil {
+			return nil, err
+		}
+		devs = append(devs, dev)
+	}
+	devs.Sort()
+	return devs, nil
+}
+
+func (s *ClientApplicationServer) getInventoryResources(ctx context.Context, deviceID string, hrefs []string) ([]*pb.InventoryResource, error) {
+	resources := make([]*pb.InventoryResource, 0, len(hrefs))
+	for _, href := range hrefs {
+		res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+			ResourceId: commands.NewResourceID(deviceID, href),
+		})
+		if status.Code(err) == codes.NotFound {
+			// device doesn't contain the resource
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, &pb.InventoryResource{
+			Href:          normalizeHref(href),
+			ResourceTypes: res.GetTypes(),
+			Content:       res.GetData().GetContent(),
+		})
+	}
+	return resources, nil
+}
+
+func (s *ClientApplicationServer) ExportInventory(ctx context.Context, req *pb.ExportInventoryRequest) (*pb.ExportInventoryResponse, error) {
+	devs, err := s.getCachedDevices(req.GetDeviceIdFilter())
+	if err != nil {
+		return nil, err
+	}
+	inv := &pb.Inventory{
+		Devices: make([]*pb.InventoryDevice, 0, len(devs)),
+	}
+	for _, dev := range devs {
+		d := dev.toInventory(s.metadata.Get(dev.ID).Labels)
+		if len(req.GetResourceHrefs()) > 0 {
+			d.Resources, err = s.getInventoryResources(ctx, d.GetId(), req.GetResourceHrefs())
+			if err != nil {
+				return nil, err
+			}
+		}
+		inv.Devices = append(inv.Devices, d)
+	}
+	data, err := encodeInventory(req.GetFormat(), inv)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot encode inventory: %v", err)
+	}
+	return &pb.ExportInventoryResponse{
+		Format: req.GetFormat(),
+		Data:   data,
+	}, nil
+}
+
+func validateInventoryDevice(d *pb.InventoryDevice) (uuid.UUID, schema.Endpoints, error) {
+	devID, err := uuid.Parse(d.GetId())
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("invalid id('%v'): %w", d.GetId(), err)
+	}
+	endpoints := make(schema.Endpoints, 0, len(d.GetEndpoints()))
+	for _, ep := range d.GetEndpoints() {
+		endpoint := schema.Endpoint{URI: ep}
+		if _, err = endpoint.GetAddr(); err != nil {
+			return uuid.Nil, nil, fmt.Errorf("invalid endpoint('%v'): %w", ep, err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 0 {
+		return uuid.Nil, nil, fmt.Errorf("endpoints of device %v are empty", devID)
+	}
+	return devID, endpoints, nil
+}
+
+func (s *ClientApplicationServer) ImportInventory(_ context.Context, req *pb.ImportInventoryRequest) (*pb.ImportInventoryResponse, error) {
+	devService := s.serviceDevice.Load()
+	if devService == nil {
+		return nil, status.Errorf(codes.Unavailable, "device service is not initialized")
+	}
+	inv, err := decodeInventory(req.GetFormat(), req.GetData())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot decode inventory: %v", err)
+	}
+	devs := make(devices, 0, len(inv.GetDevices()))
+	for i, d := range inv.GetDevices() {
+		devID, endpoints, err := validateInventoryDevice(d)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid devices[%v]: %v", i, err)
+		}
+		dev := newDevice(devID, devService, s.logger)
+		dev.updateDeviceMetadata(d.GetTypes(), endpoints, d.GetOwnershipStatus())
+		dev.updateDeviceResourceBody(d.GetDeviceResourceBody())
+		devs = append(devs, dev)
+	}
+	resp := &pb.ImportInventoryResponse{
+		DeviceIds: make([]string, 0, len(devs)),
+	}
+	for i, dev := range devs {
+		stored, loaded := s.devices.LoadOrStore(dev.ID, dev)
+		if loaded {
+			stored.update(dev)
+		}
+		if labels := inv.GetDevices()[i].GetLabels(); len(labels) > 0 {
+			if _, err = s.metadata.Update(dev.ID, func(md metadata.Metadata) metadata.Metadata {
+				md.Labels = labels
+				return md
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "cannot set labels of device %v: %v", dev.ID, err)
+			}
+		}
+		resp.DeviceIds = append(resp.DeviceIds, dev.ID.String())
+	}
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInventory(t *testing.T) *pb.Inventory {
+	body, err := jsonToContent(`{"n":"devsim","di":"00000000-0000-0000-0000-000000000001"}`)
+	require.NoError(t, err)
+	light, err := jsonToContent(`{"power":42,"state":true}`)
+	require.NoError(t, err)
+	return &pb.Inventory{
+		Devices: []*pb.InventoryDevice{
+			{
+				Id:                 "00000000-0000-0000-0000-000000000001",
+				Endpoints:          []string{"coap://127.0.0.1:5683", "coaps://127.0.0.1:5684"},
+				Types:              []string{"oic.wk.d", "oic.d.cloudDevice"},
+				OwnershipStatus:    grpcgwPb.Device_OWNED,
+				DeviceResourceBody: body,
+				Labels:             map[string]string{"room": "kitchen"},
+				Resources:          []*pb.InventoryResource{{Href: "/light/1", Content: light}},
+			},
+			{
+				Id:              "00000000-0000-0000-0000-000000000002",
+				Endpoints:       []string{"coap://127.0.0.1:5685"},
+				OwnershipStatus: grpcgwPb.Device_UNOWNED,
+			},
+		},
+	}
+}
+
+func requireEqualContent(t *testing.T, want, got *commands.Content) {
+	wantJSON, err := contentToJSON(want)
+	require.NoError(t, err)
+	gotJSON, err := contentToJSON(got)
+	require.NoError(t, err)
+	if wantJSON == "" {
+		require.Empty(t, gotJSON)
+		return
+	}
+	require.JSONEq(t, wantJSON, gotJSON)
+}
+
+func TestEncodeDecodeInventory(t *testing.T) {
+	for _, format := range []pb.InventoryFormat{pb.InventoryFormat_JSON, pb.InventoryFormat_CSV} {
+		t.Run(format.String(), func(t *testing.T) {
+			inv := newTestInventory(t)
+			data, err := encodeInventory(format, inv)
+			require.NoError(t, err)
+			got, err := decodeInventory(format, data)
+			require.NoError(t, err)
+			require.Len(t, got.GetDevices(), len(inv.GetDevices()))
+			for i := range inv.GetDevices() {
+				want := inv.GetDevices()[i]
+				dev := got.GetDevices()[i]
+				require.Equal(t, want.GetId(), dev.GetId())
+				require.Equal(t, want.GetEndpoints(), dev.GetEndpoints())
+				require.Equal(t, want.GetTypes(), dev.GetTypes())
+				require.Equal(t, want.GetOwnershipStatus(), dev.GetOwnershipStatus())
+				requireEqualContent(t, want.GetDeviceResourceBody(), dev.GetDeviceResourceBody())
+				require.Equal(t, len(want.GetLabels()), len(dev.GetLabels()))
+				for k, v := range want.GetLabels() {
+					require.Equal(t, v, dev.GetLabels()[k])
+				}
+				require.Len(t, dev.GetResources(), len(want.GetResources()))
+				for j := range want.GetResources() {
+					require.Equal(t, want.GetResources()[j].GetHref(), dev.GetResources()[j].GetHref())
+					requireEqualContent(t, want.GetResources()[j].GetContent(), dev.GetResources()[j].GetContent())
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeInventoryCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "invalid header", data: "id,endpoints\n"},
+		{name: "invalid ownershipStatus", data: "id,endpoints,types,ownershipStatus,deviceResourceBody,labels,resources\n00000000-0000-0000-0000-000000000001,coap://127.0.0.1:5683,,INVALID,,,\n"},
+		{name: "invalid labels", data: "id,endpoints,types,ownershipStatus,deviceResourceBody,labels,resources\n00000000-0000-0000-0000-000000000001,coap://127.0.0.1:5683,,OWNED,,[],\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeInventory(pb.InventoryFormat_CSV, []byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestValidateInventoryDevice(t *testing.T) {
+	tests := []struct {
+		name    string
+		dev     *pb.InventoryDevice
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			dev:  &pb.InventoryDevice{Id: "00000000-0000-0000-0000-000000000001", Endpoints: []string{"coaps+tcp://127.0.0.1:5684"}},
+		},
+		{
+			name:    "invalid id",
+			dev:     &pb.InventoryDevice{Id: "abc", Endpoints: []string{"coap://127.0.0.1:5683"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid endpoint",
+			dev:     &pb.InventoryDevice{Id: "00000000-0000-0000-0000-000000000001", Endpoints: []string{"127.0.0.1"}},
+			wantErr: true,
+		},
+		{
+			name:    "no endpoints",
+			dev:     &pb.InventoryDevice{Id: "00000000-0000-0000-0000-000000000001"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := validateInventoryDevice(tt.dev)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
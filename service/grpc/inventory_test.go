@@ -0,0 +1,73 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientApplicationServerExportImportInventory(t *testing.T) {
+	dev := test.MustFindDeviceByName(test.DevsimName, []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV4})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	err = s.GetDevices(&pb.GetDevicesRequest{}, test.NewClientApplicationGetDevicesServer(ctx))
+	require.NoError(t, err)
+
+	for _, format := range []pb.InventoryFormat{pb.InventoryFormat_JSON, pb.InventoryFormat_CSV} {
+		t.Run(format.String(), func(t *testing.T) {
+			exported, err := s.ExportInventory(ctx, &pb.ExportInventoryRequest{
+				Format:         format,
+				DeviceIdFilter: []string{dev.GetId()},
+				ResourceHrefs:  []string{"/light/1", "/notFound"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, format, exported.GetFormat())
+			require.NotEmpty(t, exported.GetData())
+
+			s1, teardown1, err := test.NewClientApplicationServer(ctx)
+			require.NoError(t, err)
+			defer teardown1()
+			imported, err := s1.ImportInventory(ctx, &pb.ImportInventoryRequest{
+				Format: format,
+				Data:   exported.GetData(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{dev.GetId()}, imported.GetDeviceIds())
+
+			// the device is accessible without discovery
+			got, err := s1.GetDevice(ctx, &pb.GetDeviceRequest{DeviceId: dev.GetId()})
+			require.NoError(t, err)
+			require.Equal(t, dev.GetId(), got.GetId())
+			_, err = s1.GetResource(ctx, &pb.GetResourceRequest{ResourceId: commands.NewResourceID(dev.GetId(), "/light/1")})
+			require.NoError(t, err)
+		})
+	}
+
+	_, err = s.ImportInventory(ctx, &pb.ImportInventoryRequest{Format: pb.InventoryFormat_CSV, Data: []byte("invalid")})
+	require.Error(t, err)
+}
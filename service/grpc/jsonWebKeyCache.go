@@ -14,9 +14,13 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+var errUnknownJSONWebKey = errors.New("could not find JWK")
+
 type JSONWebKeyCache struct {
 	owner uuid.UUID
 	keys  jwk.Set
+	// issuer of the OpenID configuration the keys were fetched from
+	issuer string
 }
 
 func NewJSONWebKeyCache(owner uuid.UUID, keys jwk.Set) *JSONWebKeyCache {
@@ -49,32 +53,88 @@ func (c *JSONWebKeyCache) LookupKey(token *jwt.Token) (jwk.Key, error) {
 			return key, nil
 		}
 	}
-	return nil, errors.New("could not find JWK")
+	return nil, errUnknownJSONWebKey
+}
+
+// verifyToken verifies the signature of the token by the current keys, the keys are refreshed when the key of the token is unknown.
+// With the OpenID discovery the keys are loaded from the authority when they haven't been set yet.
+func (s *ClientApplicationServer) verifyToken(ctx context.Context, token string, claims jwt.Claims) (*JSONWebKeyCache, error) {
+	c := s.jwksCache.Load()
+	if c == nil {
+		if _, err := s.refreshJSONWebKeys(ctx, minJSONWebKeysRefreshInterval); err != nil {
+			s.logger.Warnf("cannot load JSON web keys: %v", err)
+		}
+		c = s.jwksCache.Load()
+	}
+	if c == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "cannot validate token: missing JWK cache")
+	}
+	_, err := jwt.ParseWithClaims(token, claims, c.GetKey, s.getJWTParserOptions(c)...)
+	if errors.Is(err, errUnknownJSONWebKey) {
+		// the keys could be rotated by the authority
+		refreshed, errR := s.refreshJSONWebKeys(ctx, minJSONWebKeysRefreshInterval)
+		if errR != nil {
+			s.logger.Warnf("cannot refresh JSON web keys: %v", errR)
+		}
+		if refreshed {
+			c = s.jwksCache.Load()
+			_, err = jwt.ParseWithClaims(token, claims, c.GetKey, s.getJWTParserOptions(c)...)
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "could not parse token: %v", err)
+	}
+	return c, nil
+}
+
+// checkPrimaryOwnerToken verifies the token and checks that it belongs to the owner of the client application, the additional owners are rejected.
+func (s *ClientApplicationServer) checkPrimaryOwnerToken(ctx context.Context, token string) (uuid.UUID, error) {
+	claims := plgdJwt.NewScopeClaims()
+	c, err := s.verifyToken(ctx, token, claims)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err = checkOwner(plgdJwt.Claims(*claims), s.GetConfig().RemoteProvisioning.GetJwtOwnerClaim(), c.owner); err != nil {
+		return uuid.Nil, status.Errorf(codes.PermissionDenied, "token does not belong to the owner: %v", status.Convert(err).Message())
+	}
+	return c.owner, nil
 }
 
-func (s *ClientApplicationServer) ParseWithClaims(_ context.Context, token string, claims jwt.Claims) error {
+func (s *ClientApplicationServer) ParseWithClaims(ctx context.Context, token string, claims jwt.Claims) error {
+	if s.HasAPIKeyAuthenticationEnabled() {
+		if _, ok := s.findAPIKey(token); !ok {
+			return status.Errorf(codes.Unauthenticated, "invalid API key")
+		}
+		return nil
+	}
 	if token == "" {
 		if !s.HasJWTAuthorizationEnabled() {
 			return nil
 		}
 		return status.Errorf(codes.Unauthenticated, "missing token")
 	}
-	c := s.jwksCache.Load()
-	if c == nil {
-		return status.Errorf(codes.Unauthenticated, "cannot validate token: missing JWK cache")
-	}
-	_, err := jwt.ParseWithClaims(token, claims, c.GetKey)
+	c, err := s.verifyToken(ctx, token, claims)
 	if err != nil {
-		return status.Errorf(codes.Unauthenticated, "could not parse token: %v", err)
+		return err
 	}
-
 	scopeClaims, ok := claims.(*plgdJwt.ScopeClaims)
 	if !ok {
 		return status.Errorf(codes.Unauthenticated, "invalid type of token claims %T", claims)
 	}
 	plgdClaims := plgdJwt.Claims(*scopeClaims)
 	cfg := s.GetConfig()
-	owner, err := plgdClaims.GetOwner(cfg.RemoteProvisioning.GetJwtOwnerClaim())
+	err = checkOwner(plgdClaims, cfg.RemoteProvisioning.GetJwtOwnerClaim(), c.owner)
+	if err == nil {
+		return nil
+	}
+	if _, ok := cfg.AdditionalOwners.Find(plgdClaims); ok {
+		return nil
+	}
+	return err
+}
+
+func checkOwner(claims plgdJwt.Claims, ownerClaim string, expectedOwner uuid.UUID) error {
+	owner, err := claims.GetOwner(ownerClaim)
 	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "cannot get owner claim: %v", err)
 	}
@@ -82,13 +142,12 @@ func (s *ClientApplicationServer) ParseWithClaims(_ context.Context, token strin
 		return status.Errorf(codes.Unauthenticated, "owner claim is not set")
 	}
 	ownerID, err := uuid.Parse(events.OwnerToUUID(owner))
-	if owner == "" {
+	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "cannot parse owner claim to UUID: %v", err)
 	}
-	if ownerID != c.owner {
+	if ownerID != expectedOwner {
 		return status.Errorf(codes.Unauthenticated, "unexpected owner('%v')", owner)
 	}
-
 	return nil
 }
 
@@ -0,0 +1,176 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/plgd-dev/client-application/pb"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapNet "github.com/plgd-dev/go-coap/v3/net"
+	"github.com/plgd-dev/go-coap/v3/udp/client"
+	pkgNet "github.com/plgd-dev/kit/v2/net"
+)
+
+var multicastScopeAddresses = map[configDevice.MulticastScope]string{
+	configDevice.MulticastScopeIPv4:           core.DiscoveryAddressUDP4Local,
+	configDevice.MulticastScopeIPv6LinkLocal:  core.DiscoveryAddressUDP6LinkLocal,
+	configDevice.MulticastScopeIPv6RealmLocal: core.DiscoveryAddressUDP6RealmLocal,
+	configDevice.MulticastScopeIPv6SiteLocal:  core.DiscoveryAddressUDP6SiteLocal,
+}
+
+var multicastScopes = map[pb.GetDevicesRequest_MulticastScope]configDevice.MulticastScope{
+	pb.GetDevicesRequest_IPV4_LOCAL:       configDevice.MulticastScopeIPv4,
+	pb.GetDevicesRequest_IPV6_LINK_LOCAL:  configDevice.MulticastScopeIPv6LinkLocal,
+	pb.GetDevicesRequest_IPV6_REALM_LOCAL: configDevice.MulticastScopeIPv6RealmLocal,
+	pb.GetDevicesRequest_IPV6_SITE_LOCAL:  configDevice.MulticastScopeIPv6SiteLocal,
+}
+
+// getMulticastConfig returns the interfaces and the scopes of the request, when they are not set then the configured ones are used.
+func getMulticastConfig(req *pb.GetDevicesRequest, cfg configDevice.DiscoveryConfig) ([]string, []configDevice.MulticastScope) {
+	interfaces := cfg.MulticastInterfaces
+	if len(req.GetMulticastInterfaces()) > 0 {
+		interfaces = req.GetMulticastInterfaces()
+	}
+	scopes := cfg.MulticastScopes
+	if len(req.GetMulticastScopes()) > 0 {
+		scopes = make([]configDevice.MulticastScope, 0, len(req.GetMulticastScopes()))
+		for _, s := range req.GetMulticastScopes() {
+			if scope, ok := multicastScopes[s]; ok {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return interfaces, scopes
+}
+
+// toDiscoveryConfiguration returns the discovery configuration with the multicast addresses of the scopes of the enabled IP versions.
+// When the scopes are not set, all multicast addresses are used.
+func toDiscoveryConfiguration(ipVersionFilter ipVersionFilter, scopes []configDevice.MulticastScope) core.DiscoveryConfiguration {
+	discoveryCfg := core.DefaultDiscoveryConfiguration()
+	if len(scopes) > 0 {
+		discoveryCfg.MulticastAddressUDP4 = nil
+		discoveryCfg.MulticastAddressUDP6 = nil
+		for _, scope := range scopes {
+			addr, ok := multicastScopeAddresses[scope]
+			if !ok {
+				continue
+			}
+			if scope == configDevice.MulticastScopeIPv4 {
+				discoveryCfg.MulticastAddressUDP4 = append(discoveryCfg.MulticastAddressUDP4, addr)
+			} else if !slices.Contains(discoveryCfg.MulticastAddressUDP6, addr) {
+				discoveryCfg.MulticastAddressUDP6 = append(discoveryCfg.MulticastAddressUDP6, addr)
+			}
+		}
+	}
+	if ipVersionFilter&ipv4 == 0 {
+		discoveryCfg.MulticastAddressUDP4 = nil
+	}
+	if ipVersionFilter&ipv6 == 0 {
+		discoveryCfg.MulticastAddressUDP6 = nil
+	}
+	return discoveryCfg
+}
+
+// discoverDiscoveryResourcesOnInterfaces discovers the devices on each of the interfaces, when the interfaces are not set then all interfaces are used.
+func discoverDiscoveryResourcesOnInterfaces(ctx context.Context, discoveryCfg core.DiscoveryConfiguration, interfaces []string, onResponse func(conn *client.Conn, resp *pool.Message)) error {
+	if len(interfaces) == 0 {
+		return discoverDiscoveryResources(ctx, discoveryCfg, onResponse)
+	}
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+	appendErr := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		errs = append(errs, err)
+	}
+	for _, name := range interfaces {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			appendErr(err)
+			continue
+		}
+		cfg := discoveryCfg
+		cfg.MulticastOptions = append(slices.Clone(discoveryCfg.MulticastOptions), coapNet.WithMulticastInterface(*iface))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := discoverDiscoveryResources(ctx, cfg, onResponse); err != nil {
+				appendErr(err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// splitMulticastZone returns the multicast address without the zone and the zone, which is the name of the interface.
+func splitMulticastZone(addr pkgNet.Addr) (pkgNet.Addr, string) {
+	ip, zone := pkgNet.ParseIPZone(addr.GetHostname())
+	if ip == nil || zone == "" {
+		return addr, ""
+	}
+	return addr.SetHostname(ip.String()), zone
+}
+
+// getRemoteZone returns the zone of the link-local IPv6 remote address.
+func getRemoteZone(remoteAddr net.Addr) string {
+	switch addr := remoteAddr.(type) {
+	case *net.UDPAddr:
+		return addr.Zone
+	case *net.TCPAddr:
+		return addr.Zone
+	}
+	return ""
+}
+
+func setEndpointZone(endpoint schema.Endpoint, zone string) schema.Endpoint {
+	addr, err := endpoint.GetAddr()
+	if err != nil {
+		return endpoint
+	}
+	ip, epZone := pkgNet.ParseIPZone(addr.GetHostname())
+	if ip == nil || ip.To4() != nil || epZone != "" || !ip.IsLinkLocalUnicast() {
+		return endpoint
+	}
+	endpoint.URI = addr.SetHostname(ip.String() + "%" + zone).URL()
+	return endpoint
+}
+
+// setLinkLocalZone sets the zone of the interface on which the response was received to the link-local IPv6 endpoints
+// without the zone, because they cannot be dialed without it.
+func setLinkLocalZone(links schema.ResourceLinks, zone string) schema.ResourceLinks {
+	if zone == "" {
+		return links
+	}
+	for i := range links {
+		endpoints := make(schema.Endpoints, 0, len(links[i].Endpoints))
+		for _, ep := range links[i].Endpoints {
+			endpoints = append(endpoints, setEndpointZone(ep, zone))
+		}
+		links[i].Endpoints = endpoints
+	}
+	return links
+}
@@ -0,0 +1,122 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema"
+	pkgNet "github.com/plgd-dev/kit/v2/net"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDiscoveryConfiguration(t *testing.T) {
+	cfg := toDiscoveryConfiguration(ipv4|ipv6, nil)
+	require.Equal(t, core.DefaultDiscoveryConfiguration().MulticastAddressUDP4, cfg.MulticastAddressUDP4)
+	require.Equal(t, core.DefaultDiscoveryConfiguration().MulticastAddressUDP6, cfg.MulticastAddressUDP6)
+
+	scopes := []configDevice.MulticastScope{configDevice.MulticastScopeIPv4, configDevice.MulticastScopeIPv6LinkLocal}
+	cfg = toDiscoveryConfiguration(ipv4|ipv6, scopes)
+	require.Equal(t, []string{core.DiscoveryAddressUDP4Local}, cfg.MulticastAddressUDP4)
+	require.Equal(t, []string{core.DiscoveryAddressUDP6LinkLocal}, cfg.MulticastAddressUDP6)
+
+	cfg = toDiscoveryConfiguration(ipv6, scopes)
+	require.Empty(t, cfg.MulticastAddressUDP4)
+	require.Equal(t, []string{core.DiscoveryAddressUDP6LinkLocal}, cfg.MulticastAddressUDP6)
+}
+
+func TestGetMulticastConfig(t *testing.T) {
+	cfg := configDevice.DiscoveryConfig{
+		MulticastInterfaces: []string{"eth0"},
+		MulticastScopes:     []configDevice.MulticastScope{configDevice.MulticastScopeIPv4},
+	}
+	interfaces, scopes := getMulticastConfig(&pb.GetDevicesRequest{}, cfg)
+	require.Equal(t, []string{"eth0"}, interfaces)
+	require.Equal(t, []configDevice.MulticastScope{configDevice.MulticastScopeIPv4}, scopes)
+
+	interfaces, scopes = getMulticastConfig(&pb.GetDevicesRequest{
+		MulticastInterfaces: []string{"wlan0"},
+		MulticastScopes:     []pb.GetDevicesRequest_MulticastScope{pb.GetDevicesRequest_IPV6_SITE_LOCAL},
+	}, cfg)
+	require.Equal(t, []string{"wlan0"}, interfaces)
+	require.Equal(t, []configDevice.MulticastScope{configDevice.MulticastScopeIPv6SiteLocal}, scopes)
+}
+
+func TestSetLinkLocalZone(t *testing.T) {
+	links := schema.ResourceLinks{
+		{
+			Href: "/oic/d",
+			Endpoints: schema.Endpoints{
+				{URI: "coaps://[fe80::1]:5684"},
+				{URI: "coaps://[fe80::1%eth1]:5684"},
+				{URI: "coaps://[2001:db8::1]:5684"},
+				{URI: "coaps://192.168.1.1:5684"},
+			},
+		},
+	}
+	links = setLinkLocalZone(links, "eth0")
+	require.Equal(t, []string{
+		"coaps://[fe80::1%eth0]:5684",
+		"coaps://[fe80::1%eth1]:5684",
+		"coaps://[2001:db8::1]:5684",
+		"coaps://192.168.1.1:5684",
+	}, []string{links[0].Endpoints[0].URI, links[0].Endpoints[1].URI, links[0].Endpoints[2].URI, links[0].Endpoints[3].URI})
+
+	ep, err := links[0].Endpoints[0].GetAddr()
+	require.NoError(t, err)
+	require.Equal(t, "fe80::1%eth0", ep.GetHostname())
+
+	require.Equal(t, "eth0", getRemoteZone(&net.UDPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0"}))
+}
+
+func TestNormalizeEndpoint(t *testing.T) {
+	addr, err := normalizeEndpoint("fe80::1%eth0")
+	require.NoError(t, err)
+	require.Equal(t, "fe80::1%eth0", addr.GetHostname())
+	require.Equal(t, MulticastPort, int(addr.GetPort()))
+
+	for _, tc := range []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "192.168.1.5", want: "coap://192.168.1.5:5683"},
+		{endpoint: "coap+tcp://192.168.1.5", want: "coap+tcp://192.168.1.5:5683"},
+		{endpoint: "coaps://192.168.1.5", want: "coaps://192.168.1.5:5684"},
+		{endpoint: "coaps+tcp://192.168.1.5:40000/", want: "coaps+tcp://192.168.1.5:40000"},
+		{endpoint: "coaps+tcp://[fe80::1%eth0]", want: "coaps+tcp://[fe80::1%eth0]:5684"},
+	} {
+		addr, err = normalizeEndpoint(tc.endpoint)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, addr.URL())
+	}
+	_, err = normalizeEndpoint("http://192.168.1.5")
+	require.Error(t, err)
+
+	addr, zone := splitMulticastZone(mustNormalizeEndpoint(t, "[ff02::158%eth0]:5683"))
+	require.Equal(t, "eth0", zone)
+	require.Equal(t, "ff02::158", addr.GetHostname())
+}
+
+func mustNormalizeEndpoint(t *testing.T, endpoint string) pkgNet.Addr {
+	addr, err := normalizeEndpoint(endpoint)
+	require.NoError(t, err)
+	return addr
+}
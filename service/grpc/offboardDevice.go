@@ -31,7 +31,7 @@ func (s *ClientApplicationServer) OffboardDevice(ctx context.Context, req *pb.Of
 	if err != nil {
 		return nil, err
 	}
-	dev, links, err := s.getDeviceForSetupCloud(ctx, devID)
+	dev, links, err := s.getDeviceForSetupCloud(ctx, devID, req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,8 @@ func (s *ClientApplicationServer) OffboardDevice(ctx context.Context, req *pb.Of
 	}
 	err = dev.UpdateResource(ctx, cloudLink, cloud.ConfigurationUpdateRequest{}, nil, coap.WithDeviceID(dev.DeviceID()))
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot update resource %v for device %v: %w", cloudLink.Href, dev.ID, err)).Err()
+		dev.invalidateResourceLinksOnNotFound(err)
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot update resource %v for device %v: %w", cloudLink.Href, dev.ID, err), dev.newDeviceRequest(cloudLink.Href)).Err()
 	}
 	return &pb.OffboardDeviceResponse{}, nil
 }
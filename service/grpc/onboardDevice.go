@@ -32,6 +32,8 @@ import (
 	"github.com/plgd-dev/device/v2/schema/credential"
 	"github.com/plgd-dev/device/v2/schema/maintenance"
 	"github.com/plgd-dev/device/v2/schema/softwareupdate"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"github.com/plgd-dev/kit/v2/codec/cbor"
 	"github.com/plgd-dev/kit/v2/security"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -114,12 +116,12 @@ func validateOnboardDeviceRequest(req *pb.OnboardDeviceRequest) (uuid.UUID, []*x
 	return devID, certificateAuthorities, nil
 }
 
-func (s *ClientApplicationServer) getDeviceForSetupCloud(ctx context.Context, devID uuid.UUID) (*device, schema.ResourceLinks, error) {
+func (s *ClientApplicationServer) getDeviceForSetupCloud(ctx context.Context, devID uuid.UUID, refreshLinks bool) (*device, schema.ResourceLinks, error) {
 	dev, err := s.getDevice(devID)
 	if err != nil {
 		return nil, nil, err
 	}
-	links, err := dev.getResourceLinksAndRefreshCache(ctx)
+	links, err := dev.getOrderedResourceLinks(ctx, refreshLinks)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -133,6 +135,20 @@ func (s *ClientApplicationServer) getDeviceForSetupCloud(ctx context.Context, de
 	return dev, links, nil
 }
 
+func (s *ClientApplicationServer) getCloudConfiguration(ctx context.Context, devID uuid.UUID) (cloud.Configuration, error) {
+	res, err := s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId: commands.NewResourceID(devID.String(), cloud.ResourceURI),
+	})
+	if err != nil {
+		return cloud.Configuration{}, err
+	}
+	var cfg cloud.Configuration
+	if err = cbor.Decode(res.GetData().GetContent().GetData(), &cfg); err != nil {
+		return cloud.Configuration{}, fmt.Errorf("cannot decode cloud configuration: %w", err)
+	}
+	return cfg, nil
+}
+
 func onboardSecureDevice(ctx context.Context, dev *device, links schema.ResourceLinks, req *pb.OnboardDeviceRequest, certificateAuthorities []*x509.Certificate) error {
 	return dev.provision(ctx, links, func(ctx context.Context, pc *core.ProvisioningClient) error {
 		if errPro := setACLForCloud(ctx, pc, req.GetHubId(), links); errPro != nil {
@@ -224,7 +240,7 @@ func (s *ClientApplicationServer) OnboardDevice(ctx context.Context, req *pb.Onb
 	if err != nil {
 		return nil, err
 	}
-	dev, links, err := s.getDeviceForSetupCloud(ctx, devID)
+	dev, links, err := s.getDeviceForSetupCloud(ctx, devID, req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +250,7 @@ func (s *ClientApplicationServer) OnboardDevice(ctx context.Context, req *pb.Onb
 		err = onboardInsecureDevice(ctx, dev, links, req)
 	}
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot provision onboard configuration for device %v: %w", dev.ID, err)).Err()
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot provision onboard configuration for device %v: %w", dev.ID, err), dev.newDeviceRequest("")).Err()
 	}
 	return &pb.OnboardDeviceResponse{}, nil
 }
@@ -0,0 +1,164 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/hub/v2/identity-store/events"
+	"github.com/plgd-dev/hub/v2/pkg/security/openid"
+)
+
+const (
+	openIDDiscoveryTimeout = time.Second * 10
+	// minJSONWebKeysRefreshInterval limits the refreshes caused by tokens with an unknown key id.
+	minJSONWebKeysRefreshInterval = time.Second * 30
+	// defaultJSONWebKeysRefreshInterval is used to check the configuration when the discovery is disabled.
+	defaultJSONWebKeysRefreshInterval = time.Minute
+)
+
+func newOpenIDHTTPClient(certificateAuthorities string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if certificateAuthorities != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM([]byte(certificateAuthorities))
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   openIDDiscoveryTimeout,
+	}
+}
+
+func getJSONWebKeys(ctx context.Context, authority, certificateAuthorities string) (jwk.Set, string, error) {
+	client := newOpenIDHTTPClient(certificateAuthorities)
+	authority = strings.TrimSuffix(authority, "/")
+	openIDCfg, err := openid.GetConfiguration(ctx, client, authority)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSuffix(openIDCfg.Issuer, "/") != authority {
+		return nil, "", fmt.Errorf("issuer('%v') of the OpenID configuration doesn't match the authority('%v')", openIDCfg.Issuer, authority)
+	}
+	keys, err := jwk.Fetch(ctx, openIDCfg.JWKSURL, jwk.WithHTTPClient(client))
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot fetch JSON web keys from %v: %w", openIDCfg.JWKSURL, err)
+	}
+	return keys, openIDCfg.Issuer, nil
+}
+
+// getJSONWebKeysOwner returns the owner of the keys. Before the keys are set, the owner is taken from the identity certificate of the client application.
+func (s *ClientApplicationServer) getJSONWebKeysOwner() (uuid.UUID, bool) {
+	if c := s.jwksCache.Load(); c != nil {
+		return c.owner, true
+	}
+	devService := s.serviceDevice.Load()
+	if devService == nil || devService.GetDeviceAuthenticationMode() != pb.GetConfigurationResponse_X509 {
+		return uuid.Nil, false
+	}
+	owner := devService.GetOwner()
+	if owner == "" {
+		// the client application is not initialized yet
+		return uuid.Nil, false
+	}
+	ownerUUID, err := uuid.Parse(events.OwnerToUUID(owner))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return ownerUUID, true
+}
+
+// refreshJSONWebKeys replaces the keys of the cache by the keys of the authority, when the OpenID discovery is enabled and the owner is known.
+// The keys are not refreshed when they were refreshed within minInterval. It returns true when the keys were refreshed.
+func (s *ClientApplicationServer) refreshJSONWebKeys(ctx context.Context, minInterval time.Duration) (bool, error) {
+	cfg := s.GetConfig()
+	if !cfg.RemoteProvisioning.GetOpenIdDiscovery().GetEnabled() {
+		return false, nil
+	}
+	owner, ok := s.getJSONWebKeysOwner()
+	if !ok {
+		return false, nil
+	}
+	s.jwksRefreshMutex.Lock()
+	defer s.jwksRefreshMutex.Unlock()
+	if time.Since(s.jwksRefreshedAt) < minInterval {
+		return false, nil
+	}
+	s.jwksRefreshedAt = time.Now()
+	keys, issuer, err := getJSONWebKeys(ctx, cfg.RemoteProvisioning.GetAuthority(), cfg.RemoteProvisioning.GetCertificateAuthorities())
+	if err != nil {
+		return false, err
+	}
+	newCache := NewJSONWebKeyCache(owner, keys)
+	newCache.issuer = issuer
+	if err = s.updateJwkCache(newCache); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ClientApplicationServer) refreshJSONWebKeysPeriodically(ctx context.Context) {
+	for {
+		interval := time.Duration(s.GetConfig().RemoteProvisioning.GetOpenIdDiscovery().GetRefreshInterval())
+		if interval <= 0 {
+			interval = defaultJSONWebKeysRefreshInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		if _, err := s.refreshJSONWebKeys(ctx, 0); err != nil {
+			s.logger.Warnf("cannot refresh JSON web keys: %v", err)
+		}
+	}
+}
+
+// getJWTParserOptions returns the validation of the exp claim and, when the OpenID discovery is enabled, of the iss and aud claims.
+func (s *ClientApplicationServer) getJWTParserOptions(c *JSONWebKeyCache) []jwt.ParserOption {
+	opts := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	cfg := s.GetConfig()
+	discovery := cfg.RemoteProvisioning.GetOpenIdDiscovery()
+	if !discovery.GetEnabled() {
+		return opts
+	}
+	issuer := c.issuer
+	if issuer == "" {
+		issuer = cfg.RemoteProvisioning.GetAuthority()
+	}
+	opts = append(opts, jwt.WithIssuer(issuer))
+	if discovery.GetAudience() != "" {
+		opts = append(opts, jwt.WithAudience(discovery.GetAudience()))
+	}
+	return opts
+}
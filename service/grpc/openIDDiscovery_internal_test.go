@@ -0,0 +1,233 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/plgd-dev/client-application/service/config"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	"github.com/plgd-dev/hub/v2/identity-store/events"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/hub/v2/pkg/security/certificateSigner"
+	plgdJwt "github.com/plgd-dev/hub/v2/pkg/security/jwt"
+	"github.com/plgd-dev/kit/v2/security"
+	"github.com/plgd-dev/kit/v2/security/generateCertificate"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testOwner    = "owner"
+	testAudience = "https://client-application"
+)
+
+// testAuthority is a stand-in for the OpenID provider which serves the discovery document and the JSON web keys.
+type testAuthority struct {
+	*httptest.Server
+	mutex      sync.Mutex
+	keys       map[string]*ecdsa.PrivateKey
+	jwksCalled int
+}
+
+func newTestAuthority(t *testing.T) *testAuthority {
+	a := &testAuthority{keys: make(map[string]*ecdsa.PrivateKey)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         a.URL,
+			"jwks_uri":       a.URL + "/jwks",
+			"token_endpoint": a.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		a.jwksCalled++
+		_ = json.NewEncoder(w).Encode(a.getKeys(t))
+	})
+	a.Server = httptest.NewServer(mux)
+	return a
+}
+
+func (a *testAuthority) getKeys(t *testing.T) jwk.Set {
+	set := jwk.NewSet()
+	for kid, k := range a.keys {
+		key, err := jwk.FromRaw(k.Public())
+		require.NoError(t, err)
+		require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+		require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.ES256))
+		require.NoError(t, set.AddKey(key))
+	}
+	return set
+}
+
+// rotate replaces the keys of the authority by a new key.
+func (a *testAuthority) rotate(t *testing.T, kid string) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.keys = map[string]*ecdsa.PrivateKey{kid: k}
+}
+
+func (a *testAuthority) token(t *testing.T, kid string, claims jwt.MapClaims) string {
+	a.mutex.Lock()
+	k := a.keys[kid]
+	a.mutex.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+	v, err := token.SignedString(k)
+	require.NoError(t, err)
+	return v
+}
+
+func (a *testAuthority) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": testOwner,
+		"iss": a.URL,
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestOpenIDDiscovery(t *testing.T) {
+	a := newTestAuthority(t)
+	defer a.Close()
+	a.rotate(t, "kid1")
+
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.RemoteProvisioning.Authority = a.URL
+	cfg.RemoteProvisioning.OpenIdDiscovery.Enabled = true
+	cfg.RemoteProvisioning.OpenIdDiscovery.Audience = testAudience
+	require.NoError(t, cfg.Validate())
+	s := &ClientApplicationServer{
+		config: atomic.NewPointer(&cfg),
+		logger: log.NewLogger(log.MakeDefaultConfig()),
+	}
+	s.jwksCache.Store(NewJSONWebKeyCache(uuid.MustParse(events.OwnerToUUID(testOwner)), a.getKeys(t)))
+	ctx := context.Background()
+	require.NoError(t, s.ParseWithClaims(ctx, a.token(t, "kid1", a.claims()), plgdJwt.NewScopeClaims()))
+
+	// the unknown key id refreshes the keys
+	a.rotate(t, "kid2")
+	require.NoError(t, s.ParseWithClaims(ctx, a.token(t, "kid2", a.claims()), plgdJwt.NewScopeClaims()))
+	require.Equal(t, 1, a.jwksCalled)
+	require.Equal(t, a.URL, s.jwksCache.Load().issuer)
+
+	// the refreshes caused by the unknown key id are limited
+	a.rotate(t, "kid3")
+	err := s.ParseWithClaims(ctx, a.token(t, "kid3", a.claims()), plgdJwt.NewScopeClaims())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Equal(t, 1, a.jwksCalled)
+	refreshed, err := s.refreshJSONWebKeys(ctx, 0)
+	require.NoError(t, err)
+	require.True(t, refreshed)
+
+	invalidClaims := []func(c jwt.MapClaims){
+		func(c jwt.MapClaims) { c["iss"] = "https://unknown" },
+		func(c jwt.MapClaims) { c["aud"] = "unknown" },
+		func(c jwt.MapClaims) { delete(c, "exp") },
+		func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		func(c jwt.MapClaims) { c["sub"] = "unknown" },
+	}
+	for _, update := range invalidClaims {
+		claims := a.claims()
+		update(claims)
+		err = s.ParseWithClaims(ctx, a.token(t, "kid3", claims), plgdJwt.NewScopeClaims())
+		require.Equal(t, codes.Unauthenticated, status.Code(err), claims)
+	}
+	require.NoError(t, s.ParseWithClaims(ctx, a.token(t, "kid3", a.claims()), plgdJwt.NewScopeClaims()))
+}
+
+func TestGetJSONWebKeys(t *testing.T) {
+	a := newTestAuthority(t)
+	defer a.Close()
+	a.rotate(t, "kid1")
+	_, _, err := getJSONWebKeys(context.Background(), a.URL+"/realm", "")
+	require.Error(t, err)
+	keys, issuer, err := getJSONWebKeys(context.Background(), a.URL+"/", "")
+	require.NoError(t, err)
+	require.Equal(t, a.URL, issuer)
+	require.Equal(t, 1, keys.Len())
+}
+
+// initializeX509 sets the identity certificate of the owner signed by a new root CA, as FinishInitialize does.
+func initializeX509(t *testing.T, cfg *config.Config, owner string) *serviceDevice.Service {
+	cfg.Clients.Device.COAP.TLS.Authentication = configDevice.AuthenticationX509
+	devService, err := serviceDevice.New(context.Background(), func() configDevice.Config { return cfg.Clients.Device }, log.NewLogger(log.MakeDefaultConfig()))
+	require.NoError(t, err)
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	var caCfg generateCertificate.Configuration
+	caCfg.Subject.CommonName = "root"
+	caCfg.BasicConstraints.MaxPathLen = -1
+	caCfg.ValidFrom = "now"
+	caCfg.ValidFor = time.Hour
+	caPem, err := generateCertificate.GenerateRootCA(caCfg, caKey)
+	require.NoError(t, err)
+	caCerts, err := security.ParseX509FromPEM(caPem)
+	require.NoError(t, err)
+	csr, err := devService.GetIdentityCSR(events.OwnerToUUID(owner))
+	require.NoError(t, err)
+	chain, err := certificateSigner.NewIdentityCertificateSigner(caCerts, caKey, certificateSigner.WithNotBefore(time.Now().Add(-time.Minute)), certificateSigner.WithNotAfter(time.Now().Add(time.Hour))).Sign(context.Background(), csr)
+	require.NoError(t, err)
+	require.NoError(t, devService.SetIdentityCertificate(owner, chain))
+	return devService
+}
+
+func TestOpenIDDiscoveryLoadsKeysOfOwner(t *testing.T) {
+	a := newTestAuthority(t)
+	defer a.Close()
+	a.rotate(t, "kid1")
+
+	cfg := config.DefaultConfig(t.TempDir())
+	cfg.RemoteProvisioning.Authority = a.URL
+	cfg.RemoteProvisioning.OpenIdDiscovery.Enabled = true
+	cfg.RemoteProvisioning.OpenIdDiscovery.Audience = testAudience
+	require.NoError(t, cfg.Validate())
+	s := &ClientApplicationServer{
+		config: atomic.NewPointer(&cfg),
+		logger: log.NewLogger(log.MakeDefaultConfig()),
+	}
+	ctx := context.Background()
+	// the owner is not known before the initialization
+	err := s.ParseWithClaims(ctx, a.token(t, "kid1", a.claims()), plgdJwt.NewScopeClaims())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Equal(t, 0, a.jwksCalled)
+
+	s.serviceDevice.Store(initializeX509(t, &cfg, testOwner))
+	require.NoError(t, s.ParseWithClaims(ctx, a.token(t, "kid1", a.claims()), plgdJwt.NewScopeClaims()))
+	require.Equal(t, 1, a.jwksCalled)
+	require.Equal(t, uuid.MustParse(events.OwnerToUUID(testOwner)), s.jwksCache.Load().owner)
+}
@@ -155,6 +155,7 @@ func (s *ClientApplicationServer) ownDeviceGetCSR(ctx context.Context, timeoutVa
 		}
 		ownOpts = append(ownOpts, core.WithSetupCertificates(remoteSign.Sign))
 		err = dev.Own(remoteSign.ctx, links, devService.GetOwnershipClients(), ownOpts...)
+		dev.invalidateResourceLinks()
 		remoteSign.Close(err)
 	}()
 	csr, err := remoteSign.ReadCSR(ctx)
@@ -201,7 +202,7 @@ func (s *ClientApplicationServer) OwnDevice(ctx context.Context, req *pb.OwnDevi
 	if err != nil {
 		return nil, err
 	}
-	links, err := dev.getResourceLinksAndRefreshCache(ctx)
+	links, err := dev.getOrderedResourceLinks(ctx, req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
@@ -215,11 +216,14 @@ func (s *ClientApplicationServer) OwnDevice(ctx context.Context, req *pb.OwnDevi
 
 	ownOptions, err := devService.GetOwnOptions()
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot get own options: %w", err)).Err()
+		return nil, convErrToGrpcStatus(codes.FailedPrecondition, fmt.Errorf("cannot get own options: %w", err), deviceRequest{deviceID: dev.ID.String()}).Err()
 	}
-	err = dev.Own(ctx, links, devService.GetOwnershipClients(), ownOptions...)
+	err = dev.withRetry(ctx, req.GetRetry(), func(ctx context.Context) error {
+		return dev.Own(ctx, links, devService.GetOwnershipClients(), ownOptions...)
+	})
+	dev.invalidateResourceLinks()
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot own device %v: %w", dev.ID, err)).Err()
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot own device %v: %w", dev.ID, err), dev.newDeviceRequest("")).Err()
 	}
 	dev.updateOwnershipStatus(grpcgwPb.Device_OWNED)
 
@@ -87,3 +87,38 @@ func TestClientApplicationServerOwnDeviceViaManufacturerCertificate(t *testing.T
 	})
 	require.NoError(t, err)
 }
+
+func TestClientApplicationServerOwnDeviceOnSimulator(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+	simDev := sim.Devices()[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	dev, err := test.GetSimulatedDevice(ctx, s, simDev)
+	require.NoError(t, err)
+
+	_, err = s.OwnDevice(ctx, &pb.OwnDeviceRequest{
+		DeviceId: dev.GetId(),
+	})
+	require.NoError(t, err)
+	require.True(t, simDev.IsOwned())
+	require.Equal(t, test.PSK_OWNER, simDev.OwnerID())
+
+	_, err = s.GetResource(ctx, &pb.GetResourceRequest{
+		ResourceId: commands.NewResourceID(dev.GetId(), "/light/1"),
+	})
+	require.NoError(t, err)
+
+	_, err = s.DisownDevice(ctx, &pb.DisownDeviceRequest{
+		DeviceId: dev.GetId(),
+	})
+	require.NoError(t, err)
+	require.False(t, simDev.IsOwned())
+}
@@ -0,0 +1,261 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"go.uber.org/atomic"
+)
+
+const resourceLinksObservationTimeout = time.Second * 10
+
+// resourceLinksCache keeps the resource links of the device until the TTL expires or they are invalidated.
+type resourceLinksCache struct {
+	mutex      sync.Mutex
+	links      schema.ResourceLinks
+	expiresAt  time.Time
+	expiration *time.Timer
+	// observing is set when the changes of /oic/res are observed or the observation is starting
+	observing bool
+	// observation numbers the observations, so the notifications of the stopped observation are ignored
+	observation   uint64
+	observationID string
+	// stopObservation stops the observation of /oic/res, it is called with the locked mutex
+	stopObservation func(observationID string)
+}
+
+// copyResourceLinks copies the links with their endpoints, so the endpoints can be reordered without affecting the cache.
+func copyResourceLinks(links schema.ResourceLinks) schema.ResourceLinks {
+	linksCopy := make(schema.ResourceLinks, 0, len(links))
+	for _, link := range links {
+		link.Endpoints = slices.Clone(link.Endpoints)
+		linksCopy = append(linksCopy, link)
+	}
+	return linksCopy
+}
+
+func newResourceLinksCache(stopObservation func(observationID string)) *resourceLinksCache {
+	return &resourceLinksCache{
+		stopObservation: stopObservation,
+	}
+}
+
+func (c *resourceLinksCache) get(now time.Time) (schema.ResourceLinks, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.links == nil || !now.Before(c.expiresAt) {
+		return nil, false
+	}
+	return copyResourceLinks(c.links), true
+}
+
+// store stores the links and returns the number of the observation which must be started, when the changes of the links are not observed yet.
+func (c *resourceLinksCache) store(links schema.ResourceLinks, expiresAt time.Time) (uint64, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.links = copyResourceLinks(links)
+	c.expiresAt = expiresAt
+	if c.expiration != nil {
+		c.expiration.Stop()
+	}
+	c.expiration = time.AfterFunc(time.Until(expiresAt), c.expire)
+	if c.observing {
+		return 0, false
+	}
+	c.observing = true
+	c.observation++
+	return c.observation, true
+}
+
+// setObservationID sets the ID of the started observation, it returns false when the observation was stopped in the meantime.
+func (c *resourceLinksCache) setObservationID(observation uint64, observationID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.isObservingLocked(observation) {
+		return false
+	}
+	c.observationID = observationID
+	return true
+}
+
+func (c *resourceLinksCache) isObservingLocked(observation uint64) bool {
+	return c.observing && c.observation == observation
+}
+
+// clearLocked removes the links and stops the observation, the next store starts a new one.
+func (c *resourceLinksCache) clearLocked() {
+	c.links = nil
+	if c.expiration != nil {
+		c.expiration.Stop()
+		c.expiration = nil
+	}
+	if c.observationID != "" && c.stopObservation != nil {
+		c.stopObservation(c.observationID)
+	}
+	c.observing = false
+	c.observationID = ""
+}
+
+func (c *resourceLinksCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.clearLocked()
+}
+
+// invalidateObservation invalidates the links when the observation is not stopped yet.
+func (c *resourceLinksCache) invalidateObservation(observation uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.isObservingLocked(observation) {
+		c.clearLocked()
+	}
+}
+
+func (c *resourceLinksCache) expire() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if time.Now().Before(c.expiresAt) {
+		// the links were stored again
+		return
+	}
+	c.clearLocked()
+}
+
+// stopObserving is called when the observation is closed by the device or the connection.
+func (c *resourceLinksCache) stopObserving(observation uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.isObservingLocked(observation) {
+		return
+	}
+	c.observing = false
+	c.observationID = ""
+	c.links = nil
+}
+
+// resourceLinksObservationHandler invalidates the cached links when /oic/res notifies a change.
+type resourceLinksObservationHandler struct {
+	d           *device
+	observation uint64
+	// notified is set by the first notification, which contains the current links
+	notified atomic.Bool
+}
+
+func (h *resourceLinksObservationHandler) Handle(context.Context, coap.DecodeFunc) {
+	if h.notified.CompareAndSwap(false, true) {
+		return
+	}
+	h.d.logger.Debugf("resource links changed")
+	h.d.linksCache.invalidateObservation(h.observation)
+}
+
+func (h *resourceLinksObservationHandler) OnClose() {
+	h.d.linksCache.stopObserving(h.observation)
+}
+
+func (h *resourceLinksObservationHandler) Error(err error) {
+	h.d.logger.Debugf("cannot observe resource links: %v", err)
+	h.d.linksCache.stopObserving(h.observation)
+}
+
+func (d *device) observeResourceLinks(observation uint64, links schema.ResourceLinks) {
+	link, ok := links.GetResourceLink(resources.ResourceURI)
+	if !ok {
+		d.linksCache.stopObserving(observation)
+		return
+	}
+	if d.ToProto().GetOwnershipStatus() != grpcgwPb.Device_OWNED {
+		// the secure endpoints are preferred by the observation, but the unowned device cannot be reached by them
+		if eps := link.Endpoints.FilterUnsecureEndpoints(); len(eps) > 0 {
+			link.Endpoints = eps
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), resourceLinksObservationTimeout)
+	defer cancel()
+	observationID, err := d.ObserveResource(ctx, link, &resourceLinksObservationHandler{d: d, observation: observation}, coap.WithDeviceID(d.DeviceID()))
+	if err != nil {
+		d.logger.Debugf("cannot observe resource links: %v", err)
+		d.linksCache.stopObserving(observation)
+		return
+	}
+	if !d.linksCache.setObservationID(observation, observationID) {
+		// the links were invalidated during the start of the observation
+		d.stopResourceLinksObservation(observationID)
+	}
+}
+
+func (d *device) stopResourceLinksObservation(observationID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), resourceLinksObservationTimeout)
+	defer cancel()
+	if _, err := d.StopObservingResource(ctx, observationID); err != nil {
+		d.logger.Debugf("cannot stop observing resource links: %v", err)
+	}
+}
+
+func (d *device) resourceLinksCacheTTL() time.Duration {
+	if d.getResourceLinksCacheTTL == nil || d.linksCache == nil {
+		return 0
+	}
+	return d.getResourceLinksCacheTTL()
+}
+
+// storeResourceLinks caches the links and starts the observation of /oic/res to invalidate them on a change.
+func (d *device) storeResourceLinks(links schema.ResourceLinks) {
+	ttl := d.resourceLinksCacheTTL()
+	if ttl <= 0 {
+		return
+	}
+	if observation, ok := d.linksCache.store(links, time.Now().Add(ttl)); ok {
+		go d.observeResourceLinks(observation, d.orderResourceLinksEndpoints(copyResourceLinks(links)))
+	}
+}
+
+func (d *device) invalidateResourceLinks() {
+	if d.linksCache != nil {
+		d.linksCache.invalidate()
+	}
+}
+
+// invalidateResourceLinksOnNotFound invalidates the cached links when the device responds 4.04, because the resource was removed.
+func (d *device) invalidateResourceLinksOnNotFound(err error) {
+	var coapErr coapStatus.Status
+	if errors.As(err, &coapErr) && coapErr.Code() == codes.NotFound {
+		d.invalidateResourceLinks()
+	}
+}
+
+// getCachedResourceLinks returns the cached links, when they are not cached or refresh is set then the links are retrieved from the device.
+func (d *device) getCachedResourceLinks(ctx context.Context, refresh bool) (schema.ResourceLinks, bool, error) {
+	if !refresh && d.resourceLinksCacheTTL() > 0 {
+		if links, ok := d.linksCache.get(time.Now()); ok {
+			return links, true, nil
+		}
+	}
+	links, err := d.getResourceLinksAndRefreshCache(ctx)
+	return links, false, err
+}
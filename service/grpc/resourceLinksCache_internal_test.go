@@ -0,0 +1,127 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapStatus "github.com/plgd-dev/go-coap/v3/message/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLinksCache(t *testing.T) {
+	links := schema.ResourceLinks{
+		{Href: "/oic/d", Endpoints: schema.Endpoints{{URI: "coap://127.0.0.1:5683"}}},
+	}
+	now := time.Now()
+	stopped := make(chan string, 4)
+	c := newResourceLinksCache(func(observationID string) {
+		stopped <- observationID
+	})
+	_, ok := c.get(now)
+	require.False(t, ok)
+
+	observation, ok := c.store(links, now.Add(time.Minute))
+	require.True(t, ok)
+	_, ok = c.store(links, now.Add(time.Minute))
+	require.False(t, ok)
+	require.True(t, c.setObservationID(observation, "1"))
+	cached, ok := c.get(now)
+	require.True(t, ok)
+	require.Equal(t, links, cached)
+
+	// the cached links are not affected by the changes of the returned links
+	cached[0].Endpoints[0].URI = "coap://127.0.0.2:5683"
+	cached, ok = c.get(now)
+	require.True(t, ok)
+	require.Equal(t, links, cached)
+
+	_, ok = c.get(now.Add(time.Minute))
+	require.False(t, ok)
+
+	// the invalidation stops the observation
+	c.invalidate()
+	_, ok = c.get(now)
+	require.False(t, ok)
+	require.Equal(t, "1", <-stopped)
+
+	// the notifications and the close of the stopped observation are ignored
+	observation2, ok := c.store(links, now.Add(time.Minute))
+	require.True(t, ok)
+	require.NotEqual(t, observation, observation2)
+	require.False(t, c.setObservationID(observation, "1"))
+	c.invalidateObservation(observation)
+	c.stopObserving(observation)
+	_, ok = c.get(now)
+	require.True(t, ok)
+	require.True(t, c.setObservationID(observation2, "2"))
+
+	// the observation closed by the device is not stopped again
+	c.stopObserving(observation2)
+	_, ok = c.get(now)
+	require.False(t, ok)
+	c.invalidate()
+	require.Empty(t, stopped)
+
+	// the expiration stops the observation
+	observation, ok = c.store(links, time.Now().Add(time.Millisecond*10))
+	require.True(t, ok)
+	require.True(t, c.setObservationID(observation, "3"))
+	select {
+	case id := <-stopped:
+		require.Equal(t, "3", id)
+	case <-time.After(time.Second):
+		require.Fail(t, "observation is not stopped after the TTL")
+	}
+	_, ok = c.get(time.Now().Add(-time.Second))
+	require.False(t, ok)
+}
+
+func TestInvalidateResourceLinksOnNotFound(t *testing.T) {
+	d := &device{
+		getResourceLinksCacheTTL: func() time.Duration { return time.Minute },
+		linksCache:               newResourceLinksCache(nil),
+	}
+	links := schema.ResourceLinks{{Href: "/oic/d"}}
+	d.linksCache.store(links, time.Now().Add(time.Minute))
+
+	d.invalidateResourceLinksOnNotFound(errors.New("timeout"))
+	_, ok := d.linksCache.get(time.Now())
+	require.True(t, ok)
+
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(codes.BadRequest)
+	d.invalidateResourceLinksOnNotFound(coapStatus.Errorf(msg, "bad request"))
+	_, ok = d.linksCache.get(time.Now())
+	require.True(t, ok)
+
+	msg.SetCode(codes.NotFound)
+	d.invalidateResourceLinksOnNotFound(coapStatus.Errorf(msg, "not found"))
+	_, ok = d.linksCache.get(time.Now())
+	require.False(t, ok)
+
+	// the device without the cache is ignored
+	d = &device{}
+	require.Equal(t, time.Duration(0), d.resourceLinksCacheTTL())
+	d.invalidateResourceLinks()
+}
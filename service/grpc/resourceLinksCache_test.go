@@ -0,0 +1,66 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/simulator"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientApplicationServerResourceLinksCacheOnSimulator(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+	simDev := sim.Devices()[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	cfg := test.MakeSimulatorDeviceConfig()
+	const ttl = time.Second
+	cfg.COAP.ResourceLinksCache.TTL = ttl
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx, test.WithDeviceConfig(cfg))
+	require.NoError(t, err)
+	defer teardown()
+	dev, err := test.GetSimulatedDevice(ctx, s, simDev)
+	require.NoError(t, err)
+
+	getLinks := func() {
+		_, err := s.GetDeviceResourceLinks(ctx, &pb.GetDeviceResourceLinksRequest{DeviceId: dev.GetId()})
+		require.NoError(t, err)
+	}
+	isObserved := func() bool { return simDev.ResourceLinksObservers() > 0 }
+	isNotObserved := func() bool { return simDev.ResourceLinksObservers() == 0 }
+
+	// the change of the links invalidates the cache and stops the observation
+	getLinks()
+	require.Eventually(t, isObserved, time.Second*3, time.Millisecond*10)
+	err = simDev.AddResource(simulator.Resource{Href: "/light/2", ResourceTypes: []string{"core.light"}})
+	require.NoError(t, err)
+	require.Eventually(t, isNotObserved, ttl/2, time.Millisecond*10)
+
+	// the expiration of the cache stops the observation
+	getLinks()
+	require.Eventually(t, isObserved, time.Second*3, time.Millisecond*10)
+	require.Eventually(t, isNotObserved, ttl*3, time.Millisecond*10)
+}
@@ -19,17 +19,24 @@ package grpc
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/audit"
 	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/config/apikey"
 	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
 	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	"github.com/plgd-dev/client-application/service/metadata"
 	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
 	"github.com/plgd-dev/hub/v2/pkg/log"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
 	"go.uber.org/atomic"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -44,11 +51,22 @@ type ClientApplicationServer struct {
 	config             *atomic.Pointer[config.Config]
 	jwksCache          atomic.Pointer[JSONWebKeyCache]
 	remoteOwnSignCache *coapSync.Map[uuid.UUID, *remoteSign]
+	metadata           *metadata.Store
+	auditLog           *audit.Log
+	cancel             context.CancelFunc
 
-	initializationMutex sync.Mutex
+	initializationMutex   sync.Mutex
+	jwksRefreshMutex      sync.Mutex
+	jwksRefreshedAt       time.Time
+	additionalOwnersMutex sync.Mutex
 }
 
-func NewClientApplicationServer(cfg *atomic.Pointer[config.Config], devService *serviceDevice.Service, info *configGrpc.ServiceInformation, logger log.Logger) *ClientApplicationServer {
+// NewClientApplicationServer creates the server. When metadataStore is nil, the metadata of devices are kept only in memory.
+// When auditLog is nil, the state-changing operations are not recorded. The server closes the audit log.
+func NewClientApplicationServer(cfg *atomic.Pointer[config.Config], devService *serviceDevice.Service, metadataStore *metadata.Store, auditLog *audit.Log, info *configGrpc.ServiceInformation, logger log.Logger) *ClientApplicationServer {
+	if metadataStore == nil {
+		metadataStore, _ = metadata.New("")
+	}
 	csrCache := ttlcache.New[uuid.UUID, *serviceDevice.Service]()
 	go csrCache.Start()
 	var ui *pb.UIConfiguration
@@ -65,10 +83,15 @@ func NewClientApplicationServer(cfg *atomic.Pointer[config.Config], devService *
 		config:             cfg,
 		remoteOwnSignCache: coapSync.NewMap[uuid.UUID, *remoteSign](),
 		devices:            coapSync.NewMap[uuid.UUID, *device](),
+		metadata:           metadataStore,
+		auditLog:           auditLog,
 	}
 	if devService != nil {
 		s.init(context.Background(), devService)
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.refreshJSONWebKeysPeriodically(ctx)
 	return &s
 }
 
@@ -93,7 +116,79 @@ func (s *ClientApplicationServer) StoreConfig(cfg *config.Config) error {
 }
 
 func (s *ClientApplicationServer) Close() {
+	s.cancel()
 	s.csrCache.Stop()
+	if s.auditLog != nil {
+		if err := s.auditLog.Close(); err != nil {
+			s.logger.Errorf("cannot close audit log: %v", err)
+		}
+	}
+}
+
+// APIKeyMetadataKey is the metadata key of the API key, it is an alternative to the authorization bearer token.
+const APIKeyMetadataKey = "x-api-key"
+
+// HasAPIKeyAuthenticationEnabled returns true when the API keys are configured and the JWT authorization is not enabled.
+func (s *ClientApplicationServer) HasAPIKeyAuthenticationEnabled() bool {
+	if s.HasJWTAuthorizationEnabled() {
+		return false
+	}
+	cfg := s.GetConfig()
+	return cfg.APIs.APIKeys.IsEnabled()
+}
+
+func (s *ClientApplicationServer) findAPIKey(key string) (apikey.Key, bool) {
+	cfg := s.GetConfig()
+	return cfg.APIs.APIKeys.Find(key)
+}
+
+// getAPIKey returns the configured key which authenticated the request.
+func (s *ClientApplicationServer) getAPIKey(ctx context.Context) (apikey.Key, bool) {
+	token, err := pkgGrpc.TokenFromMD(ctx)
+	if err != nil {
+		return apikey.Key{}, false
+	}
+	return s.findAPIKey(token)
+}
+
+// ctxWithAPIKey sets the authorization bearer token from the x-api-key metadata, so the API key is verified by the authentication interceptor.
+func ctxWithAPIKey(ctx context.Context) context.Context {
+	md, ok := grpcMetadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(APIKeyMetadataKey)) == 0 || len(md.Get("authorization")) > 0 {
+		return ctx
+	}
+	md = md.Copy()
+	md.Set("authorization", "bearer "+md.Get(APIKeyMetadataKey)[0])
+	return grpcMetadata.NewIncomingContext(ctx, md)
+}
+
+// APIKeyUnaryInterceptor accepts the API key in the x-api-key metadata. It must precede the authentication interceptor.
+func (s *ClientApplicationServer) APIKeyUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if s.HasAPIKeyAuthenticationEnabled() {
+			ctx = ctxWithAPIKey(ctx)
+		}
+		return handler(ctx, req)
+	}
+}
+
+type apiKeyServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *apiKeyServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// APIKeyStreamInterceptor accepts the API key in the x-api-key metadata. It must precede the authentication interceptor.
+func (s *ClientApplicationServer) APIKeyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if s.HasAPIKeyAuthenticationEnabled() {
+			ss = &apiKeyServerStream{ServerStream: ss, ctx: ctxWithAPIKey(ss.Context())}
+		}
+		return handler(srv, ss)
+	}
 }
 
 func (s *ClientApplicationServer) getDevice(deviceID uuid.UUID) (*device, error) {
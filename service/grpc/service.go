@@ -26,6 +26,7 @@ import (
 	"github.com/plgd-dev/hub/v2/pkg/log"
 	"github.com/plgd-dev/hub/v2/pkg/net/grpc/server"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 type Service struct {
@@ -44,6 +45,11 @@ func New(config configGrpc.Config, clientApplicationServer *ClientApplicationSer
 	if err != nil {
 		return nil, fmt.Errorf("cannot create grpc server options: %w", err)
 	}
+	// the API key interceptors must precede the authentication interceptors of the chain
+	opts = append(opts, grpc.UnaryInterceptor(clientApplicationServer.APIKeyUnaryInterceptor()),
+		grpc.StreamInterceptor(clientApplicationServer.APIKeyStreamInterceptor()))
+	opts = append(opts, grpc.ChainUnaryInterceptor(clientApplicationServer.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(clientApplicationServer.AuthorizationStreamInterceptor()))
 
 	server, err := pkgGrpcServer.New(config, fileWatcher, logger, opts...)
 	if err != nil {
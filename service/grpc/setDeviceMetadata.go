@@ -0,0 +1,46 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ClientApplicationServer) SetDeviceMetadata(_ context.Context, req *pb.SetDeviceMetadataRequest) (*pb.DeviceMetadata, error) {
+	devID, err := strDeviceID2UUID(req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	md := metadata.Metadata{
+		Alias:  req.GetAlias(),
+		Labels: req.GetLabels(),
+		Groups: req.GetGroups(),
+	}
+	if err = md.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+	md, err = s.metadata.Set(devID, md)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set metadata of device %v: %v", devID, err)
+	}
+	return toDeviceMetadataProto(devID, md), nil
+}
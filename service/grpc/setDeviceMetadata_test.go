@@ -0,0 +1,87 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientApplicationServerSetDeviceMetadata(t *testing.T) {
+	dev := test.MustFindDeviceByName(test.DevsimName, []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV4})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+
+	_, err = s.SetDeviceMetadata(ctx, &pb.SetDeviceMetadataRequest{DeviceId: "abc"})
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+	_, err = s.SetDeviceMetadata(ctx, &pb.SetDeviceMetadataRequest{DeviceId: dev.GetId(), Labels: map[string]string{"a=b": "c"}})
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	md, err := s.SetDeviceMetadata(ctx, &pb.SetDeviceMetadataRequest{
+		DeviceId: dev.GetId(),
+		Alias:    "devsim",
+		Labels:   map[string]string{"room": "kitchen"},
+		Groups:   []string{"lights"},
+	})
+	require.NoError(t, err)
+	got, err := s.GetDeviceMetadata(ctx, &pb.GetDeviceMetadataRequest{DeviceId: dev.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, md.GetAlias(), got.GetAlias())
+	require.Equal(t, md.GetLabels(), got.GetLabels())
+	require.Equal(t, md.GetGroups(), got.GetGroups())
+
+	getDevices := func(req *pb.GetDevicesRequest) []*pb.Device {
+		srv := test.NewClientApplicationGetDevicesServer(ctx)
+		err := s.GetDevices(req, srv)
+		require.NoError(t, err)
+		return srv.Devices
+	}
+	devices := getDevices(&pb.GetDevicesRequest{LabelSelector: []string{"room=kitchen"}})
+	require.Len(t, devices, 1)
+	require.Equal(t, dev.GetId(), devices[0].GetId())
+	require.Equal(t, "devsim", devices[0].GetAlias())
+	require.Equal(t, []string{"lights"}, devices[0].GetGroups())
+
+	devices = getDevices(&pb.GetDevicesRequest{UseCache: true, GroupFilter: []string{"lights", "sensors"}})
+	require.Len(t, devices, 1)
+	devices = getDevices(&pb.GetDevicesRequest{UseCache: true, GroupFilter: []string{"sensors"}})
+	require.Empty(t, devices)
+	devices = getDevices(&pb.GetDevicesRequest{UseCache: true, LabelSelector: []string{"room!=kitchen"}})
+	require.Empty(t, devices)
+
+	err = s.GetDevices(&pb.GetDevicesRequest{UseCache: true, LabelSelector: []string{"=kitchen"}}, test.NewClientApplicationGetDevicesServer(ctx))
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	// empty metadata remove the record
+	_, err = s.SetDeviceMetadata(ctx, &pb.SetDeviceMetadataRequest{DeviceId: dev.GetId()})
+	require.NoError(t, err)
+	got, err = s.GetDeviceMetadata(ctx, &pb.GetDeviceMetadataRequest{DeviceId: dev.GetId()})
+	require.NoError(t, err)
+	require.Empty(t, got.GetAlias())
+	require.Empty(t, got.GetLabels())
+}
@@ -0,0 +1,112 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+
+	"github.com/google/uuid"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getSubnetHosts returns the unique hosts of the subnets. The network and the broadcast addresses of the IPv4 subnets are skipped.
+func getSubnetHosts(subnets []string, maxHosts int) ([]netip.Addr, error) {
+	hosts := make([]netip.Addr, 0, 256)
+	unique := make(map[netip.Addr]struct{})
+	for _, subnet := range subnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid useSubnets('%v'): %v", subnet, err)
+		}
+		prefix = prefix.Masked()
+		hostBits := prefix.Addr().BitLen() - prefix.Bits()
+		if hostBits >= 31 || 1<<hostBits > maxHosts {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid useSubnets('%v'): subnet exceeds the limit of %v hosts", subnet, maxHosts)
+		}
+		skipNetworkAndBroadcast := prefix.Addr().Is4() && hostBits > 1
+		for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+			if skipNetworkAndBroadcast && (addr == prefix.Addr() || !prefix.Contains(addr.Next())) {
+				continue
+			}
+			if _, ok := unique[addr]; ok {
+				continue
+			}
+			if len(hosts) >= maxHosts {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid useSubnets('%v'): subnets exceed the limit of %v hosts", subnets, maxHosts)
+			}
+			unique[addr] = struct{}{}
+			hosts = append(hosts, addr)
+		}
+	}
+	return hosts, nil
+}
+
+func probeSubnetHost(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, host netip.Addr, cfg configDevice.SubnetSweepConfig, devices *foundDevices) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.HostTimeout)
+	defer cancel()
+	found := newFoundDevices(nil)
+	err := getDeviceByUnicastAddress(ctx, serviceDevice, logger, netip.AddrPortFrom(host, MulticastPort).String(), found)
+	if err != nil {
+		logger.Debugf("cannot get device by address %v: %v", host, err)
+	}
+	found.Range(func(key uuid.UUID, d *device) bool {
+		stored, loaded := devices.LoadOrStore(key, d)
+		if loaded {
+			stored.update(d)
+		}
+		devices.notify(stored)
+		return true
+	})
+}
+
+// getDevicesBySubnetSweep probes the hosts by the unicast discovery with the bounded concurrency and rate. The found devices are collected to the devices and notified when the devices are streamed incrementally.
+func getDevicesBySubnetSweep(ctx context.Context, serviceDevice *serviceDevice.Service, logger log.Logger, hosts []netip.Addr, cfg configDevice.SubnetSweepConfig, devices *foundDevices) {
+	limit := rate.Inf
+	if cfg.RateLimit > 0 {
+		limit = rate.Limit(cfg.RateLimit)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+	semaphore := make(chan struct{}, cfg.MaxConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, host := range hosts {
+		if err := limiter.Wait(ctx); err != nil {
+			logger.Debugf("subnet sweep stopped: %v", err)
+			return
+		}
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func(host netip.Addr) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			probeSubnetHost(ctx, serviceDevice, logger, host, cfg, devices)
+		}(host)
+	}
+}
@@ -0,0 +1,52 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package grpc
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubnetHosts(t *testing.T) {
+	hosts, err := getSubnetHosts([]string{"192.168.1.0/30"}, 16)
+	require.NoError(t, err)
+	require.Equal(t, []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("192.168.1.2")}, hosts)
+
+	hosts, err = getSubnetHosts([]string{"192.168.1.5/32", "192.168.1.4/31", "fd00::/127"}, 16)
+	require.NoError(t, err)
+	require.Equal(t, []netip.Addr{
+		netip.MustParseAddr("192.168.1.5"),
+		netip.MustParseAddr("192.168.1.4"),
+		netip.MustParseAddr("fd00::"),
+		netip.MustParseAddr("fd00::1"),
+	}, hosts)
+
+	hosts, err = getSubnetHosts([]string{"10.0.0.0/24"}, 256)
+	require.NoError(t, err)
+	require.Len(t, hosts, 254)
+
+	_, err = getSubnetHosts([]string{"10.0.0.0/16"}, 256)
+	require.Error(t, err)
+
+	_, err = getSubnetHosts([]string{"10.0.0.0/24", "10.0.1.0/24"}, 256)
+	require.Error(t, err)
+
+	_, err = getSubnetHosts([]string{"10.0.0.1"}, 256)
+	require.Error(t, err)
+}
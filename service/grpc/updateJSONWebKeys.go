@@ -18,12 +18,12 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/plgd-dev/hub/v2/identity-store/events"
 	"github.com/plgd-dev/hub/v2/pkg/net/grpc"
-	plgdJwt "github.com/plgd-dev/hub/v2/pkg/security/jwt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -41,36 +41,34 @@ func (s *ClientApplicationServer) updateJwkCache(newCache *JSONWebKeyCache) erro
 	}
 }
 
-func (s *ClientApplicationServer) getOwnerForUpdateJSONWebKeys(ctx context.Context) (string, error) {
+func (s *ClientApplicationServer) getOwnerForUpdateJSONWebKeys(ctx context.Context) (uuid.UUID, error) {
 	token, err := grpc.TokenFromMD(ctx)
 	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "cannot get token: %v", err)
+		return uuid.Nil, status.Errorf(codes.Unauthenticated, "cannot get token: %v", err)
 	}
-	cfg := s.GetConfig()
-	if s.jwksCache.Load() == nil {
+	c := s.jwksCache.Load()
+	if c == nil {
+		cfg := s.GetConfig()
 		owner, err2 := grpc.OwnerFromTokenMD(ctx, cfg.RemoteProvisioning.GetJwtOwnerClaim())
 		if err2 != nil {
-			return "", status.Errorf(codes.Unauthenticated, "cannot get owner from token: %v", err2)
+			return uuid.Nil, status.Errorf(codes.Unauthenticated, "cannot get owner from token: %v", err2)
 		}
-		return owner, nil
-	}
-	scopedClaims := plgdJwt.NewScopeClaims()
-	err = s.ParseWithClaims(ctx, token, scopedClaims)
-	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "cannot parse token: %v", err)
-	}
-	claims := plgdJwt.Claims(*scopedClaims)
-	owner, err := claims.GetOwner(cfg.RemoteProvisioning.GetJwtOwnerClaim())
-	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "cannot get owner from token: %v", err)
-	}
-	if owner == "" {
-		return "", status.Errorf(codes.Unauthenticated, "cannot get owner from token: claim %v is not set", cfg.RemoteProvisioning.GetJwtOwnerClaim())
+		ownerUUID, err2 := uuid.Parse(events.OwnerToUUID(owner))
+		if err2 != nil {
+			return uuid.Nil, status.Errorf(codes.InvalidArgument, "cannot parse owner: %v", err2)
+		}
+		return ownerUUID, nil
 	}
-	return owner, nil
+	// only the owner can replace the keys, otherwise an additional owner could sign the tokens of the owner
+	return s.checkPrimaryOwnerToken(ctx, token)
 }
 
-func (s *ClientApplicationServer) UpdateJSONWebKeys(ctx context.Context, jwksReq *structpb.Struct) error {
+// UpdateJSONWebKeys replaces the keys used to verify the tokens. It isn't called via the audit interceptor, so it writes its own audit record.
+func (s *ClientApplicationServer) UpdateJSONWebKeys(ctx context.Context, jwksReq *structpb.Struct) (err error) {
+	start := time.Now()
+	defer func() {
+		s.writeAuditRecord(ctx, "UpdateJSONWebKeys", "", "", start, err)
+	}()
 	owner, err := s.getOwnerForUpdateJSONWebKeys(ctx)
 	if err != nil {
 		return err
@@ -85,11 +83,7 @@ func (s *ClientApplicationServer) UpdateJSONWebKeys(ctx context.Context, jwksReq
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, "cannot marshal keys: %v", err)
 	}
-	ownerUuid, err := uuid.Parse(events.OwnerToUUID(owner))
-	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "cannot parse owner: %v", err)
-	}
-	if err := s.updateJwkCache(NewJSONWebKeyCache(ownerUuid, jwks)); err != nil {
+	if err := s.updateJwkCache(NewJSONWebKeyCache(owner, jwks)); err != nil {
 		return err
 	}
 	return nil
@@ -59,7 +59,7 @@ func (s *ClientApplicationServer) UpdateResource(ctx context.Context, req *pb.Up
 	if err != nil {
 		return nil, err
 	}
-	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), req.GetResourceInterface())
+	link, err := dev.getResourceLinkAndCheckAccess(ctx, req.GetResourceId(), req.GetResourceInterface(), req.GetRefreshLinks())
 	if err != nil {
 		return nil, err
 	}
@@ -69,9 +69,12 @@ func (s *ClientApplicationServer) UpdateResource(ctx context.Context, req *pb.Up
 	if req.GetResourceInterface() != "" {
 		options = append(options, pkgCoap.WithInterface(req.GetResourceInterface()))
 	}
-	err = dev.UpdateResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), updateData, &response, options...)
+	err = dev.withRetry(ctx, req.GetRetry(), func(ctx context.Context) error {
+		return dev.UpdateResourceWithCodec(ctx, link, rawcodec.GetRawCodec(message.AppOcfCbor), updateData, &response, options...)
+	})
 	if err != nil {
-		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot update resource %v for device %v: %w", req.GetResourceId().GetHref(), dev.ID, err)).Err()
+		dev.invalidateResourceLinksOnNotFound(err)
+		return nil, convErrToGrpcStatus(codes.Unavailable, fmt.Errorf("cannot update resource %v for device %v: %w", req.GetResourceId().GetHref(), dev.ID, err), dev.newDeviceRequest(link.Href)).Err()
 	}
 	return &grpcgwPb.UpdateResourceResponse{
 		Data: &events.ResourceUpdated{
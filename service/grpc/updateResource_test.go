@@ -28,6 +28,7 @@ import (
 	"github.com/plgd-dev/device/v2/schema/configuration"
 	"github.com/plgd-dev/device/v2/schema/device"
 	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/pstat"
 	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
 	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
 	"github.com/plgd-dev/hub/v2/resource-aggregate/events"
@@ -160,3 +161,133 @@ func TestClientApplicationServerUpdateResource(t *testing.T) {
 		})
 	}
 }
+
+func TestClientApplicationServerUpdateResourceOnSimulator(t *testing.T) {
+	simCfg := test.MakeSimulatorConfig("sim-" + t.Name())
+	simCfg.Multicast = false
+	sim, tearDown := test.NewSimulator(t, simCfg)
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
+	defer cancel()
+
+	s, teardown, err := test.NewSimulatorClientApplicationServer(ctx)
+	require.NoError(t, err)
+	defer teardown()
+	dev, err := test.GetSimulatedDevice(ctx, s, sim.Devices()[0])
+	require.NoError(t, err)
+
+	type args struct {
+		req *pb.UpdateResourceRequest
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        *grpcgwPb.UpdateResourceResponse
+		wantErr     bool
+		wantErrCode codes.Code
+	}{
+		{
+			name: "doxm update",
+			args: args{
+				req: &pb.UpdateResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     doxm.ResourceURI,
+					},
+					Content: &grpcgwPb.Content{
+						ContentType: serviceHttp.ApplicationJsonContentType,
+						Data:        []byte(`{"oxmsel":0}`),
+					},
+				},
+			},
+			want: &grpcgwPb.UpdateResourceResponse{
+				Data: &events.ResourceUpdated{
+					Content: &commands.Content{},
+					Status:  commands.Status_OK,
+				},
+			},
+		},
+		{
+			name: "device resource - fail",
+			args: args{
+				req: &pb.UpdateResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     device.ResourceURI,
+					},
+					Content: &grpcgwPb.Content{
+						ContentType: serviceHttp.ApplicationJsonContentType,
+						Data:        []byte(`{"name":"test"}`),
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.PermissionDenied,
+		},
+		{
+			name: "unknown device",
+			args: args{
+				req: &pb.UpdateResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: uuid.NewString(),
+						Href:     device.ResourceURI,
+					},
+					Content: &grpcgwPb.Content{
+						ContentType: serviceHttp.ApplicationJsonContentType,
+						Data:        []byte(`{"name":"test"}`),
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.NotFound,
+		},
+		{
+			name: "unknown href",
+			args: args{
+				req: &pb.UpdateResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     "/unknown",
+					},
+					Content: &grpcgwPb.Content{
+						ContentType: serviceHttp.ApplicationJsonContentType,
+						Data:        []byte(`{"name":"test"}`),
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.NotFound,
+		},
+		{
+			name: "permission denied - cannot establish TLS connection",
+			args: args{
+				req: &pb.UpdateResourceRequest{
+					ResourceId: &commands.ResourceId{
+						DeviceId: dev.GetId(),
+						Href:     pstat.ResourceURI,
+					},
+					Content: &grpcgwPb.Content{
+						ContentType: serviceHttp.ApplicationJsonContentType,
+						Data:        []byte(`{"name":"test"}`),
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.UpdateResource(ctx, tt.args.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrCode.String(), status.Code(err).String())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
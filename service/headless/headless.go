@@ -0,0 +1,365 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/config"
+	configDevice "github.com/plgd-dev/client-application/service/config/device"
+	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
+	serviceDevice "github.com/plgd-dev/client-application/service/device"
+	serviceGrpc "github.com/plgd-dev/client-application/service/grpc"
+	"github.com/plgd-dev/go-coap/v3/message"
+	grpcgwPb "github.com/plgd-dev/hub/v2/grpc-gateway/pb"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/plgd-dev/hub/v2/resource-aggregate/commands"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Runner executes operations via ClientApplicationServer without starting HTTP and gRPC servers.
+type Runner struct {
+	deviceService           *serviceDevice.Service
+	clientApplicationServer *serviceGrpc.ClientApplicationServer
+	discoverTimeout         time.Duration
+}
+
+// New creates runner with device service configured by cfg.Clients.Device.
+func New(ctx context.Context, cfg config.Config, info *configGrpc.ServiceInformation, logger log.Logger) (*Runner, error) {
+	if err := cfg.Clients.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: clients.%w", err)
+	}
+	switch cfg.Clients.Device.COAP.TLS.Authentication {
+	case configDevice.AuthenticationPreSharedKey:
+	case configDevice.AuthenticationX509:
+		return nil, fmt.Errorf("device authentication %v is not supported in headless mode", configDevice.AuthenticationX509)
+	default:
+		return nil, errors.New("device authentication is not initialized: pre-shared key must be set")
+	}
+	deviceService, err := serviceDevice.New(ctx, func() configDevice.Config {
+		return cfg.Clients.Device
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create device service: %w", err)
+	}
+	r := &Runner{
+		deviceService:   deviceService,
+		discoverTimeout: cfg.APIs.HTTP.UI.DefaultDiscoveryTimeout,
+	}
+	if r.discoverTimeout <= 0 {
+		r.discoverTimeout = serviceGrpc.DefaultTimeout
+	}
+	// device service is served by ClientApplicationServer
+	r.clientApplicationServer = serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), deviceService, nil, nil, info, logger)
+	return r, nil
+}
+
+// ClientApplicationServer returns in-process server used to execute operations.
+func (r *Runner) ClientApplicationServer() *serviceGrpc.ClientApplicationServer {
+	return r.clientApplicationServer
+}
+
+// Close releases all resources of the runner.
+func (r *Runner) Close() error {
+	r.clientApplicationServer.Close()
+	return r.deviceService.Close()
+}
+
+// Result of one operation.
+type Result struct {
+	Operation string          `json:"operation"`
+	DeviceID  string          `json:"deviceId,omitempty"`
+	Href      string          `json:"href,omitempty"`
+	Duration  string          `json:"duration"`
+	Error     string          `json:"error,omitempty"`
+	Code      string          `json:"code,omitempty"`
+	Response  json.RawMessage `json:"response,omitempty"`
+}
+
+// Report of executed script.
+type Report struct {
+	Success bool     `json:"success"`
+	Results []Result `json:"results"`
+}
+
+type getDevicesServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	devices []*pb.Device
+}
+
+func (s *getDevicesServer) Send(d *pb.Device) error {
+	s.devices = append(s.devices, d)
+	return nil
+}
+
+func (s *getDevicesServer) Context() context.Context {
+	return s.ctx
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func marshalResponse(v proto.Message) (json.RawMessage, error) {
+	data, err := protojson.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal response: %w", err)
+	}
+	return data, nil
+}
+
+func (r *Runner) discover(ctx context.Context, req *pb.GetDevicesRequest) ([]*pb.Device, error) {
+	if req.GetTimeout() <= 0 {
+		req.Timeout = r.discoverTimeout.Nanoseconds()
+	}
+	srv := &getDevicesServer{ctx: ctx}
+	if err := r.clientApplicationServer.GetDevices(req, srv); err != nil {
+		return nil, err
+	}
+	return srv.devices, nil
+}
+
+func containsDevice(devices []*pb.Device, deviceID string) bool {
+	for _, d := range devices {
+		if d.GetId() == deviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureDevice discovers devices by multicast when the device is not in the cache. It fails with NotFound when the device isn't discovered.
+func (r *Runner) ensureDevice(ctx context.Context, deviceID string) error {
+	devices, err := r.discover(ctx, &pb.GetDevicesRequest{UseCache: true})
+	if err != nil {
+		return err
+	}
+	if containsDevice(devices, deviceID) {
+		return nil
+	}
+	devices, err = r.discover(ctx, &pb.GetDevicesRequest{})
+	if err != nil {
+		return err
+	}
+	if !containsDevice(devices, deviceID) {
+		return status.Errorf(codes.NotFound, "device %v not found", deviceID)
+	}
+	return nil
+}
+
+func (r *Runner) runDiscover(ctx context.Context, op *DiscoverOperation) (json.RawMessage, error) {
+	devices, err := r.discover(ctx, op.ToRequest())
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]json.RawMessage, 0, len(devices))
+	for _, d := range devices {
+		data, err := marshalResponse(d)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, data)
+	}
+	return json.Marshal(resp)
+}
+
+func (r *Runner) runDeviceOperation(ctx context.Context, deviceID string, do func(ctx context.Context) (proto.Message, error)) (json.RawMessage, error) {
+	if err := r.ensureDevice(ctx, deviceID); err != nil {
+		return nil, err
+	}
+	resp, err := do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return marshalResponse(resp)
+}
+
+func (r *Runner) runOnboard(ctx context.Context, op *OnboardOperation) (json.RawMessage, error) {
+	cas, err := op.getCertificateAuthorities()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return r.runDeviceOperation(ctx, op.DeviceID, func(ctx context.Context) (proto.Message, error) {
+		return r.clientApplicationServer.OnboardDevice(ctx, &pb.OnboardDeviceRequest{
+			DeviceId:                  op.DeviceID,
+			CoapGatewayAddress:        op.CoapGatewayAddress,
+			AuthorizationCode:         op.AuthorizationCode,
+			AuthorizationProviderName: op.AuthorizationProviderName,
+			HubId:                     op.HubID,
+			CertificateAuthorities:    cas,
+		})
+	})
+}
+
+func (r *Runner) runUpdateResource(ctx context.Context, op *UpdateResourceOperation) (json.RawMessage, error) {
+	data, err := json.Marshal(op.Content)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot encode content: %v", err)
+	}
+	return r.runDeviceOperation(ctx, op.DeviceID, func(ctx context.Context) (proto.Message, error) {
+		return r.clientApplicationServer.UpdateResource(ctx, &pb.UpdateResourceRequest{
+			ResourceId:        commands.NewResourceID(op.DeviceID, op.Href),
+			ResourceInterface: op.ResourceInterface,
+			Content: &grpcgwPb.Content{
+				ContentType: message.AppJSON.String(),
+				Data:        data,
+			},
+		})
+	})
+}
+
+func (r *Runner) runApplyManifest(ctx context.Context, op *ApplyManifestOperation) (json.RawMessage, error) {
+	req, err := LoadManifest(op.Manifest)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.DryRun = req.GetDryRun() || op.DryRun
+	discovered := false
+	for _, m := range req.GetDevices() {
+		switch {
+		case m.GetDeviceId() != "":
+			err = r.ensureDevice(ctx, m.GetDeviceId())
+		case !discovered:
+			// devices are selected by type from the cache
+			_, err = r.discover(ctx, &pb.GetDevicesRequest{})
+			discovered = true
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp, err := r.clientApplicationServer.ApplyManifest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := marshalResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	failed := 0
+	for _, step := range resp.GetSteps() {
+		if step.GetStatus() == pb.ManifestStep_FAILED {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return data, status.Errorf(codes.Aborted, "%v steps of the manifest failed", failed)
+	}
+	return data, nil
+}
+
+func (r *Runner) runOperation(ctx context.Context, op *Operation) (json.RawMessage, error) {
+	switch {
+	case op.Discover != nil:
+		return r.runDiscover(ctx, op.Discover)
+	case op.Own != nil:
+		return r.runDeviceOperation(ctx, op.Own.DeviceID, func(ctx context.Context) (proto.Message, error) {
+			return r.clientApplicationServer.OwnDevice(ctx, &pb.OwnDeviceRequest{DeviceId: op.Own.DeviceID})
+		})
+	case op.Disown != nil:
+		return r.runDeviceOperation(ctx, op.Disown.DeviceID, func(ctx context.Context) (proto.Message, error) {
+			return r.clientApplicationServer.DisownDevice(ctx, &pb.DisownDeviceRequest{DeviceId: op.Disown.DeviceID})
+		})
+	case op.GetResource != nil:
+		return r.runDeviceOperation(ctx, op.GetResource.DeviceID, func(ctx context.Context) (proto.Message, error) {
+			return r.clientApplicationServer.GetResource(ctx, &pb.GetResourceRequest{
+				ResourceId:        commands.NewResourceID(op.GetResource.DeviceID, op.GetResource.Href),
+				ResourceInterface: op.GetResource.ResourceInterface,
+			})
+		})
+	case op.UpdateResource != nil:
+		return r.runUpdateResource(ctx, op.UpdateResource)
+	case op.Onboard != nil:
+		return r.runOnboard(ctx, op.Onboard)
+	case op.ApplyManifest != nil:
+		return r.runApplyManifest(ctx, op.ApplyManifest)
+	case op.Offboard != nil:
+		return r.runDeviceOperation(ctx, op.Offboard.DeviceID, func(ctx context.Context) (proto.Message, error) {
+			return r.clientApplicationServer.OffboardDevice(ctx, &pb.OffboardDeviceRequest{DeviceId: op.Offboard.DeviceID})
+		})
+	}
+	return nil, status.Error(codes.InvalidArgument, "operation is not set")
+}
+
+func operationDetails(op *Operation) (string, string, time.Duration) {
+	switch {
+	case op.Discover != nil:
+		return "", "", 0
+	case op.Own != nil:
+		return op.Own.DeviceID, "", op.Own.Timeout
+	case op.Disown != nil:
+		return op.Disown.DeviceID, "", op.Disown.Timeout
+	case op.GetResource != nil:
+		return op.GetResource.DeviceID, op.GetResource.Href, op.GetResource.Timeout
+	case op.UpdateResource != nil:
+		return op.UpdateResource.DeviceID, op.UpdateResource.Href, op.UpdateResource.Timeout
+	case op.Onboard != nil:
+		return op.Onboard.DeviceID, "", op.Onboard.Timeout
+	case op.Offboard != nil:
+		return op.Offboard.DeviceID, "", op.Offboard.Timeout
+	case op.ApplyManifest != nil:
+		return "", "", op.ApplyManifest.Timeout
+	}
+	return "", "", 0
+}
+
+// Run executes operations of the script in order. It stops at the first failure unless script.ContinueOnError is set.
+func (r *Runner) Run(ctx context.Context, script *Script) *Report {
+	report := &Report{
+		Success: true,
+		Results: make([]Result, 0, len(script.Operations)),
+	}
+	for idx := range script.Operations {
+		op := &script.Operations[idx]
+		deviceID, href, timeout := operationDetails(op)
+		start := time.Now()
+		opCtx, cancel := withTimeout(ctx, timeout)
+		resp, err := r.runOperation(opCtx, op)
+		cancel()
+		result := Result{
+			Operation: op.Name(),
+			DeviceID:  deviceID,
+			Href:      href,
+			Duration:  time.Since(start).String(),
+			Response:  resp,
+		}
+		if err != nil {
+			report.Success = false
+			result.Error = err.Error()
+			result.Code = status.Code(err).String()
+		}
+		report.Results = append(report.Results, result)
+		if err != nil && !script.ContinueOnError {
+			break
+		}
+	}
+	return report
+}
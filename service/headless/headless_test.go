@@ -0,0 +1,66 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/service/config"
+	"github.com/plgd-dev/client-application/service/headless"
+	"github.com/plgd-dev/client-application/test"
+	"github.com/plgd-dev/hub/v2/pkg/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRunnerReportsDeviceNotFound(t *testing.T) {
+	sim, tearDown := test.NewSimulator(t, test.MakeSimulatorConfig("sim-"+t.Name()))
+	defer tearDown()
+	simDev := sim.Devices()[0]
+
+	var cfg config.Config
+	cfg.Log = log.MakeDefaultConfig()
+	cfg.Clients.Device = test.MakeSimulatorDeviceConfig()
+	cfg.APIs.HTTP.UI.DefaultDiscoveryTimeout = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	r, err := headless.New(ctx, cfg, test.NewServiceInformation().GetBuildInfo(), log.NewLogger(cfg.Log))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+
+	unknownID := uuid.NewString()
+	report := r.Run(ctx, &headless.Script{
+		ContinueOnError: true,
+		Operations: []headless.Operation{
+			{Own: &headless.DeviceOperation{DeviceID: simDev.ID().String()}},
+			{Disown: &headless.DeviceOperation{DeviceID: simDev.ID().String()}},
+			{Own: &headless.DeviceOperation{DeviceID: unknownID}},
+		},
+	})
+	require.False(t, report.Success)
+	require.Len(t, report.Results, 3)
+	require.Empty(t, report.Results[0].Error)
+	require.Empty(t, report.Results[1].Error)
+	require.Equal(t, unknownID, report.Results[2].DeviceID)
+	require.Equal(t, codes.NotFound.String(), report.Results[2].Code)
+	require.Equal(t, "rpc error: code = NotFound desc = device "+unknownID+" not found", report.Results[2].Error)
+}
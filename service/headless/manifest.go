@@ -0,0 +1,57 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/plgd-dev/client-application/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseManifest decodes YAML or JSON manifest in the format of ApplyManifestRequest.
+func ParseManifest(data []byte) (*pb.ApplyManifestRequest, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	var req pb.ApplyManifestRequest
+	if err = protojson.Unmarshal(jsonData, &req); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if len(req.GetDevices()) == 0 {
+		return nil, errors.New("invalid manifest: devices are empty")
+	}
+	return &req, nil
+}
+
+// LoadManifest reads manifest from the file.
+func LoadManifest(path string) (*pb.ApplyManifestRequest, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
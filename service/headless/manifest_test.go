@@ -0,0 +1,62 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/headless"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifest(t *testing.T) {
+	req, err := headless.ParseManifest([]byte(`
+dryRun: true
+devices:
+  - deviceId: 00000000-0000-0000-0000-000000000001
+    owned: true
+    resources:
+      - href: /light/1
+        content:
+          power: 42
+    accessControls:
+      - subjectConnectionType: anon-clear
+        hrefs: [/light/1]
+        permissions: [READ]
+    onboard:
+      coapGatewayAddress: coaps+tcp://localhost:5684
+      authorizationCode: code
+      authorizationProviderName: plgd
+      hubId: hub
+  - type_filter: [oic.d.light]
+`))
+	require.NoError(t, err)
+	require.True(t, req.GetDryRun())
+	require.Len(t, req.GetDevices(), 2)
+	dev := req.GetDevices()[0]
+	require.True(t, dev.GetOwned())
+	require.Equal(t, float64(42), dev.GetResources()[0].GetContent().GetStructValue().AsMap()["power"])
+	require.Equal(t, []pb.AccessControlManifest_Permission{pb.AccessControlManifest_READ}, dev.GetAccessControls()[0].GetPermissions())
+	require.Equal(t, "hub", dev.GetOnboard().GetHubId())
+	require.Equal(t, []string{"oic.d.light"}, req.GetDevices()[1].GetTypeFilter())
+
+	_, err = headless.ParseManifest([]byte(`devices: []`))
+	require.Error(t, err)
+	_, err = headless.ParseManifest([]byte(`devices: [{unknown: true}]`))
+	require.Error(t, err)
+}
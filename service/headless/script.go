@@ -0,0 +1,280 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/pb"
+	"gopkg.in/yaml.v3"
+)
+
+// Script is a sequence of operations executed by the headless mode.
+type Script struct {
+	// ContinueOnError executes the remaining operations even when some operation fails.
+	ContinueOnError bool        `yaml:"continueOnError" json:"continueOnError"`
+	Operations      []Operation `yaml:"operations" json:"operations"`
+}
+
+func (s *Script) Validate() error {
+	if len(s.Operations) == 0 {
+		return errors.New("operations('[]') - is empty")
+	}
+	for idx := range s.Operations {
+		if err := s.Operations[idx].Validate(); err != nil {
+			return fmt.Errorf("operations[%v].%w", idx, err)
+		}
+	}
+	return nil
+}
+
+// Operation contains exactly one of the supported operations.
+type Operation struct {
+	Discover       *DiscoverOperation       `yaml:"discover,omitempty" json:"discover,omitempty"`
+	Own            *DeviceOperation         `yaml:"own,omitempty" json:"own,omitempty"`
+	Disown         *DeviceOperation         `yaml:"disown,omitempty" json:"disown,omitempty"`
+	GetResource    *ResourceOperation       `yaml:"getResource,omitempty" json:"getResource,omitempty"`
+	UpdateResource *UpdateResourceOperation `yaml:"updateResource,omitempty" json:"updateResource,omitempty"`
+	Onboard        *OnboardOperation        `yaml:"onboard,omitempty" json:"onboard,omitempty"`
+	Offboard       *DeviceOperation         `yaml:"offboard,omitempty" json:"offboard,omitempty"`
+	ApplyManifest  *ApplyManifestOperation  `yaml:"applyManifest,omitempty" json:"applyManifest,omitempty"`
+}
+
+type operationValidator interface {
+	Validate() error
+}
+
+func (o *Operation) set() (string, operationValidator) {
+	ops := make(map[string]operationValidator, 1)
+	if o.Discover != nil {
+		ops[OperationDiscover] = o.Discover
+	}
+	if o.Own != nil {
+		ops[OperationOwn] = o.Own
+	}
+	if o.Disown != nil {
+		ops[OperationDisown] = o.Disown
+	}
+	if o.GetResource != nil {
+		ops[OperationGetResource] = o.GetResource
+	}
+	if o.UpdateResource != nil {
+		ops[OperationUpdateResource] = o.UpdateResource
+	}
+	if o.Onboard != nil {
+		ops[OperationOnboard] = o.Onboard
+	}
+	if o.Offboard != nil {
+		ops[OperationOffboard] = o.Offboard
+	}
+	if o.ApplyManifest != nil {
+		ops[OperationApplyManifest] = o.ApplyManifest
+	}
+	if len(ops) != 1 {
+		return "", nil
+	}
+	for name, op := range ops {
+		return name, op
+	}
+	return "", nil
+}
+
+// Name returns name of the operation or empty string when operation is not set properly.
+func (o *Operation) Name() string {
+	name, _ := o.set()
+	return name
+}
+
+func (o *Operation) Validate() error {
+	name, op := o.set()
+	if op == nil {
+		return errors.New("exactly one operation must be set")
+	}
+	if err := op.Validate(); err != nil {
+		return fmt.Errorf("%v.%w", name, err)
+	}
+	return nil
+}
+
+const (
+	OperationDiscover       = "discover"
+	OperationOwn            = "own"
+	OperationDisown         = "disown"
+	OperationGetResource    = "getResource"
+	OperationUpdateResource = "updateResource"
+	OperationOnboard        = "onboard"
+	OperationOffboard       = "offboard"
+	OperationApplyManifest  = "applyManifest"
+)
+
+type DiscoverOperation struct {
+	Timeout      time.Duration `yaml:"timeout" json:"timeout"`
+	UseMulticast []string      `yaml:"useMulticast" json:"useMulticast"`
+	UseEndpoints []string      `yaml:"useEndpoints" json:"useEndpoints"`
+}
+
+func (o *DiscoverOperation) Validate() error {
+	for idx, m := range o.UseMulticast {
+		if _, ok := pb.GetDevicesRequest_UseMulticast_value[m]; !ok {
+			return fmt.Errorf("useMulticast[%v]('%v') - supports only '%v,%v'", idx, m, pb.GetDevicesRequest_IPV4, pb.GetDevicesRequest_IPV6)
+		}
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("timeout('%v')", o.Timeout)
+	}
+	return nil
+}
+
+func (o *DiscoverOperation) ToRequest() *pb.GetDevicesRequest {
+	useMulticast := make([]pb.GetDevicesRequest_UseMulticast, 0, len(o.UseMulticast))
+	for _, m := range o.UseMulticast {
+		useMulticast = append(useMulticast, pb.GetDevicesRequest_UseMulticast(pb.GetDevicesRequest_UseMulticast_value[m]))
+	}
+	return &pb.GetDevicesRequest{
+		UseMulticast: useMulticast,
+		UseEndpoints: o.UseEndpoints,
+		Timeout:      o.Timeout.Nanoseconds(),
+	}
+}
+
+type DeviceOperation struct {
+	DeviceID string        `yaml:"deviceId" json:"deviceId"`
+	Timeout  time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+func validateDeviceID(deviceID string) error {
+	if _, err := uuid.Parse(deviceID); err != nil {
+		return fmt.Errorf("deviceId('%v') - %w", deviceID, err)
+	}
+	return nil
+}
+
+func (o *DeviceOperation) Validate() error {
+	return validateDeviceID(o.DeviceID)
+}
+
+type ResourceOperation struct {
+	DeviceOperation   `yaml:",inline" json:",inline"`
+	Href              string `yaml:"href" json:"href"`
+	ResourceInterface string `yaml:"resourceInterface" json:"resourceInterface"`
+}
+
+func (o *ResourceOperation) Validate() error {
+	if err := o.DeviceOperation.Validate(); err != nil {
+		return err
+	}
+	if o.Href == "" {
+		return fmt.Errorf("href('%v') - is empty", o.Href)
+	}
+	return nil
+}
+
+type UpdateResourceOperation struct {
+	ResourceOperation `yaml:",inline" json:",inline"`
+	// Content is encoded to JSON and sent to the device.
+	Content interface{} `yaml:"content" json:"content"`
+}
+
+func (o *UpdateResourceOperation) Validate() error {
+	if err := o.ResourceOperation.Validate(); err != nil {
+		return err
+	}
+	if _, err := json.Marshal(o.Content); err != nil {
+		return fmt.Errorf("content('%v') - %w", o.Content, err)
+	}
+	return nil
+}
+
+type OnboardOperation struct {
+	DeviceOperation           `yaml:",inline" json:",inline"`
+	CoapGatewayAddress        string `yaml:"coapGatewayAddress" json:"coapGatewayAddress"`
+	AuthorizationCode         string `yaml:"authorizationCode" json:"authorizationCode"`
+	AuthorizationProviderName string `yaml:"authorizationProviderName" json:"authorizationProviderName"`
+	HubID                     string `yaml:"hubId" json:"hubId"`
+	// CertificateAuthorities contains PEM encoded certificates or a path to the file with them.
+	CertificateAuthorities string `yaml:"certificateAuthorities" json:"certificateAuthorities"`
+}
+
+func (o *OnboardOperation) Validate() error {
+	if err := o.DeviceOperation.Validate(); err != nil {
+		return err
+	}
+	if o.CoapGatewayAddress == "" {
+		return fmt.Errorf("coapGatewayAddress('%v') - is empty", o.CoapGatewayAddress)
+	}
+	if o.AuthorizationProviderName == "" {
+		return fmt.Errorf("authorizationProviderName('%v') - is empty", o.AuthorizationProviderName)
+	}
+	if o.HubID == "" {
+		return fmt.Errorf("hubId('%v') - is empty", o.HubID)
+	}
+	return nil
+}
+
+func (o *OnboardOperation) getCertificateAuthorities() (string, error) {
+	if o.CertificateAuthorities == "" {
+		return "", nil
+	}
+	if _, err := os.Stat(o.CertificateAuthorities); err != nil {
+		return o.CertificateAuthorities, nil
+	}
+	data, err := os.ReadFile(o.CertificateAuthorities)
+	if err != nil {
+		return "", fmt.Errorf("cannot read certificate authorities from file %v: %w", o.CertificateAuthorities, err)
+	}
+	return string(data), nil
+}
+
+type ApplyManifestOperation struct {
+	// Manifest is a path to the YAML or JSON file with ApplyManifestRequest.
+	Manifest string        `yaml:"manifest" json:"manifest"`
+	DryRun   bool          `yaml:"dryRun" json:"dryRun"`
+	Timeout  time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+func (o *ApplyManifestOperation) Validate() error {
+	if o.Manifest == "" {
+		return fmt.Errorf("manifest('%v') - is empty", o.Manifest)
+	}
+	return nil
+}
+
+// ParseScript decodes YAML or JSON script and validates it.
+func ParseScript(data []byte) (*Script, error) {
+	var s Script
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	return &s, nil
+}
+
+// LoadScript reads script from the file.
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("cannot read script: %w", err)
+	}
+	return ParseScript(data)
+}
@@ -0,0 +1,135 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package headless_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/service/headless"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScript(t *testing.T) {
+	const deviceID = "00000000-0000-0000-0000-000000000001"
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `
+operations:
+  - discover:
+      timeout: 1s
+      useMulticast: [IPV4]
+  - own:
+      deviceId: ` + deviceID + `
+  - updateResource:
+      deviceId: ` + deviceID + `
+      href: /light/1
+      content:
+        state: true
+  - onboard:
+      deviceId: ` + deviceID + `
+      coapGatewayAddress: coaps+tcp://localhost:5684
+      authorizationProviderName: plgd
+      hubId: hub
+`,
+			want: []string{headless.OperationDiscover, headless.OperationOwn, headless.OperationUpdateResource, headless.OperationOnboard},
+		},
+		{
+			name: "valid json",
+			data: `{"continueOnError": true, "operations": [{"disown": {"deviceId": "` + deviceID + `"}}]}`,
+			want: []string{headless.OperationDisown},
+		},
+		{
+			name:    "empty",
+			data:    `operations: []`,
+			wantErr: true,
+		},
+		{
+			name: "two operations in one item",
+			data: `
+operations:
+  - own:
+      deviceId: ` + deviceID + `
+    disown:
+      deviceId: ` + deviceID + `
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid deviceId",
+			data: `
+operations:
+  - own:
+      deviceId: abc
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing href",
+			data: `
+operations:
+  - getResource:
+      deviceId: ` + deviceID + `
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid multicast",
+			data: `
+operations:
+  - discover:
+      useMulticast: [IPV5]
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := headless.ParseScript([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got := make([]string, 0, len(s.Operations))
+			for _, op := range s.Operations {
+				got = append(got, op.Name())
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscoverOperationToRequest(t *testing.T) {
+	op := headless.DiscoverOperation{
+		Timeout:      time.Second,
+		UseMulticast: []string{pb.GetDevicesRequest_IPV6.String()},
+		UseEndpoints: []string{"127.0.0.1:5683"},
+	}
+	require.NoError(t, op.Validate())
+	req := op.ToRequest()
+	require.Equal(t, time.Second.Nanoseconds(), req.GetTimeout())
+	require.Equal(t, []pb.GetDevicesRequest_UseMulticast{pb.GetDevicesRequest_IPV6}, req.GetUseMulticast())
+	require.Equal(t, []string{"127.0.0.1:5683"}, req.GetUseEndpoints())
+}
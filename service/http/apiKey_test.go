@@ -0,0 +1,70 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/service/config/apikey"
+	serviceHttp "github.com/plgd-dev/client-application/service/http"
+	"github.com/plgd-dev/client-application/test"
+	httpgwTest "github.com/plgd-dev/hub/v2/http-gateway/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientApplicationServerAPIKey(t *testing.T) {
+	cfg := test.MakeConfig(t)
+	cfg.APIs.HTTP.TLS.ClientCertificateRequired = false
+	cfg.APIs.APIKeys.Keys = []apikey.Key{
+		{Name: "reader", Hash: apikey.Hash("reader-key"), Scopes: []string{"devices:read"}},
+	}
+	shutDown := test.New(t, cfg)
+	defer shutDown()
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		wantCode int
+	}{
+		{name: "api key header", header: serviceHttp.APIKeyHeader, value: "reader-key", wantCode: http.StatusOK},
+		{name: "bearer", header: "Authorization", value: "Bearer reader-key", wantCode: http.StatusOK},
+		{name: "rejected api key header", header: serviceHttp.APIKeyHeader, value: "invalid-key", wantCode: http.StatusUnauthorized},
+		{name: "rejected bearer", header: "Authorization", value: "Bearer invalid-key", wantCode: http.StatusUnauthorized},
+		{name: "missing", wantCode: http.StatusUnauthorized},
+	}
+	// the diagnostics are served by the HTTP handler, the metadata by the grpc-gateway which forwards the key to the gRPC interceptors
+	paths := map[string]string{
+		"diagnostics": serviceHttp.Diagnostics,
+		"metadata":    serviceHttp.DeviceMetadata,
+	}
+	for pathName, path := range paths {
+		for _, tt := range tests {
+			t.Run(pathName+" "+tt.name, func(t *testing.T) {
+				request := httpgwTest.NewRequest(http.MethodGet, path, nil).Host(test.CLIENT_APPLICATION_HTTP_HOST).DeviceId(uuid.NewString()).Build()
+				if tt.header != "" {
+					request.Header.Set(tt.header, tt.value)
+				}
+				resp := httpgwTest.HTTPDo(t, request)
+				_ = resp.Body.Close()
+				require.Equal(t, tt.wantCode, resp.StatusCode)
+			})
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/hub/v2/http-gateway/serverMux"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	ApplicationGzipContentType = "application/gzip"
+	DiagnosticsFileName        = "diagnostics.tar.gz"
+)
+
+// getDiagnostics returns the archive directly instead of GetDiagnosticsResponse unless protojson is accepted.
+func (requestHandler *RequestHandler) getDiagnostics(w http.ResponseWriter, r *http.Request) {
+	body, ok := requestHandler.serveProtoJSON(w, r)
+	if !ok {
+		return
+	}
+	var resp pb.GetDiagnosticsResponse
+	if err := protojson.Unmarshal(body, &resp); err != nil {
+		serverMux.WriteError(w, pkgGrpc.ForwardErrorf(codes.Internal, "cannot get diagnostics: %v", err))
+		return
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+DiagnosticsFileName+"\"")
+	writeRawData(w, ApplicationGzipContentType, resp.GetData())
+}
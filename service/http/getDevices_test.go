@@ -43,7 +43,7 @@ func getDevices(t *testing.T, token string) {
 	resp := httpgwTest.HTTPDo(t, request.Build())
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 	for {
-		var dev grpcgwPb.Device
+		var dev pb.Device
 		err := pkgHttpPb.Unmarshal(resp.StatusCode, resp.Body, &dev)
 		if errors.Is(err, io.EOF) {
 			break
@@ -113,9 +113,9 @@ func TestClientApplicationServerGetDevices(t *testing.T) {
 				_ = resp.Body.Close()
 			}()
 
-			var got []*grpcgwPb.Device
+			var got []*pb.Device
 			for {
-				var dev grpcgwPb.Device
+				var dev pb.Device
 				err := pkgHttpPb.Unmarshal(resp.StatusCode, resp.Body, &dev)
 				if errors.Is(err, io.EOF) {
 					break
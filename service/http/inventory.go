@@ -0,0 +1,118 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/hub/v2/http-gateway/serverMux"
+	pkgGrpc "github.com/plgd-dev/hub/v2/pkg/net/grpc"
+	pkgHttp "github.com/plgd-dev/hub/v2/pkg/net/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const TextCSVContentType = "text/csv"
+
+func inventoryFormatContentType(format pb.InventoryFormat) string {
+	if format == pb.InventoryFormat_CSV {
+		return TextCSVContentType
+	}
+	return ApplicationJsonContentType
+}
+
+func contentTypeToInventoryFormat(contentType string) pb.InventoryFormat {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == TextCSVContentType {
+		return pb.InventoryFormat_CSV
+	}
+	return pb.InventoryFormat_JSON
+}
+
+// serveProtoJSON serves the request by the mux with the protojson response. It returns the body of the successful
+// response when the client doesn't accept protojson, otherwise the response is written to w and it returns false.
+func (requestHandler *RequestHandler) serveProtoJSON(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Header.Get(pkgHttp.AcceptHeaderKey) == pkgHttp.ApplicationProtoJsonContentType {
+		requestHandler.mux.ServeHTTP(w, r)
+		return nil, false
+	}
+	r.Header.Set(pkgHttp.AcceptHeaderKey, pkgHttp.ApplicationProtoJsonContentType)
+	rec := httptest.NewRecorder()
+	requestHandler.mux.ServeHTTP(rec, r)
+	if rec.Code != http.StatusOK {
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = rec.Body.WriteTo(w)
+		return nil, false
+	}
+	return rec.Body.Bytes(), true
+}
+
+func writeRawData(w http.ResponseWriter, contentType string, data []byte) {
+	w.Header().Set(pkgHttp.ContentTypeHeaderKey, contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// exportInventory returns the exported data directly instead of ExportInventoryResponse unless protojson is accepted.
+func (requestHandler *RequestHandler) exportInventory(w http.ResponseWriter, r *http.Request) {
+	body, ok := requestHandler.serveProtoJSON(w, r)
+	if !ok {
+		return
+	}
+	var resp pb.ExportInventoryResponse
+	if err := protojson.Unmarshal(body, &resp); err != nil {
+		serverMux.WriteError(w, pkgGrpc.ForwardErrorf(codes.Internal, "cannot export inventory: %v", err))
+		return
+	}
+	writeRawData(w, inventoryFormatContentType(resp.GetFormat()), resp.GetData())
+}
+
+// importInventory wraps the raw data to ImportInventoryRequest unless the body is protojson.
+func (requestHandler *RequestHandler) importInventory(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get(pkgHttp.ContentTypeHeaderKey)
+	if contentType == pkgHttp.ApplicationProtoJsonContentType {
+		requestHandler.mux.ServeHTTP(w, r)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		serverMux.WriteError(w, pkgGrpc.ForwardErrorf(codes.InvalidArgument, "cannot import inventory: %v", fmt.Errorf("read body: %w", err)))
+		return
+	}
+	reqData, err := protojson.Marshal(&pb.ImportInventoryRequest{
+		Format: contentTypeToInventoryFormat(contentType),
+		Data:   data,
+	})
+	if err != nil {
+		serverMux.WriteError(w, pkgGrpc.ForwardErrorf(codes.InvalidArgument, "cannot import inventory: %v", fmt.Errorf("cannot marshal to protojson: %w", err)))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(reqData))
+	r.ContentLength = int64(len(reqData))
+	r.Header.Set(pkgHttp.ContentTypeHeaderKey, pkgHttp.ApplicationProtoJsonContentType)
+	requestHandler.mux.ServeHTTP(w, r)
+}
@@ -89,11 +89,6 @@ func createAuthFunc(config configHttp.Config, clientApplicationServer *grpc.Clie
 			Method: http.MethodGet,
 			URI:    regexp.MustCompile(regexp.QuoteMeta(WellKnownConfiguration)),
 		},
-		{
-			// token is directly verified by clientApplication
-			Method: http.MethodPost,
-			URI:    regexp.MustCompile(regexp.QuoteMeta(Initialize)),
-		},
 	}
 	if config.UI.Enabled {
 		whiteList = append(whiteList, pkgHttpJwt.RequestMatcher{
@@ -101,11 +96,21 @@ func createAuthFunc(config configHttp.Config, clientApplicationServer *grpc.Clie
 			URI:    regexp.MustCompile(`^\/(a$|[^a].*|ap$|a[^p].*|ap[^i].*|api[^/])`),
 		})
 	}
+	// the API key is also required by the initialization
+	apiKeyAuth := pkgHttpJwt.NewInterceptorWithValidator(clientApplicationServer, kitNetHttp.NewDefaultAuthorizationRules(ApiV1), whiteList...)
+	whiteList = append(whiteList, pkgHttpJwt.RequestMatcher{
+		// token is directly verified by clientApplication
+		Method: http.MethodPost,
+		URI:    regexp.MustCompile(regexp.QuoteMeta(Initialize)),
+	})
 	auth := pkgHttpJwt.NewInterceptorWithValidator(clientApplicationServer, kitNetHttp.NewDefaultAuthorizationRules(ApiV1), whiteList...)
 	return func(ctx context.Context, method, uri string) (context.Context, error) {
 		if clientApplicationServer.HasJWTAuthorizationEnabled() {
 			return auth(ctx, method, uri)
 		}
+		if clientApplicationServer.HasAPIKeyAuthenticationEnabled() {
+			return apiKeyAuth(ctx, method, uri)
+		}
 		return ctx, nil
 	}
 }
@@ -204,14 +209,16 @@ func New(ctx context.Context, serviceName string, config configHttp.Config, clie
 		return nil, fmt.Errorf("cannot create grpc server: %w", err)
 	}
 
-	ch := new(inprocgrpc.Channel)
+	ch := new(inprocgrpc.Channel).
+		WithServerUnaryInterceptor(clientApplicationServer.UnaryInterceptor()).
+		WithServerStreamInterceptor(clientApplicationServer.AuthorizationStreamInterceptor())
 	pb.RegisterClientApplicationServer(ch, clientApplicationServer)
 	grpcClient := pb.NewClientApplicationClient(ch)
 
 	auth := createAuthFunc(config, clientApplicationServer)
 	mux := serverMux.New()
 	r := serverMux.NewRouter(queryCaseInsensitive, auth)
-	handler := newCORSHandler(config, r)
+	handler := withAPIKey(newCORSHandler(config, r))
 
 	// register grpc-proxy handler
 	if err := pb.RegisterClientApplicationHandlerClient(ctx, mux, grpcClient); err != nil {
@@ -221,6 +228,9 @@ func New(ctx context.Context, serviceName string, config configHttp.Config, clie
 	requestHandler := &RequestHandler{mux: mux, clientApplicationServer: clientApplicationServer, config: config}
 	r.PathPrefix(Devices).Methods(http.MethodPut).MatcherFunc(resourceMatcher).HandlerFunc(requestHandler.updateResource)
 	r.PathPrefix(Devices).Methods(http.MethodPost).MatcherFunc(resourceMatcher).HandlerFunc(requestHandler.createResource)
+	r.Path(Inventory).Methods(http.MethodGet).HandlerFunc(requestHandler.exportInventory)
+	r.Path(Inventory).Methods(http.MethodPost).HandlerFunc(requestHandler.importInventory)
+	r.Path(Diagnostics).Methods(http.MethodGet).HandlerFunc(requestHandler.getDiagnostics)
 	r.PathPrefix(ApiV1).Handler(mux)
 	r.PathPrefix(WellKnown).Handler(mux)
 
@@ -261,19 +271,41 @@ func (s *Service) Address() string {
 }
 
 const (
-	UseCacheQueryKey              = "useCache"
-	UseMulticastQueryKey          = "useMulticast"
-	UseEndpointsQueryKey          = "useEndpoints"
-	TimeoutQueryKey               = "timeout"
-	OwnershipStatusFilterQueryKey = "ownershipStatusFilter"
-	TypeFilterQueryKey            = "typeFilter"
+	UseCacheQueryKey               = "useCache"
+	UseMulticastQueryKey           = "useMulticast"
+	UseEndpointsQueryKey           = "useEndpoints"
+	TimeoutQueryKey                = "timeout"
+	OwnershipStatusFilterQueryKey  = "ownershipStatusFilter"
+	TypeFilterQueryKey             = "typeFilter"
+	FormatQueryKey                 = "format"
+	DeviceIDFilterQueryKey         = "deviceIdFilter"
+	ResourceHrefsQueryKey          = "resourceHrefs"
+	LabelSelectorQueryKey          = "labelSelector"
+	GroupFilterQueryKey            = "groupFilter"
+	NameFilterQueryKey             = "nameFilter"
+	NameRegexFilterQueryKey        = "nameRegexFilter"
+	DeviceIDPrefixFilterQueryKey   = "deviceIdPrefixFilter"
+	SubnetFilterQueryKey           = "subnetFilter"
+	SchemeFilterQueryKey           = "schemeFilter"
+	OnboardingStatusFilterQueryKey = "onboardingStatusFilter"
 )
 
 var queryCaseInsensitive = map[string]string{
-	strings.ToLower(UseCacheQueryKey):              UseCacheQueryKey,
-	strings.ToLower(UseMulticastQueryKey):          UseMulticastQueryKey,
-	strings.ToLower(UseEndpointsQueryKey):          UseEndpointsQueryKey,
-	strings.ToLower(TimeoutQueryKey):               TimeoutQueryKey,
-	strings.ToLower(OwnershipStatusFilterQueryKey): OwnershipStatusFilterQueryKey,
-	strings.ToLower(TypeFilterQueryKey):            TypeFilterQueryKey,
+	strings.ToLower(UseCacheQueryKey):               UseCacheQueryKey,
+	strings.ToLower(UseMulticastQueryKey):           UseMulticastQueryKey,
+	strings.ToLower(UseEndpointsQueryKey):           UseEndpointsQueryKey,
+	strings.ToLower(TimeoutQueryKey):                TimeoutQueryKey,
+	strings.ToLower(OwnershipStatusFilterQueryKey):  OwnershipStatusFilterQueryKey,
+	strings.ToLower(TypeFilterQueryKey):             TypeFilterQueryKey,
+	strings.ToLower(FormatQueryKey):                 FormatQueryKey,
+	strings.ToLower(DeviceIDFilterQueryKey):         DeviceIDFilterQueryKey,
+	strings.ToLower(ResourceHrefsQueryKey):          ResourceHrefsQueryKey,
+	strings.ToLower(LabelSelectorQueryKey):          LabelSelectorQueryKey,
+	strings.ToLower(GroupFilterQueryKey):            GroupFilterQueryKey,
+	strings.ToLower(NameFilterQueryKey):             NameFilterQueryKey,
+	strings.ToLower(NameRegexFilterQueryKey):        NameRegexFilterQueryKey,
+	strings.ToLower(DeviceIDPrefixFilterQueryKey):   DeviceIDPrefixFilterQueryKey,
+	strings.ToLower(SubnetFilterQueryKey):           SubnetFilterQueryKey,
+	strings.ToLower(SchemeFilterQueryKey):           SchemeFilterQueryKey,
+	strings.ToLower(OnboardingStatusFilterQueryKey): OnboardingStatusFilterQueryKey,
 }
@@ -41,7 +41,11 @@ const (
 	DisownDevice        = Device + "/disown"
 	OnboardDevice       = Device + "/onboard"
 	OffboardDevice      = Device + "/offboard"
+	DeviceMetadata      = Device + "/metadata"
 
+	Manifest               = ApiV1 + "/manifest"
+	Inventory              = ApiV1 + "/inventory"
+	Diagnostics            = ApiV1 + "/diagnostics"
 	Initialize             = ApiV1 + "/initialize"
 	Reset                  = ApiV1 + "/reset"
 	IdentityCertificate    = Identity + "/certificate"
@@ -0,0 +1,115 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type selectorOperator int
+
+const (
+	selectorEquals selectorOperator = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+// LabelSelector matches labels of the device.
+type LabelSelector struct {
+	key      string
+	value    string
+	operator selectorOperator
+}
+
+// ValidateLabelKey checks that the key can be used in the label selector.
+func ValidateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("key('%v') - is empty", key)
+	}
+	if strings.ContainsAny(key, "=!,") || strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("key('%v') - contains invalid characters", key)
+	}
+	return nil
+}
+
+// ParseLabelSelector parses selector in format <key>=<value>, <key>!=<value>, <key> or !<key>.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	s := strings.TrimSpace(selector)
+	var ls LabelSelector
+	switch {
+	case strings.Contains(s, "!="):
+		ls.operator = selectorNotEquals
+		ls.key, ls.value, _ = strings.Cut(s, "!=")
+	case strings.Contains(s, "="):
+		ls.operator = selectorEquals
+		ls.key, ls.value, _ = strings.Cut(s, "=")
+	case strings.HasPrefix(s, "!"):
+		ls.operator = selectorNotExists
+		ls.key = s[1:]
+	default:
+		ls.operator = selectorExists
+		ls.key = s
+	}
+	ls.key = strings.TrimSpace(ls.key)
+	ls.value = strings.TrimSpace(ls.value)
+	if err := ValidateLabelKey(ls.key); err != nil {
+		return LabelSelector{}, fmt.Errorf("invalid selector('%v'): %w", selector, err)
+	}
+	return ls, nil
+}
+
+// ParseLabelSelectors parses all selectors.
+func ParseLabelSelectors(selectors []string) ([]LabelSelector, error) {
+	result := make([]LabelSelector, 0, len(selectors))
+	for _, s := range selectors {
+		ls, err := ParseLabelSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ls)
+	}
+	return result, nil
+}
+
+// Match returns true when the labels satisfy the selector.
+func (ls LabelSelector) Match(labels map[string]string) bool {
+	v, ok := labels[ls.key]
+	switch ls.operator {
+	case selectorEquals:
+		return ok && v == ls.value
+	case selectorNotEquals:
+		return !ok || v != ls.value
+	case selectorExists:
+		return ok
+	case selectorNotExists:
+		return !ok
+	}
+	return false
+}
+
+// MatchLabelSelectors returns true when the labels satisfy all selectors.
+func MatchLabelSelectors(selectors []LabelSelector, labels map[string]string) bool {
+	for _, ls := range selectors {
+		if !ls.Match(labels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/client-application/service/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"room": "kitchen", "floor": "1"}
+	tests := []struct {
+		selector string
+		want     bool
+		wantErr  bool
+	}{
+		{selector: "room=kitchen", want: true},
+		{selector: "room=bedroom", want: false},
+		{selector: "room!=bedroom", want: true},
+		{selector: "room!=kitchen", want: false},
+		{selector: "building!=a", want: true},
+		{selector: "floor", want: true},
+		{selector: "building", want: false},
+		{selector: "!building", want: true},
+		{selector: "!floor", want: false},
+		{selector: " room = kitchen ", want: true},
+		{selector: "", wantErr: true},
+		{selector: "=kitchen", wantErr: true},
+		{selector: "!", wantErr: true},
+		{selector: "my room=kitchen", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			ls, err := metadata.ParseLabelSelector(tt.selector)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ls.Match(labels))
+		})
+	}
+}
+
+func TestMatchLabelSelectors(t *testing.T) {
+	selectors, err := metadata.ParseLabelSelectors([]string{"room=kitchen", "!building"})
+	require.NoError(t, err)
+	require.True(t, metadata.MatchLabelSelectors(selectors, map[string]string{"room": "kitchen"}))
+	require.False(t, metadata.MatchLabelSelectors(selectors, map[string]string{"room": "kitchen", "building": "a"}))
+	require.False(t, metadata.MatchLabelSelectors(selectors, nil))
+	require.True(t, metadata.MatchLabelSelectors(nil, nil))
+
+	_, err = metadata.ParseLabelSelectors([]string{"room=kitchen", ""})
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/service/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata are user-defined data of the device which are not stored on the device.
+type Metadata struct {
+	Alias  string            `yaml:"alias,omitempty" json:"alias,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Groups []string          `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+func (m Metadata) IsEmpty() bool {
+	return m.Alias == "" && len(m.Labels) == 0 && len(m.Groups) == 0
+}
+
+func (m Metadata) Clone() Metadata {
+	return Metadata{
+		Alias:  m.Alias,
+		Labels: maps.Clone(m.Labels),
+		Groups: slices.Clone(m.Groups),
+	}
+}
+
+func (m Metadata) Validate() error {
+	for k := range m.Labels {
+		if err := ValidateLabelKey(k); err != nil {
+			return fmt.Errorf("labels.%w", err)
+		}
+	}
+	for i, g := range m.Groups {
+		if g == "" {
+			return fmt.Errorf("groups[%v]('%v') - is empty", i, g)
+		}
+	}
+	return nil
+}
+
+// HasOneOfGroups returns true when the device is a member of one of the groups.
+func (m Metadata) HasOneOfGroups(groups []string) bool {
+	for _, g := range groups {
+		if slices.Contains(m.Groups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store keeps metadata of devices in memory and persists them to the file when the file path is set.
+type Store struct {
+	filePath string
+
+	mutex   sync.RWMutex
+	devices map[uuid.UUID]Metadata
+}
+
+// New creates the store and loads the metadata from the file. Empty file path means that the metadata are not persisted.
+func New(filePath string) (*Store, error) {
+	s := &Store{
+		filePath: filePath,
+		devices:  make(map[uuid.UUID]Metadata),
+	}
+	if filePath == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read metadata file %v: %w", filePath, err)
+	}
+	var devices map[string]Metadata
+	if err = yaml.Unmarshal(data, &devices); err != nil {
+		return nil, fmt.Errorf("cannot decode metadata file %v: %w", filePath, err)
+	}
+	for id, md := range devices {
+		deviceID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device id('%v') in metadata file %v: %w", id, filePath, err)
+		}
+		if err = md.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid metadata of device %v in metadata file %v: %w", id, filePath, err)
+		}
+		if md.IsEmpty() {
+			continue
+		}
+		s.devices[deviceID] = md
+	}
+	return s, nil
+}
+
+// Get returns a copy of the metadata of the device. The zero value is returned when the metadata were not set.
+func (s *Store) Get(deviceID uuid.UUID) Metadata {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.devices[deviceID].Clone()
+}
+
+func (s *Store) storeLocked() error {
+	if s.filePath == "" {
+		return nil
+	}
+	devices := make(map[string]Metadata, len(s.devices))
+	for id, md := range s.devices {
+		devices[id.String()] = md
+	}
+	if err := config.Store(devices, s.filePath); err != nil {
+		return fmt.Errorf("cannot store metadata to file %v: %w", s.filePath, err)
+	}
+	return nil
+}
+
+// Update atomically modifies the metadata of the device and persists the store. Empty metadata are removed.
+func (s *Store) Update(deviceID uuid.UUID, update func(md Metadata) Metadata) (Metadata, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	old, ok := s.devices[deviceID]
+	md := update(old.Clone())
+	if err := md.Validate(); err != nil {
+		return Metadata{}, err
+	}
+	if md.IsEmpty() {
+		delete(s.devices, deviceID)
+	} else {
+		s.devices[deviceID] = md.Clone()
+	}
+	if err := s.storeLocked(); err != nil {
+		// rollback
+		if ok {
+			s.devices[deviceID] = old
+		} else {
+			delete(s.devices, deviceID)
+		}
+		return Metadata{}, err
+	}
+	return md.Clone(), nil
+}
+
+// Set replaces the metadata of the device.
+func (s *Store) Set(deviceID uuid.UUID, md Metadata) (Metadata, error) {
+	return s.Update(deviceID, func(Metadata) Metadata {
+		return md
+	})
+}
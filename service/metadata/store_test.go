@@ -0,0 +1,98 @@
+// ************************************************************************
+// Copyright (C) 2022 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package metadata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/client-application/service/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "metadata.yaml")
+	s, err := metadata.New(filePath)
+	require.NoError(t, err)
+
+	deviceID := uuid.New()
+	require.True(t, s.Get(deviceID).IsEmpty())
+
+	md := metadata.Metadata{
+		Alias:  "kitchen light",
+		Labels: map[string]string{"room": "kitchen", "floor": "1"},
+		Groups: []string{"lights"},
+	}
+	got, err := s.Set(deviceID, md)
+	require.NoError(t, err)
+	require.Equal(t, md, got)
+	require.Equal(t, md, s.Get(deviceID))
+
+	// returned metadata are copies
+	got.Labels["room"] = "bedroom"
+	require.Equal(t, "kitchen", s.Get(deviceID).Labels["room"])
+
+	_, err = s.Set(deviceID, metadata.Metadata{Labels: map[string]string{"a=b": "c"}})
+	require.Error(t, err)
+	require.Equal(t, md, s.Get(deviceID))
+
+	// metadata are loaded from the file
+	s1, err := metadata.New(filePath)
+	require.NoError(t, err)
+	require.Equal(t, md, s1.Get(deviceID))
+
+	got, err = s1.Update(deviceID, func(md metadata.Metadata) metadata.Metadata {
+		md.Alias = ""
+		return md
+	})
+	require.NoError(t, err)
+	require.Empty(t, got.Alias)
+	require.Equal(t, md.Labels, got.Labels)
+
+	// empty metadata are removed
+	_, err = s1.Set(deviceID, metadata.Metadata{})
+	require.NoError(t, err)
+	s2, err := metadata.New(filePath)
+	require.NoError(t, err)
+	require.True(t, s2.Get(deviceID).IsEmpty())
+}
+
+func TestStoreInMemory(t *testing.T) {
+	s, err := metadata.New("")
+	require.NoError(t, err)
+	deviceID := uuid.New()
+	_, err = s.Set(deviceID, metadata.Metadata{Alias: "a"})
+	require.NoError(t, err)
+	require.Equal(t, "a", s.Get(deviceID).Alias)
+}
+
+func TestStoreInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "metadata.yaml")
+	err := os.WriteFile(filePath, []byte("invalid: [\n"), 0o600)
+	require.NoError(t, err)
+	_, err = metadata.New(filePath)
+	require.Error(t, err)
+
+	err = os.WriteFile(filePath, []byte("abc:\n  alias: a\n"), 0o600)
+	require.NoError(t, err)
+	_, err = metadata.New(filePath)
+	require.Error(t, err)
+}
@@ -21,12 +21,16 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/plgd-dev/client-application/pkg/simulator"
+	"github.com/plgd-dev/client-application/service/audit"
 	"github.com/plgd-dev/client-application/service/config"
 	configDevice "github.com/plgd-dev/client-application/service/config/device"
 	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
+	configSimulator "github.com/plgd-dev/client-application/service/config/simulator"
 	"github.com/plgd-dev/client-application/service/device"
 	"github.com/plgd-dev/client-application/service/grpc"
 	"github.com/plgd-dev/client-application/service/http"
+	"github.com/plgd-dev/client-application/service/metadata"
 	"github.com/plgd-dev/hub/v2/pkg/fn"
 	"github.com/plgd-dev/hub/v2/pkg/fsnotify"
 	"github.com/plgd-dev/hub/v2/pkg/log"
@@ -88,23 +92,63 @@ func closeServicesOnError(err error, services []service.APIService) error {
 	return fmt.Errorf("%v", errors)
 }
 
+func newSimulator(cfg configSimulator.Config, logger log.Logger) (*simulator.Simulator, error) {
+	deviceCfgs, err := cfg.MakeDeviceConfigs()
+	if err != nil {
+		return nil, err
+	}
+	if len(deviceCfgs) == 0 {
+		return nil, nil
+	}
+	sim, err := simulator.New(deviceCfgs, logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range sim.Devices() {
+		log.Infof("simulated device %v(%v) available on %v", d.Name(), d.ID(), d.UDPAddress())
+	}
+	return sim, nil
+}
+
 // New creates server.
 func New(ctx context.Context, cfg config.Config, info *configGrpc.ServiceInformation, fileWatcher *fsnotify.Watcher, logger log.Logger) (*service.Service, error) {
 	tracerProvider := noop.NewTracerProvider()
 	var closerFunc fn.FuncList
 	config := atomic.NewPointer(&cfg)
+	metadataStore, err := metadata.New(cfg.Metadata.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create metadata store: %w", err)
+	}
+	auditLog, err := audit.New(cfg.Audit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create audit log: %w", err)
+	}
 	var deviceService *device.Service
-	var err error
 	if cfg.Clients.Device.COAP.TLS.Authentication != configDevice.AuthenticationUninitialized {
 		deviceService, err = device.New(ctx, func() configDevice.Config {
 			return config.Load().Clients.Device
 		}, logger)
 		if err != nil {
+			if auditLog != nil {
+				_ = auditLog.Close()
+			}
 			return nil, fmt.Errorf("cannot create device service: %w", err)
 		}
 	}
-	clientApplicationServer := grpc.NewClientApplicationServer(config, deviceService, info, logger)
+	clientApplicationServer := grpc.NewClientApplicationServer(config, deviceService, metadataStore, auditLog, info, logger)
 	closerFunc.AddFunc(clientApplicationServer.Close)
+	sim, err := newSimulator(cfg.Simulator, logger)
+	if err != nil {
+		closerFunc.Execute()
+		return nil, fmt.Errorf("cannot create simulator: %w", err)
+	}
+	if sim != nil {
+		closerFunc.AddFunc(func() {
+			if errC := sim.Close(); errC != nil {
+				log.Errorf("cannot close simulator: %v", errC)
+			}
+		})
+	}
 	services := make([]service.APIService, 0, 2)
 	if cfg.APIs.HTTP.Enabled {
 		httpService, err := newHttpService(ctx, cfg, clientApplicationServer, fileWatcher, logger, tracerProvider)
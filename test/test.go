@@ -26,9 +26,12 @@ import (
 	"time"
 
 	"github.com/plgd-dev/client-application/pb"
+	"github.com/plgd-dev/client-application/pkg/logbuffer"
 	"github.com/plgd-dev/client-application/pkg/net/grpc/server"
 	"github.com/plgd-dev/client-application/pkg/net/listener"
+	"github.com/plgd-dev/client-application/pkg/simulator"
 	"github.com/plgd-dev/client-application/service"
+	"github.com/plgd-dev/client-application/service/audit"
 	"github.com/plgd-dev/client-application/service/config"
 	configDevice "github.com/plgd-dev/client-application/service/config/device"
 	configGrpc "github.com/plgd-dev/client-application/service/config/grpc"
@@ -183,7 +186,7 @@ func MakeHttpConfig() config.HTTPConfig {
 			},
 			CORS: configHttp.CORSConfig{
 				AllowedOrigins: []string{"*"},
-				AllowedHeaders: []string{"Accept", "Accept-Language", "Accept-Encoding", "Content-Type", "Content-Language", "Content-Length", "Origin", "X-CSRF-Token", "Authorization"},
+				AllowedHeaders: []string{"Accept", "Accept-Language", "Accept-Encoding", "Content-Type", "Content-Language", "Content-Length", "Origin", "X-CSRF-Token", "Authorization", "X-API-Key"},
 				AllowedMethods: []string{"GET", "PATCH", "HEAD", "POST", "PUT", "OPTIONS", "DELETE"},
 			},
 		},
@@ -286,6 +289,7 @@ func NewServiceInformation() *pb.GetConfigurationResponse {
 type ClientApplicationServerCfg struct {
 	Cfg                   configDevice.Config
 	RemoteProvisioningCfg *pb.RemoteProvisioning
+	AuditLog              *audit.Log
 }
 
 type ClientApplicationServerOpt = func(c *ClientApplicationServerCfg)
@@ -302,6 +306,13 @@ func WithRemoteProvisioningConfig(cfg *pb.RemoteProvisioning) ClientApplicationS
 	}
 }
 
+// WithAuditLog sets the audit log, the client application server closes it.
+func WithAuditLog(auditLog *audit.Log) ClientApplicationServerOpt {
+	return func(c *ClientApplicationServerCfg) {
+		c.AuditLog = auditLog
+	}
+}
+
 func NewClientApplicationServer(ctx context.Context, opts ...ClientApplicationServerOpt) (*serviceGrpc.ClientApplicationServer, func(), error) {
 	cfg, err := MakeConfig2()
 	if err != nil {
@@ -314,15 +325,42 @@ func NewClientApplicationServer(ctx context.Context, opts ...ClientApplicationSe
 	for _, o := range opts {
 		o(&updateCfg)
 	}
+	return newClientApplicationServer(ctx, cfg, updateCfg)
+}
+
+// MakeSimulatorDeviceConfig returns configuration of the device client for the simulated devices, which support only the just works ownership transfer.
+func MakeSimulatorDeviceConfig() configDevice.Config {
+	cfg := MakeDeviceConfig()
+	cfg.COAP.OwnershipTransfer.Methods = []configDevice.OwnershipTransferMethod{configDevice.OwnershipTransferJustWorks}
+	return cfg
+}
+
+// NewSimulatorClientApplicationServer creates the client application server for the simulated devices. The remote provisioning
+// is not configured, so the certificates of the hub are not required.
+func NewSimulatorClientApplicationServer(ctx context.Context, opts ...ClientApplicationServerOpt) (*serviceGrpc.ClientApplicationServer, func(), error) {
+	var cfg config.Config
+	cfg.Log = log.MakeDefaultConfig()
+	updateCfg := ClientApplicationServerCfg{
+		Cfg: MakeSimulatorDeviceConfig(),
+	}
+	for _, o := range opts {
+		o(&updateCfg)
+	}
+	return newClientApplicationServer(ctx, cfg, updateCfg)
+}
+
+func newClientApplicationServer(ctx context.Context, cfg config.Config, updateCfg ClientApplicationServerCfg) (*serviceGrpc.ClientApplicationServer, func(), error) {
 	deviceCfg := updateCfg.Cfg
-	if err = deviceCfg.Validate(); err != nil {
+	if err := deviceCfg.Validate(); err != nil {
 		return nil, nil, err
 	}
 	remoteProvisioningCfg := updateCfg.RemoteProvisioningCfg
-	if err = remoteProvisioningCfg.Validate(); err != nil {
-		return nil, nil, err
+	if remoteProvisioningCfg != nil {
+		if err := remoteProvisioningCfg.Validate(); err != nil {
+			return nil, nil, err
+		}
 	}
-	logger := log.NewLogger(cfg.Log)
+	logger := logbuffer.NewLogger(log.NewLogger(cfg.Log), logbuffer.New(logbuffer.DefaultSize))
 	d, err := serviceDevice.New(ctx, func() configDevice.Config {
 		return deviceCfg
 	}, logger)
@@ -337,7 +375,7 @@ func NewClientApplicationServer(ctx context.Context, opts ...ClientApplicationSe
 	}()
 	cfg.RemoteProvisioning = remoteProvisioningCfg
 	cfg.Clients.Device = deviceCfg
-	clientApplicationServer := serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), d, NewServiceInformation().GetBuildInfo(), logger)
+	clientApplicationServer := serviceGrpc.NewClientApplicationServer(atomic.NewPointer(&cfg), d, nil, updateCfg.AuditLog, NewServiceInformation().GetBuildInfo(), logger)
 	return clientApplicationServer, func() {
 		_ = d.Close()
 		clientApplicationServer.Close()
@@ -347,7 +385,7 @@ func NewClientApplicationServer(ctx context.Context, opts ...ClientApplicationSe
 
 type ClientApplicationGetDevicesServer struct {
 	grpc.ServerStream
-	Devices []*grpcgwPb.Device
+	Devices []*pb.Device
 	Ctx     context.Context
 }
 
@@ -357,7 +395,7 @@ func NewClientApplicationGetDevicesServer(ctx context.Context) *ClientApplicatio
 	}
 }
 
-func (s *ClientApplicationGetDevicesServer) Send(d *grpcgwPb.Device) error {
+func (s *ClientApplicationGetDevicesServer) Send(d *pb.Device) error {
 	s.Devices = append(s.Devices, d)
 	return nil
 }
@@ -388,7 +426,7 @@ func FindDeviceByName(name string, useMulticast []pb.GetDevicesRequest_UseMultic
 				continue
 			}
 			if dev.Name == name {
-				return d, nil
+				return d.ToGrpcGatewayDevice(), nil
 			}
 		}
 	}
@@ -403,6 +441,41 @@ func MustFindDeviceByName(name string, useMulticast []pb.GetDevicesRequest_UseMu
 	return d
 }
 
+// MakeSimulatorConfig returns configuration of a simulated device with the /light/1 resource of devsim.
+func MakeSimulatorConfig(name string) simulator.Config {
+	cfg := simulator.MakeLightConfig(name)
+	cfg.ResourceTypes = []string{"oic.d.cloudDevice"}
+	cfg.Multicast = true
+	return cfg
+}
+
+// GetSimulatedDevice discovers the simulated device via its unicast endpoint, so the device is also cached by the client application server.
+func GetSimulatedDevice(ctx context.Context, s *serviceGrpc.ClientApplicationServer, dev *simulator.Device) (*grpcgwPb.Device, error) {
+	srv := NewClientApplicationGetDevicesServer(ctx)
+	err := s.GetDevices(&pb.GetDevicesRequest{
+		UseEndpoints: []string{dev.UDPAddress()},
+	}, srv)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range srv.Devices {
+		if d.GetId() == dev.ID().String() {
+			return d.ToGrpcGatewayDevice(), nil
+		}
+	}
+	return nil, fmt.Errorf("device %s not found", dev.Name())
+}
+
+// NewSimulator starts simulated devices, the devices with enabled multicast can be found by FindDeviceByName.
+func NewSimulator(t *testing.T, cfgs ...simulator.Config) (*simulator.Simulator, func()) {
+	s, err := simulator.New(cfgs, log.NewLogger(log.MakeDefaultConfig()))
+	require.NoError(t, err)
+	return s, func() {
+		err := s.Close()
+		require.NoError(t, err)
+	}
+}
+
 func GetDeviceResourceLinks() schema.ResourceLinks {
 	resources := make(schema.ResourceLinks, 0, len(deviceTest.TestDevsimResources)+len(deviceTest.TestDevsimPrivateResources)+len(deviceTest.TestDevsimSecResources))
 	resources = append(resources, deviceTest.TestDevsimResources...)